@@ -0,0 +1,176 @@
+// Package audit provides an audit log that durably records all availability status transitions of a
+// service (of the aggregated system status as well as of each component). The recorded history can be
+// queried afterwards, e.g., for postmortems or SLO reporting.
+package audit
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/alexliesenfeld/health/interceptors"
+)
+
+type (
+	// Record is an entry of the audit log that describes a single status transition.
+	Record struct {
+		// Component is the name of the check. It is empty for transitions of the aggregated system status.
+		Component string `json:"component,omitempty"`
+		// From is the availability status before the transition.
+		From health.AvailabilityStatus `json:"from"`
+		// To is the availability status after the transition.
+		To health.AvailabilityStatus `json:"to"`
+		// Error is the error message of the check function (empty if successful).
+		Error string `json:"error,omitempty"`
+		// Timestamp is the time of when the transition was detected.
+		Timestamp time.Time `json:"timestamp"`
+	}
+
+	// Query filters the records that are returned by Log.History.
+	Query struct {
+		// Component only returns records of the check with this name. If empty, records of all checks
+		// and of the aggregated system status are returned.
+		Component string
+		// Since only returns records that were created at or after this time. If zero, no time filter is applied.
+		Since time.Time
+		// Limit is the maximum number of records that are returned. If zero, all matching records are returned.
+		Limit int
+	}
+
+	// Store persists audit log records.
+	Store interface {
+		// Append adds a record to the store.
+		Append(ctx context.Context, record Record) error
+		// Query returns all records that match the query, ordered from newest to oldest.
+		// Stores that cannot be queried return ErrQueryNotSupported.
+		Query(ctx context.Context, query Query) ([]Record, error)
+	}
+
+	// Log records status transitions in a Store. Component transitions are recorded by using Log.Interceptor
+	// (or by passing the Log as a sink to interceptors.RecordTransitions), transitions of the aggregated system
+	// status are recorded by using Log.StatusListener.
+	Log struct {
+		store        Store
+		mtx          sync.Mutex
+		systemStatus health.AvailabilityStatus
+		pending      []Record
+		done         chan struct{}
+	}
+)
+
+// ErrQueryNotSupported is returned by stores that can only be written to (see NewWriterStore).
+var ErrQueryNotSupported = errors.New("store does not support queries")
+
+// New creates a new Log that records status transitions in the provided Store.
+func New(store Store) *Log {
+	return &Log{store: store, systemStatus: health.StatusUnknown}
+}
+
+// Interceptor creates a health.Interceptor that records status transitions of checks.
+// Use it with health.WithInterceptors (for all checks) or health.Check.Interceptors (for individual checks).
+func (l *Log) Interceptor() health.Interceptor {
+	return interceptors.RecordTransitions(l)
+}
+
+// StatusListener returns a status listener that records transitions of the aggregated system status.
+// Use it with health.WithStatusListener. The listener returns immediately, so that the health.Checker is not
+// blocked by the Store: records are appended one after another (and in order) by a background goroutine.
+// Use Flush to wait until all records have been appended (e.g., when the service shuts down).
+func (l *Log) StatusListener() func(ctx context.Context, state health.CheckerState) {
+	return func(_ context.Context, state health.CheckerState) {
+		l.mtx.Lock()
+		defer l.mtx.Unlock()
+
+		l.pending = append(l.pending, Record{From: l.systemStatus, To: state.Status, Timestamp: time.Now().UTC()})
+		l.systemStatus = state.Status
+		if l.done == nil {
+			l.done = make(chan struct{})
+			go l.appendPending(l.done)
+		}
+	}
+}
+
+// Flush blocks until all records of the status listener (see StatusListener) have been appended to the Store
+// or the context is done.
+func (l *Log) Flush(ctx context.Context) error {
+	l.mtx.Lock()
+	done := l.done
+	l.mtx.Unlock()
+
+	if done == nil {
+		return nil
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Record records a transition of a check. It implements interceptors.TransitionSink.
+func (l *Log) Record(ctx context.Context, transition interceptors.Transition) {
+	record := Record{
+		Component: transition.Check,
+		From:      transition.From,
+		To:        transition.To,
+		Timestamp: transition.Timestamp,
+	}
+	if transition.Error != nil {
+		record.Error = transition.Error.Error()
+	}
+
+	l.append(ctx, record)
+}
+
+// History returns the recorded transitions that match the provided query, ordered from newest to oldest.
+// Records of the status listener that have not been appended yet are awaited (see Flush).
+func (l *Log) History(ctx context.Context, query Query) ([]Record, error) {
+	if err := l.Flush(ctx); err != nil {
+		return nil, err
+	}
+
+	return l.store.Query(ctx, query)
+}
+
+// append stores the record. Errors are logged using the standard logger (see package log),
+// because listeners and interceptors cannot return them.
+func (l *Log) append(ctx context.Context, record Record) {
+	if err := l.store.Append(ctx, record); err != nil {
+		log.Printf("cannot append record to audit log: %v", err)
+	}
+}
+
+// appendPending appends the pending records of the status listener until no new records have been added
+// in the meantime.
+func (l *Log) appendPending(done chan struct{}) {
+	defer close(done)
+
+	for {
+		l.mtx.Lock()
+		records := l.pending
+		l.pending = nil
+		if len(records) == 0 {
+			l.done = nil
+			l.mtx.Unlock()
+			return
+		}
+		l.mtx.Unlock()
+
+		for _, record := range records {
+			// The context of the listener ends with the health check, so records are appended using their own context.
+			l.append(context.Background(), record)
+		}
+	}
+}
+
+func (q *Query) matches(record *Record) bool {
+	if q.Component != "" && q.Component != record.Component {
+		return false
+	}
+	return q.Since.IsZero() || !record.Timestamp.Before(q.Since)
+}
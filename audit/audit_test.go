@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogRecordsSystemAndComponentTransitions(t *testing.T) {
+	// Arrange
+	auditLog := New(NewMemoryStore(10))
+	interceptor := auditLog.Interceptor()
+	listener := auditLog.StatusListener()
+
+	// Act
+	interceptor(func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+		return health.CheckState{Status: health.StatusDown, Result: errors.New("connection refused")}
+	})(context.Background(), "database", health.CheckState{Status: health.StatusUp})
+	listener(context.Background(), health.CheckerState{Status: health.StatusDown})
+
+	// Assert
+	history, err := auditLog.History(context.Background(), Query{})
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, Record{From: health.StatusUnknown, To: health.StatusDown, Timestamp: history[0].Timestamp}, history[0])
+	assert.Equal(t, Record{
+		Component: "database",
+		From:      health.StatusUp,
+		To:        health.StatusDown,
+		Error:     "connection refused",
+		Timestamp: history[1].Timestamp,
+	}, history[1])
+}
+
+func TestMemoryStoreQuery(t *testing.T) {
+	// Arrange
+	store := NewMemoryStore(3)
+	now := time.Now().UTC()
+	for i, component := range []string{"a", "b", "a", "b"} {
+		require.NoError(t, store.Append(context.Background(), Record{
+			Component: component,
+			To:        health.StatusDown,
+			Timestamp: now.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+
+	// Act
+	all, _ := store.Query(context.Background(), Query{})
+	limited, _ := store.Query(context.Background(), Query{Component: "b", Limit: 1})
+	since, _ := store.Query(context.Background(), Query{Since: now.Add(2 * time.Minute)})
+
+	// Assert
+	assert.Len(t, all, 3)
+	assert.Equal(t, []Record{{Component: "b", To: health.StatusDown, Timestamp: now.Add(3 * time.Minute)}}, limited)
+	assert.Len(t, since, 2)
+}
+
+func TestLogStatusListenerDoesNotBlockOnStore(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+	store := &blockingStore{Store: NewMemoryStore(10), release: release}
+	auditLog := New(store)
+	listener := auditLog.StatusListener()
+
+	// Act
+	listener(context.Background(), health.CheckerState{Status: health.StatusDown})
+	listener(context.Background(), health.CheckerState{Status: health.StatusUp})
+	close(release)
+	history, err := auditLog.History(context.Background(), Query{})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, health.StatusDown, history[0].From)
+	assert.Equal(t, health.StatusUp, history[0].To)
+	assert.Equal(t, health.StatusUnknown, history[1].From)
+	assert.Equal(t, health.StatusDown, history[1].To)
+}
+
+func TestMemoryStoreWithNegativeSize(t *testing.T) {
+	// Arrange
+	store := NewMemoryStore(-1)
+
+	// Act
+	err := store.Append(context.Background(), Record{To: health.StatusDown})
+	records, _ := store.Query(context.Background(), Query{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+type blockingStore struct {
+	Store
+	release chan struct{}
+}
+
+func (s *blockingStore) Append(ctx context.Context, record Record) error {
+	<-s.release
+	return s.Store.Append(ctx, record)
+}
@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+type (
+	// SQLStore is a Store that inserts records into a database table. The table must already exist
+	// and have the following columns (the column types may vary depending on the database):
+	//
+	//	CREATE TABLE health_audit_log (
+	//	    component   VARCHAR(255) NOT NULL,
+	//	    from_status VARCHAR(16)  NOT NULL,
+	//	    to_status   VARCHAR(16)  NOT NULL,
+	//	    error       TEXT         NOT NULL,
+	//	    timestamp   TIMESTAMP    NOT NULL
+	//	);
+	SQLStore struct {
+		db          *sql.DB
+		table       string
+		placeholder func(n int) string
+	}
+
+	// SQLOption is a configuration option for a SQLStore.
+	SQLOption func(s *SQLStore)
+)
+
+// WithDollarPlaceholders configures the SQLStore to use numbered placeholders ($1, $2, ...) in
+// queries, as required by PostgreSQL drivers. By default, question mark placeholders are used.
+func WithDollarPlaceholders() SQLOption {
+	return func(s *SQLStore) {
+		s.placeholder = func(n int) string {
+			return fmt.Sprintf("$%d", n)
+		}
+	}
+}
+
+// NewSQLStore creates a new SQLStore that inserts records into the provided table.
+func NewSQLStore(db *sql.DB, table string, options ...SQLOption) *SQLStore {
+	s := SQLStore{db: db, table: table, placeholder: func(int) string { return "?" }}
+	for _, opt := range options {
+		opt(&s)
+	}
+	return &s
+}
+
+// Append inserts the record into the table.
+func (s *SQLStore) Append(ctx context.Context, record Record) error {
+	query := fmt.Sprintf("INSERT INTO %s (component, from_status, to_status, error, timestamp) VALUES (%s, %s, %s, %s, %s)",
+		s.table, s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4), s.placeholder(5))
+
+	_, err := s.db.ExecContext(ctx, query,
+		record.Component, string(record.From), string(record.To), record.Error, record.Timestamp.UTC())
+	if err != nil {
+		return fmt.Errorf("cannot insert audit log record: %w", err)
+	}
+
+	return nil
+}
+
+// Query returns all records that match the query, ordered from newest to oldest.
+func (s *SQLStore) Query(ctx context.Context, query Query) ([]Record, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	if query.Component != "" {
+		args = append(args, query.Component)
+		conditions = append(conditions, "component = "+s.placeholder(len(args)))
+	}
+	if !query.Since.IsZero() {
+		args = append(args, query.Since.UTC())
+		conditions = append(conditions, "timestamp >= "+s.placeholder(len(args)))
+	}
+
+	stmt := fmt.Sprintf("SELECT component, from_status, to_status, error, timestamp FROM %s", s.table)
+	if len(conditions) > 0 {
+		stmt += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	stmt += " ORDER BY timestamp DESC"
+	if query.Limit > 0 {
+		stmt += fmt.Sprintf(" LIMIT %d", query.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot query audit log records: %w", err)
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var record Record
+		if err := rows.Scan(&record.Component, &record.From, &record.To, &record.Error, &record.Timestamp); err != nil {
+			return nil, fmt.Errorf("cannot read audit log record: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
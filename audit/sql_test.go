@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingDriver is a minimal database/sql driver that records all statements and
+// answers queries with the rows that were inserted before.
+type recordingDriver struct {
+	mtx        sync.Mutex
+	statements []string
+	args       [][]driver.Value
+	rows       [][]driver.Value
+}
+
+type (
+	recordingConn struct{ driver *recordingDriver }
+	recordingStmt struct {
+		driver *recordingDriver
+		query  string
+	}
+	recordingRows struct {
+		rows [][]driver.Value
+		next int
+	}
+)
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) { return &recordingConn{driver: d}, nil }
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return &recordingStmt{driver: c.driver, query: query}, nil
+}
+func (c *recordingConn) Close() error              { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+func (s *recordingStmt) Close() error  { return nil }
+func (s *recordingStmt) NumInput() int { return -1 }
+
+func (s *recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.driver.mtx.Lock()
+	defer s.driver.mtx.Unlock()
+	s.driver.statements = append(s.driver.statements, s.query)
+	s.driver.args = append(s.driver.args, args)
+	s.driver.rows = append([][]driver.Value{args}, s.driver.rows...)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.driver.mtx.Lock()
+	defer s.driver.mtx.Unlock()
+	s.driver.statements = append(s.driver.statements, s.query)
+	s.driver.args = append(s.driver.args, args)
+	return &recordingRows{rows: s.driver.rows}, nil
+}
+
+func (r *recordingRows) Columns() []string {
+	return []string{"component", "from_status", "to_status", "error", "timestamp"}
+}
+func (r *recordingRows) Close() error { return nil }
+
+func (r *recordingRows) Next(dest []driver.Value) error {
+	if r.next == len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
+
+func TestSQLStoreAppendAndQuery(t *testing.T) {
+	// Arrange
+	drv := recordingDriver{}
+	sql.Register("audit-recording", &drv)
+	db, err := sql.Open("audit-recording", "")
+	require.NoError(t, err)
+	defer db.Close()
+
+	store := NewSQLStore(db, "health_audit_log", WithDollarPlaceholders())
+	since := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	record := Record{Component: "database", From: health.StatusUp, To: health.StatusDown, Error: "timeout", Timestamp: since}
+
+	// Act
+	require.NoError(t, store.Append(context.Background(), record))
+	records, err := store.Query(context.Background(), Query{Component: "database", Since: since, Limit: 10})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []Record{record}, records)
+	assert.Equal(t, []string{
+		"INSERT INTO health_audit_log (component, from_status, to_status, error, timestamp) VALUES ($1, $2, $3, $4, $5)",
+		"SELECT component, from_status, to_status, error, timestamp FROM health_audit_log " +
+			"WHERE component = $1 AND timestamp >= $2 ORDER BY timestamp DESC LIMIT 10",
+	}, drv.statements)
+	assert.Equal(t, []driver.Value{"database", since}, drv.args[1])
+}
@@ -0,0 +1,181 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+type (
+	// MemoryStore is a Store that keeps the most recent records in memory. Records are lost when the
+	// process terminates.
+	MemoryStore struct {
+		mtx     sync.Mutex
+		records []Record
+		size    int
+	}
+
+	// WriterStore is a Store that writes each record as a single line of JSON into an io.Writer
+	// (e.g., os.Stdout to hand records over to a log collector). It does not support queries.
+	WriterStore struct {
+		mtx sync.Mutex
+		w   io.Writer
+	}
+
+	// FileStore is a Store that appends each record as a single line of JSON to a file.
+	// Each record is synced to disk before Append returns.
+	FileStore struct {
+		mtx  sync.Mutex
+		path string
+	}
+)
+
+// NewMemoryStore creates a new MemoryStore that keeps up to 'size' of the most recent records.
+// A negative size is treated as zero.
+func NewMemoryStore(size int) *MemoryStore {
+	if size < 0 {
+		size = 0
+	}
+	return &MemoryStore{size: size}
+}
+
+// Append adds a record to the store. If the store is full, the oldest record is removed.
+func (s *MemoryStore) Append(_ context.Context, record Record) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.records = append(s.records, record)
+	if len(s.records) > s.size {
+		s.records = append([]Record(nil), s.records[len(s.records)-s.size:]...)
+	}
+
+	return nil
+}
+
+// Query returns all records that match the query, ordered from newest to oldest.
+func (s *MemoryStore) Query(_ context.Context, query Query) ([]Record, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return filter(s.records, query), nil
+}
+
+// NewWriterStore creates a new WriterStore that writes into the provided io.Writer.
+func NewWriterStore(w io.Writer) *WriterStore {
+	return &WriterStore{w: w}
+}
+
+// Append writes the record into the io.Writer.
+func (s *WriterStore) Append(_ context.Context, record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
+
+// Query always returns ErrQueryNotSupported.
+func (s *WriterStore) Query(_ context.Context, _ Query) ([]Record, error) {
+	return nil, ErrQueryNotSupported
+}
+
+// NewFileStore creates a new FileStore that appends records to the file at the provided path.
+// The file is created if it does not exist.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Append appends the record to the file.
+func (s *FileStore) Append(_ context.Context, record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("cannot open audit log file %q: %w", s.path, err)
+	}
+
+	_, err = file.Write(append(line, '\n'))
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("cannot write audit log file %q: %w", s.path, err)
+	}
+
+	return nil
+}
+
+// Query returns all records that match the query, ordered from newest to oldest.
+// Attention: The whole file is read for each query.
+func (s *FileStore) Query(_ context.Context, query Query) ([]Record, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	file, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("cannot open audit log file %q: %w", s.path, err)
+	}
+	defer file.Close()
+
+	// Lines are read with a bufio.Reader instead of a bufio.Scanner, because records can exceed the maximum
+	// token size of a bufio.Scanner (e.g., if a check returns a long error message).
+	var records []Record
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("cannot read audit log file %q: %w", s.path, err)
+		}
+
+		if len(bytes.TrimSpace(line)) > 0 {
+			var record Record
+			if err := json.Unmarshal(line, &record); err != nil {
+				return nil, fmt.Errorf("cannot parse audit log file %q: %w", s.path, err)
+			}
+			records = append(records, record)
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	return filter(records, query), nil
+}
+
+// filter returns the records that match the query, ordered from newest to oldest.
+// The provided records must be ordered from oldest to newest.
+func filter(records []Record, query Query) []Record {
+	var result []Record
+	for i := len(records) - 1; i >= 0; i-- {
+		if query.Limit > 0 && len(result) == query.Limit {
+			break
+		}
+		if query.matches(&records[i]) {
+			result = append(result, records[i])
+		}
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStoreAppendAndQuery(t *testing.T) {
+	// Arrange
+	store := NewFileStore(filepath.Join(t.TempDir(), "audit.log"))
+	timestamp := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	records := []Record{
+		{Component: "database", From: health.StatusUp, To: health.StatusDown, Error: "timeout", Timestamp: timestamp},
+		{Component: "database", From: health.StatusDown, To: health.StatusUp, Timestamp: timestamp.Add(time.Minute)},
+	}
+
+	// Act
+	empty, err := store.Query(context.Background(), Query{})
+	require.NoError(t, err)
+	for _, record := range records {
+		require.NoError(t, store.Append(context.Background(), record))
+	}
+	result, err := store.Query(context.Background(), Query{Component: "database"})
+
+	// Assert
+	assert.Empty(t, empty)
+	assert.NoError(t, err)
+	assert.Equal(t, []Record{records[1], records[0]}, result)
+}
+
+func TestFileStoreQueriesLongRecords(t *testing.T) {
+	// Arrange
+	store := NewFileStore(filepath.Join(t.TempDir(), "audit.log"))
+	record := Record{
+		Component: "database",
+		From:      health.StatusUp,
+		To:        health.StatusDown,
+		Error:     strings.Repeat("x", 128*1024),
+		Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, store.Append(context.Background(), record))
+
+	// Act
+	result, err := store.Query(context.Background(), Query{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []Record{record}, result)
+}
+
+func TestWriterStoreWritesJSONLines(t *testing.T) {
+	// Arrange
+	buf := bytes.Buffer{}
+	store := NewWriterStore(&buf)
+	timestamp := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Act
+	err := store.Append(context.Background(), Record{From: health.StatusUp, To: health.StatusDown, Timestamp: timestamp})
+	_, queryErr := store.Query(context.Background(), Query{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"from\":\"up\",\"to\":\"down\",\"timestamp\":\"2024-01-01T12:00:00Z\"}\n", buf.String())
+	assert.ErrorIs(t, queryErr, ErrQueryNotSupported)
+}
@@ -20,6 +20,7 @@ type (
 		interceptors         []Interceptor
 		detailsDisabled      bool
 		autostartDisabled    bool
+		noChecksStatus       AvailabilityStatus
 	}
 
 	defaultChecker struct {
@@ -32,6 +33,12 @@ type (
 		periodicCheckCount int
 	}
 
+	checkFuncWrapper func(check func(ctx context.Context) error) func(ctx context.Context) error
+
+	checkFuncWrapperKey struct{}
+
+	checkLabelsKey struct{}
+
 	checkResult struct {
 		checkName string
 		newState  CheckState
@@ -117,6 +124,9 @@ type (
 		Timestamp time.Time `json:"timestamp,omitempty"`
 		// Error contains the check error message, if the check failed.
 		Error error `json:"error,omitempty"`
+		// Labels contains the labels of the check (see Check.Labels).
+		// Labels are not included in the JSON representation.
+		Labels map[string]string `json:"-"`
 	}
 
 	// Interceptor is factory function that allows creating new instances of
@@ -276,6 +286,32 @@ func (ck *defaultChecker) Check(ctx context.Context) CheckerResult {
 	return ck.mapStateToCheckerResult()
 }
 
+// cacheExpiry returns the point in time at which the first check result of the current
+// state becomes stale (i.e., the point in time until which the current result can be cached).
+func (ck *defaultChecker) cacheExpiry() time.Time {
+	ck.mtx.Lock()
+	defer ck.mtx.Unlock()
+
+	var expiry time.Time
+	for _, check := range ck.cfg.checks {
+		ttl := ck.cfg.cacheTTL
+		if isPeriodicCheck(check) {
+			ttl = check.updateInterval
+		}
+
+		lastCheckedAt := ck.state.CheckState[check.Name].LastCheckedAt
+		if ttl <= 0 || lastCheckedAt.IsZero() {
+			return time.Time{}
+		}
+
+		if checkExpiry := lastCheckedAt.Add(ttl); expiry.IsZero() || checkExpiry.Before(expiry) {
+			expiry = checkExpiry
+		}
+	}
+
+	return expiry
+}
+
 func (ck *defaultChecker) runSynchronousChecks(ctx context.Context) {
 	var (
 		numChecks          = len(ck.cfg.checks)
@@ -384,7 +420,11 @@ func (ck *defaultChecker) updateState(ctx context.Context, updates ...checkResul
 	}
 
 	oldStatus := ck.state.Status
-	ck.state.Status = aggregateStatus(ck.state.CheckState)
+	if len(ck.state.CheckState) == 0 {
+		ck.state.Status = ck.cfg.noChecksStatus
+	} else {
+		ck.state.Status = aggregateStatus(ck.state.CheckState)
+	}
 
 	if oldStatus != ck.state.Status && ck.cfg.statusChangeListener != nil {
 		ck.cfg.statusChangeListener(ctx, ck.state)
@@ -406,6 +446,7 @@ func (ck *defaultChecker) mapStateToCheckerResult() CheckerResult {
 				Status:    checkState.Status,
 				Error:     checkState.Result,
 				Timestamp: checkState.LastCheckedAt,
+				Labels:    check.Labels,
 			}
 		}
 	}
@@ -458,13 +499,18 @@ func executeCheck(
 	interceptors = append(interceptors, cfg.interceptors...)
 	interceptors = append(interceptors, check.Interceptors...)
 
+	checkCtx := ctx
+	if len(check.Labels) > 0 {
+		checkCtx = context.WithValue(ctx, checkLabelsKey{}, check.Labels)
+	}
+
 	newState = withInterceptors(interceptors, func(ctx context.Context, _ string, state CheckState) CheckState {
 		checkFuncResult := executeCheckFunc(ctx, check)
 		return createNextCheckState(checkFuncResult, check, state)
-	})(ctx, check.Name, newState)
+	})(checkCtx, check.Name, newState)
 
 	if check.StatusListener != nil && oldState.Status != newState.Status {
-		check.StatusListener(ctx, check.Name, newState)
+		check.StatusListener(checkCtx, check.Name, newState)
 	}
 
 	return ctx, newState
@@ -491,7 +537,14 @@ func executeCheckFunc(ctx context.Context, check *Check) error {
 			}
 		}()
 
-		res <- check.Check(ctx)
+		checkFunc, checkCtx := check.Check, ctx
+		if wrapper, ok := ctx.Value(checkFuncWrapperKey{}).(checkFuncWrapper); ok {
+			checkFunc = wrapper(checkFunc)
+			// The wrapper must not be applied to checks that are executed by the check function itself.
+			checkCtx = context.WithValue(ctx, checkFuncWrapperKey{}, nil)
+		}
+
+		res <- checkFunc(checkCtx)
 	}()
 
 	select {
@@ -552,6 +605,68 @@ func aggregateStatus(results map[string]CheckState) AvailabilityStatus {
 	return status
 }
 
+// MergeResults combines several CheckerResults into one. The aggregated status of the merged result
+// is the most critical status of all results (e.g., StatusDown if any of the results is StatusDown).
+// The keys of the provided map are used as namespaces: the details and info values of each result
+// are prefixed with the namespace and a slash (e.g., component "db" of result "legacy" becomes "legacy/db").
+// Results with an empty namespace are not prefixed.
+func MergeResults(results map[string]CheckerResult) CheckerResult {
+	merged := CheckerResult{Status: StatusUp}
+
+	for namespace, result := range results {
+		if result.Status.criticality() > merged.Status.criticality() {
+			merged.Status = result.Status
+		}
+
+		for name, checkResult := range result.Details {
+			if merged.Details == nil {
+				merged.Details = map[string]CheckResult{}
+			}
+			merged.Details[namespacedKey(namespace, name)] = checkResult
+		}
+
+		for key, value := range result.Info {
+			if merged.Info == nil {
+				merged.Info = map[string]interface{}{}
+			}
+			merged.Info[namespacedKey(namespace, key)] = value
+		}
+	}
+
+	return merged
+}
+
+func namespacedKey(namespace, key string) string {
+	if namespace == "" {
+		return key
+	}
+	return namespace + "/" + key
+}
+
+// CheckLabels returns the labels of the check (see Check.Labels) that is currently being executed.
+// The checker provides them in the context that is passed to interceptors, status listeners of
+// the check and the check function. It returns nil for checks without labels.
+func CheckLabels(ctx context.Context) map[string]string {
+	labels, _ := ctx.Value(checkLabelsKey{}).(map[string]string)
+	return labels
+}
+
+// WrapCheckFunc returns a copy of the provided context that instructs the checker to wrap the check function
+// with the provided wrapper when the check function is executed with this context. This allows interceptors
+// to alter the behaviour of the check function itself (e.g., to inject faults) by passing the returned
+// context to the next InterceptorFunc. Other than modifying the CheckState within an interceptor, the
+// result of the wrapped check function is still subject to panic recovery, timeouts and status
+// evaluation (see Check.MaxContiguousFails and Check.MaxTimeInError). If the context already contains a
+// wrapper, the new wrapper is applied on top of it.
+func WrapCheckFunc(ctx context.Context, wrapper func(check func(ctx context.Context) error) func(ctx context.Context) error) context.Context {
+	if existing, ok := ctx.Value(checkFuncWrapperKey{}).(checkFuncWrapper); ok {
+		return context.WithValue(ctx, checkFuncWrapperKey{}, checkFuncWrapper(func(check func(ctx context.Context) error) func(ctx context.Context) error {
+			return wrapper(existing(check))
+		}))
+	}
+	return context.WithValue(ctx, checkFuncWrapperKey{}, checkFuncWrapper(wrapper))
+}
+
 func withInterceptors(interceptors []Interceptor, target InterceptorFunc) InterceptorFunc {
 	chain := target
 
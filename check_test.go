@@ -2,6 +2,8 @@ package health
 
 import (
 	"context"
+	"encoding/json"
+	"expvar"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -179,3 +181,136 @@ func TestPanicRecovery(t *testing.T) {
 	assert.NotNil(t, checkRes.Error)
 	assert.Equal(t, (checkRes.Error).Error(), expectedPanicMsg)
 }
+
+func TestWhenNoChecksThenConfiguredStatusIsReported(t *testing.T) {
+	for _, status := range []AvailabilityStatus{StatusUp, StatusDown, StatusUnknown} {
+		// Arrange
+		ckr := NewChecker(WithNoChecksStatus(status))
+
+		// Act
+		res := ckr.Check(context.Background())
+
+		// Assert
+		assert.Equal(t, status, res.Status)
+	}
+}
+
+func TestCheckLabelsArePassedToCheckResult(t *testing.T) {
+	// Arrange
+	labels := map[string]string{"type": "database"}
+	ckr := NewChecker(
+		WithCheck(Check{
+			Name:   "check",
+			Labels: labels,
+			Check: func(ctx context.Context) error {
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, labels, res.Details["check"].Labels)
+}
+
+func TestCheckLabelsArePassedToInterceptorsAndCheckFunction(t *testing.T) {
+	// Arrange
+	labels := map[string]string{"team": "payments"}
+	var interceptorLabels, checkLabels map[string]string
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithInterceptors(func(next InterceptorFunc) InterceptorFunc {
+			return func(ctx context.Context, name string, state CheckState) CheckState {
+				interceptorLabels = CheckLabels(ctx)
+				return next(ctx, name, state)
+			}
+		}),
+		WithCheck(Check{
+			Name:   "check",
+			Labels: labels,
+			Check: func(ctx context.Context) error {
+				checkLabels = CheckLabels(ctx)
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, labels, interceptorLabels)
+	assert.Equal(t, labels, checkLabels)
+}
+
+func TestMergeResults(t *testing.T) {
+	// Arrange
+	results := map[string]CheckerResult{
+		"": {
+			Status:  StatusUp,
+			Info:    map[string]interface{}{"version": "1.0.0"},
+			Details: map[string]CheckResult{"db": {Status: StatusUp}},
+		},
+		"legacy": {
+			Status:  StatusDown,
+			Details: map[string]CheckResult{"db": {Status: StatusDown}},
+		},
+	}
+
+	// Act
+	merged := MergeResults(results)
+
+	// Assert
+	assert.Equal(t, StatusDown, merged.Status)
+	assert.Equal(t, map[string]interface{}{"version": "1.0.0"}, merged.Info)
+	assert.Equal(t, map[string]CheckResult{
+		"db":        {Status: StatusUp},
+		"legacy/db": {Status: StatusDown},
+	}, merged.Details)
+}
+
+func TestWrapCheckFuncAppliesWrappersInOrder(t *testing.T) {
+	// Arrange
+	var calls []string
+	wrap := func(name string) func(func(ctx context.Context) error) func(ctx context.Context) error {
+		return func(check func(ctx context.Context) error) func(ctx context.Context) error {
+			return func(ctx context.Context) error {
+				calls = append(calls, name)
+				return check(ctx)
+			}
+		}
+	}
+
+	ctx := WrapCheckFunc(WrapCheckFunc(context.Background(), wrap("inner")), wrap("outer"))
+
+	// Act
+	err := executeCheckFunc(ctx, &Check{Check: func(ctx context.Context) error {
+		calls = append(calls, "check")
+		return nil
+	}})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"outer", "inner", "check"}, calls)
+}
+
+func TestPublishExpvar(t *testing.T) {
+	// Arrange
+	checker := NewChecker(WithDisabledAutostart(), WithCheck(Check{
+		Name: "check",
+		Check: func(ctx context.Context) error {
+			return nil
+		},
+	}))
+
+	// Act
+	PublishExpvar(checker, "health_test")
+
+	// Assert
+	result := CheckerResult{}
+	assert.NoError(t, json.Unmarshal([]byte(expvar.Get("health_test").String()), &result))
+	assert.Equal(t, StatusUp, result.Status)
+	assert.Equal(t, StatusUp, result.Details["check"].Status)
+}
@@ -0,0 +1,82 @@
+// Package azblob provides a health check for Azure Blob Storage containers.
+package azblob
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		probeBlob string
+	}
+)
+
+// WithProbeBlob configures the check to additionally upload a small block blob with the provided name,
+// to download it again and to delete it afterwards. This verifies that the credentials permit writes
+// and that the container accepts them (e.g., that it is not protected by an immutability policy).
+func WithProbeBlob(name string) Option {
+	return func(cfg *config) {
+		cfg.probeBlob = name
+	}
+}
+
+// New creates a new Azure Blob Storage health check function that reads the properties of 'container',
+// which fails if the container does not exist or the credentials of the client do not grant access to it.
+// All requests adhere to the deadline of the context that is passed to the check function.
+func New(client *azblob.Client, container string, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	containerClient := client.ServiceClient().NewContainerClient(container)
+
+	return func(ctx context.Context) error {
+		if _, err := containerClient.GetProperties(ctx, nil); err != nil {
+			return fmt.Errorf("cannot access container %s: %w", container, err)
+		}
+
+		if cfg.probeBlob != "" {
+			return probe(ctx, client, container, cfg.probeBlob)
+		}
+
+		return nil
+	}
+}
+
+func probe(ctx context.Context, client *azblob.Client, container, blob string) error {
+	content := []byte(strconv.FormatInt(time.Now().UnixNano(), 10))
+
+	if _, err := client.UploadBuffer(ctx, container, blob, content, nil); err != nil {
+		return fmt.Errorf("cannot upload probe blob %s to container %s: %w", blob, container, err)
+	}
+
+	res, err := client.DownloadStream(ctx, container, blob, nil)
+	if err != nil {
+		return fmt.Errorf("cannot download probe blob %s from container %s: %w", blob, container, err)
+	}
+	actual, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return fmt.Errorf("cannot download probe blob %s from container %s: %w", blob, container, err)
+	}
+	if !bytes.Equal(actual, content) {
+		return fmt.Errorf("probe blob %s in container %s has unexpected content %q", blob, container, actual)
+	}
+
+	if _, err := client.DeleteBlob(ctx, container, blob, nil); err != nil {
+		return fmt.Errorf("cannot delete probe blob %s from container %s: %w", blob, container, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package azblob
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newClient returns a client for a minimal Blob Storage emulator that contains the container "health"
+// and records the requests that it receives.
+func newClient(t *testing.T) (*azblob.Client, *[]string) {
+	var mu sync.Mutex
+	var requests []string
+	blobs := map[string][]byte{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		container, blob, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/account/"), "/")
+		if container != "health" {
+			w.Header().Set("x-ms-error-code", "ContainerNotFound")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			if blob == "" {
+				return
+			}
+			w.Header().Set("Content-Length", strconv.Itoa(len(blobs[blob])))
+			_, _ = w.Write(blobs[blob])
+		case http.MethodPut:
+			blobs[blob], _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			delete(blobs, blob)
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := azblob.NewClientWithNoCredential(server.URL+"/account", nil)
+	require.NoError(t, err)
+
+	return client, &requests
+}
+
+func TestCheckWithProbeBlob(t *testing.T) {
+	// Arrange
+	client, requests := newClient(t)
+
+	// Act
+	err := New(client, "health", WithProbeBlob("probe"))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET /account/health",
+		"PUT /account/health/probe",
+		"GET /account/health/probe",
+		"DELETE /account/health/probe",
+	}, *requests)
+}
+
+func TestCheckFailsIfContainerDoesNotExist(t *testing.T) {
+	// Arrange
+	client, _ := newClient(t)
+
+	// Act
+	err := New(client, "missing")(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "ContainerNotFound")
+}
@@ -0,0 +1,59 @@
+// Package cassandra provides a health check for Apache Cassandra (https://cassandra.apache.org)
+// and ScyllaDB (https://www.scylladb.com) that uses the gocql driver.
+package cassandra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocql/gocql"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		query       string
+		consistency gocql.Consistency
+	}
+)
+
+// WithQuery sets the query that is executed by the check. Default is "SELECT release_version FROM system.local".
+// Because system.local is a node-local table, the default query only verifies that the coordinator node
+// responds. To verify that enough replicas are available for a consistency level (see WithConsistency),
+// use a query that reads from a table of your keyspace (e.g., "SELECT id FROM orders LIMIT 1").
+func WithQuery(query string) Option {
+	return func(cfg *config) {
+		cfg.query = query
+	}
+}
+
+// WithConsistency sets the consistency level of the query. Default is gocql.One.
+func WithConsistency(consistency gocql.Consistency) Option {
+	return func(cfg *config) {
+		cfg.consistency = consistency
+	}
+}
+
+// New creates a new Cassandra health check function that executes a lightweight query. The query is
+// aborted when the context passed to the check function is done (e.g., because the check timed out).
+func New(session *gocql.Session, options ...Option) func(ctx context.Context) error {
+	cfg := config{query: "SELECT release_version FROM system.local", consistency: gocql.One}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		if session.Closed() {
+			return fmt.Errorf("cassandra session is closed")
+		}
+
+		iter := session.Query(cfg.query).WithContext(ctx).Consistency(cfg.consistency).Idempotent(true).Iter()
+		if err := iter.Close(); err != nil {
+			return fmt.Errorf("cassandra query failed: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,53 @@
+package cassandra
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSession connects to the Cassandra nodes that are listed in the environment variable
+// CASSANDRA_HOSTS (comma separated). The test is skipped if the variable is not set.
+func newSession(t *testing.T) *gocql.Session {
+	hosts := os.Getenv("CASSANDRA_HOSTS")
+	if hosts == "" {
+		t.Skip("CASSANDRA_HOSTS is not set")
+	}
+
+	cluster := gocql.NewCluster(strings.Split(hosts, ",")...)
+	cluster.Timeout = 5 * time.Second
+	session, err := cluster.CreateSession()
+	require.NoError(t, err)
+
+	return session
+}
+
+func TestCheck(t *testing.T) {
+	// Arrange
+	session := newSession(t)
+	defer session.Close()
+
+	// Act
+	err := New(session, WithConsistency(gocql.LocalOne))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestCheckFailsIfSessionIsClosed(t *testing.T) {
+	// Arrange
+	session := newSession(t)
+	session.Close()
+
+	// Act
+	err := New(session)(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "cassandra session is closed")
+}
@@ -0,0 +1,71 @@
+// Package clickhouse provides a health check for ClickHouse (https://clickhouse.com) databases that are
+// accessed using the database/sql package. The clickhouse-go driver supports both, the native and the HTTP
+// protocol (e.g., clickhouse.OpenDB with clickhouse.Options.Protocol set to clickhouse.HTTP).
+package clickhouse
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		maxReplicationQueue int64
+	}
+)
+
+// WithMaxReplicationQueue configures the check to fail if the replication queue of any replicated table
+// (see column queue_size of table system.replicas) contains more entries than the provided number.
+func WithMaxReplicationQueue(n int64) Option {
+	return func(cfg *config) {
+		cfg.maxReplicationQueue = n
+	}
+}
+
+// New creates a new ClickHouse health check function that executes "SELECT 1". The query is
+// aborted when the context passed to the check function is done (e.g., because the check timed out).
+func New(db *sql.DB, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		var one int
+		if err := db.QueryRowContext(ctx, "SELECT 1").Scan(&one); err != nil {
+			return fmt.Errorf("clickhouse probe query failed: %w", err)
+		}
+
+		if cfg.maxReplicationQueue > 0 {
+			return checkReplicationQueue(ctx, db, cfg.maxReplicationQueue)
+		}
+
+		return nil
+	}
+}
+
+func checkReplicationQueue(ctx context.Context, db *sql.DB, maxSize int64) error {
+	var (
+		database, table string
+		size            int64
+	)
+	err := db.QueryRowContext(ctx,
+		"SELECT database, table, queue_size FROM system.replicas ORDER BY queue_size DESC LIMIT 1",
+	).Scan(&database, &table, &size)
+	if err == sql.ErrNoRows {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("cannot read clickhouse replication queue: %w", err)
+	}
+
+	if size > maxSize {
+		return fmt.Errorf("clickhouse replication queue of table %s.%s has %d entries, which exceeds %d",
+			database, table, size, maxSize)
+	}
+
+	return nil
+}
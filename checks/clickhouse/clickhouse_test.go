@@ -0,0 +1,49 @@
+package clickhouse
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/alexliesenfeld/health/internal/sqltest"
+	"github.com/stretchr/testify/assert"
+)
+
+const queueQuery = "SELECT database, table, queue_size FROM system.replicas ORDER BY queue_size DESC LIMIT 1"
+
+var selectOne = sqltest.Result{Columns: []string{"1"}, Rows: [][]driver.Value{{int64(1)}}}
+
+func TestCheckWithMaxReplicationQueue(t *testing.T) {
+	// Arrange
+	db := sqltest.Open(sqltest.Config{Results: map[string]sqltest.Result{
+		"SELECT 1": selectOne,
+		queueQuery: {
+			Columns: []string{"database", "table", "queue_size"},
+			Rows:    [][]driver.Value{{"default", "events", int64(120)}},
+		},
+	}})
+	defer db.Close()
+
+	// Act
+	withinLimit := New(db, WithMaxReplicationQueue(200))(context.Background())
+	exceedingLimit := New(db, WithMaxReplicationQueue(100))(context.Background())
+
+	// Assert
+	assert.NoError(t, withinLimit)
+	assert.EqualError(t, exceedingLimit, "clickhouse replication queue of table default.events has 120 entries, which exceeds 100")
+}
+
+func TestCheckWithMaxReplicationQueuePassesWithoutReplicatedTables(t *testing.T) {
+	// Arrange
+	db := sqltest.Open(sqltest.Config{Results: map[string]sqltest.Result{
+		"SELECT 1": selectOne,
+		queueQuery: {Columns: []string{"database", "table", "queue_size"}},
+	}})
+	defer db.Close()
+
+	// Act
+	err := New(db, WithMaxReplicationQueue(100))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
@@ -0,0 +1,114 @@
+// Package consul provides a health check for Consul (https://www.consul.io) that uses the HTTP API
+// of a Consul agent.
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		client   *http.Client
+		token    string
+		services []string
+	}
+)
+
+// WithHTTPClient sets the http.Client that is used to call the Consul agent.
+// By default, http.DefaultClient is used.
+func WithHTTPClient(client *http.Client) Option {
+	return func(cfg *config) {
+		cfg.client = client
+	}
+}
+
+// WithToken sets the ACL token that is sent to the Consul agent.
+func WithToken(token string) Option {
+	return func(cfg *config) {
+		cfg.token = token
+	}
+}
+
+// WithService configures the check to additionally verify that the service with the provided name has
+// at least one instance that passes all of its Consul health checks.
+// This option can be used multiple times to verify multiple services.
+func WithService(name string) Option {
+	return func(cfg *config) {
+		cfg.services = append(cfg.services, name)
+	}
+}
+
+// New creates a new Consul health check function that asks the agent at 'url'
+// (e.g., http://localhost:8500) for the current Raft leader (GET /v1/status/leader).
+// The check fails if no leader is elected.
+func New(url string, options ...Option) func(ctx context.Context) error {
+	cfg := config{client: http.DefaultClient}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	baseURL := strings.TrimSuffix(url, "/")
+
+	return func(ctx context.Context) error {
+		var leader string
+		if err := get(ctx, &cfg, baseURL+"/v1/status/leader", &leader); err != nil {
+			return fmt.Errorf("cannot get consul leader: %w", err)
+		}
+		if leader == "" {
+			return fmt.Errorf("consul cluster has no leader")
+		}
+
+		for _, service := range cfg.services {
+			if err := checkService(ctx, &cfg, baseURL, service); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func checkService(ctx context.Context, cfg *config, baseURL, service string) error {
+	var instances []json.RawMessage
+	endpoint := baseURL + "/v1/health/service/" + url.PathEscape(service) + "?passing"
+	if err := get(ctx, cfg, endpoint, &instances); err != nil {
+		return fmt.Errorf("cannot get health of consul service %q: %w", service, err)
+	}
+
+	if len(instances) == 0 {
+		return fmt.Errorf("consul service %q has no passing instances", service)
+	}
+
+	return nil
+}
+
+func get(ctx context.Context, cfg *config, url string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	if cfg.token != "" {
+		req.Header.Set("X-Consul-Token", cfg.token)
+	}
+
+	res, err := cfg.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	return json.NewDecoder(res.Body).Decode(result)
+}
@@ -0,0 +1,66 @@
+package consul
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newServer(t *testing.T, leader string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Consul-Token") != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/status/leader":
+			_, _ = w.Write([]byte(`"` + leader + `"`))
+		case "/v1/health/service/orders":
+			assert.True(t, r.URL.Query().Has("passing"))
+			_, _ = w.Write([]byte(`[{"Node":{"Node":"node-1"},"Service":{"Service":"orders"},"Checks":[]}]`))
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCheckWithService(t *testing.T) {
+	// Arrange
+	server := newServer(t, "10.0.0.1:8300")
+
+	// Act
+	passing := New(server.URL, WithToken("secret"), WithService("orders"))(context.Background())
+	failing := New(server.URL, WithToken("secret"), WithService("invoices"))(context.Background())
+
+	// Assert
+	assert.NoError(t, passing)
+	assert.EqualError(t, failing, "consul service \"invoices\" has no passing instances")
+}
+
+func TestCheckFailsIfNoLeaderIsElected(t *testing.T) {
+	// Arrange
+	server := newServer(t, "")
+
+	// Act
+	err := New(server.URL, WithToken("secret"))(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "consul cluster has no leader")
+}
+
+func TestCheckFailsIfRequestIsDenied(t *testing.T) {
+	// Arrange
+	server := newServer(t, "10.0.0.1:8300")
+
+	// Act
+	err := New(server.URL)(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "cannot get consul leader: unexpected status code 403")
+}
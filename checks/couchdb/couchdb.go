@@ -0,0 +1,115 @@
+// Package couchdb provides a health check for Apache CouchDB (https://couchdb.apache.org).
+package couchdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		client    *http.Client
+		username  string
+		password  string
+		databases []string
+	}
+)
+
+// WithHTTPClient sets the http.Client that is used to call CouchDB. By default, http.DefaultClient is used.
+func WithHTTPClient(client *http.Client) Option {
+	return func(cfg *config) {
+		cfg.client = client
+	}
+}
+
+// WithBasicAuth configures the check to authenticate using HTTP basic authentication.
+func WithBasicAuth(username, password string) Option {
+	return func(cfg *config) {
+		cfg.username = username
+		cfg.password = password
+	}
+}
+
+// WithDatabase configures the check to additionally verify that the database with the provided name exists.
+// This option can be used multiple times to verify multiple databases.
+func WithDatabase(name string) Option {
+	return func(cfg *config) {
+		cfg.databases = append(cfg.databases, name)
+	}
+}
+
+// New creates a new CouchDB health check function that calls the /_up endpoint of the CouchDB server
+// at 'url' (e.g., http://localhost:5984) and validates that the reported status is "ok".
+func New(url string, options ...Option) func(ctx context.Context) error {
+	cfg := config{client: http.DefaultClient}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	baseURL := strings.TrimSuffix(url, "/")
+
+	return func(ctx context.Context) error {
+		res, err := do(ctx, &cfg, http.MethodGet, baseURL+"/_up")
+		if err != nil {
+			return fmt.Errorf("cannot get couchdb status: %w", err)
+		}
+		defer res.Body.Close()
+
+		var status struct {
+			Status string `json:"status"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&status); err != nil && res.StatusCode == http.StatusOK {
+			return fmt.Errorf("cannot parse couchdb status: %w", err)
+		}
+
+		if res.StatusCode != http.StatusOK || status.Status != "ok" {
+			return fmt.Errorf("couchdb is not available (status code %d, status %q)", res.StatusCode, status.Status)
+		}
+
+		for _, database := range cfg.databases {
+			if err := checkDatabase(ctx, &cfg, baseURL, database); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func checkDatabase(ctx context.Context, cfg *config, baseURL, database string) error {
+	res, err := do(ctx, cfg, http.MethodHead, baseURL+"/"+url.PathEscape(database))
+	if err != nil {
+		return fmt.Errorf("cannot get couchdb database %q: %w", database, err)
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("couchdb database %q does not exist", database)
+	default:
+		return fmt.Errorf("cannot get couchdb database %q: unexpected status code %d", database, res.StatusCode)
+	}
+}
+
+func do(ctx context.Context, cfg *config, method, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if cfg.username != "" {
+		req.SetBasicAuth(cfg.username, cfg.password)
+	}
+
+	return cfg.client.Do(req)
+}
@@ -0,0 +1,55 @@
+package couchdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newServer(t *testing.T, upStatusCode int, upBody string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, _ := r.BasicAuth(); username != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/_up":
+			w.WriteHeader(upStatusCode)
+			_, _ = w.Write([]byte(upBody))
+		case "/orders":
+			assert.Equal(t, http.MethodHead, r.Method)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCheckWithDatabase(t *testing.T) {
+	// Arrange
+	server := newServer(t, http.StatusOK, `{"status":"ok","seeds":{}}`)
+
+	// Act
+	existing := New(server.URL, WithBasicAuth("admin", "secret"), WithDatabase("orders"))(context.Background())
+	missing := New(server.URL, WithBasicAuth("admin", "secret"), WithDatabase("invoices"))(context.Background())
+
+	// Assert
+	assert.NoError(t, existing)
+	assert.EqualError(t, missing, "couchdb database \"invoices\" does not exist")
+}
+
+func TestCheckFailsInMaintenanceMode(t *testing.T) {
+	// Arrange
+	server := newServer(t, http.StatusNotFound, `{"status":"maintenance_mode"}`)
+
+	// Act
+	err := New(server.URL, WithBasicAuth("admin", "secret"))(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "couchdb is not available (status code 404, status \"maintenance_mode\")")
+}
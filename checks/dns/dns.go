@@ -0,0 +1,173 @@
+// Package dns provides a health check that resolves DNS records and validates the answers.
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecordType is the type of DNS records that are resolved by the check.
+type RecordType string
+
+// Supported record types.
+const (
+	RecordA    RecordType = "A"
+	RecordAAAA RecordType = "AAAA"
+	RecordSRV  RecordType = "SRV"
+	RecordTXT  RecordType = "TXT"
+	RecordMX   RecordType = "MX"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		recordType RecordType
+		resolver   *net.Resolver
+		expected   []string
+		maxLatency time.Duration
+	}
+)
+
+// WithRecordType sets the type of the records that are resolved. Default is RecordA.
+func WithRecordType(recordType RecordType) Option {
+	return func(cfg *config) {
+		cfg.recordType = recordType
+	}
+}
+
+// WithResolver sets the resolver that is used for lookups. By default, net.DefaultResolver is used.
+func WithResolver(resolver *net.Resolver) Option {
+	return func(cfg *config) {
+		cfg.resolver = resolver
+	}
+}
+
+// WithServer configures the check to send queries to the DNS server at 'address'
+// (e.g., "10.0.0.2:53") instead of the servers that are configured for the system.
+func WithServer(address string) Option {
+	return WithResolver(&net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, address)
+		},
+	})
+}
+
+// WithExpectedAnswers configures the check to fail unless all the provided values are contained
+// in the answers. Values are compared case-insensitively and without a trailing dot in the following
+// format: IP addresses for A and AAAA records, "target:port" for SRV records, host names for MX records
+// and the text for TXT records.
+func WithExpectedAnswers(values ...string) Option {
+	return func(cfg *config) {
+		cfg.expected = append(cfg.expected, values...)
+	}
+}
+
+// WithMaxLatency configures the check to fail if the lookup takes longer than the provided duration.
+func WithMaxLatency(d time.Duration) Option {
+	return func(cfg *config) {
+		cfg.maxLatency = d
+	}
+}
+
+// New creates a new DNS health check function that resolves the records of 'name' (for SRV records,
+// the full name, e.g., "_ldap._tcp.example.com") and fails if the lookup fails, returns no records or
+// does not return the expected answers. The lookup adheres to the deadline of the context that is
+// passed to the check function.
+func New(name string, options ...Option) func(ctx context.Context) error {
+	cfg := config{recordType: RecordA, resolver: net.DefaultResolver}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		start := time.Now()
+		answers, err := lookup(ctx, cfg.resolver, cfg.recordType, name)
+		latency := time.Since(start)
+		if err != nil {
+			return fmt.Errorf("cannot resolve %s records of %s: %w", cfg.recordType, name, err)
+		}
+
+		if len(answers) == 0 {
+			return fmt.Errorf("%s has no %s records", name, cfg.recordType)
+		}
+
+		for _, expected := range cfg.expected {
+			if !contains(answers, normalize(expected)) {
+				return fmt.Errorf("%s records of %s do not contain %q (got %s)",
+					cfg.recordType, name, expected, strings.Join(answers, ", "))
+			}
+		}
+
+		if cfg.maxLatency > 0 && latency > cfg.maxLatency {
+			return fmt.Errorf("lookup latency of %v exceeds %v", latency, cfg.maxLatency)
+		}
+
+		return nil
+	}
+}
+
+func lookup(ctx context.Context, resolver *net.Resolver, recordType RecordType, name string) ([]string, error) {
+	var answers []string
+
+	switch recordType {
+	case RecordA, RecordAAAA:
+		network := "ip4"
+		if recordType == RecordAAAA {
+			network = "ip6"
+		}
+		ips, err := resolver.LookupIP(ctx, network, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			answers = append(answers, ip.String())
+		}
+	case RecordSRV:
+		_, records, err := resolver.LookupSRV(ctx, "", "", name)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			answers = append(answers, normalize(record.Target)+":"+strconv.Itoa(int(record.Port)))
+		}
+	case RecordTXT:
+		records, err := resolver.LookupTXT(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		answers = append(answers, records...)
+	case RecordMX:
+		records, err := resolver.LookupMX(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			answers = append(answers, normalize(record.Host))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported record type %q", recordType)
+	}
+
+	return answers, nil
+}
+
+func normalize(value string) string {
+	return strings.ToLower(strings.TrimSuffix(value, "."))
+}
+
+func contains(answers []string, value string) bool {
+	for _, answer := range answers {
+		if normalize(answer) == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package dns
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	typeA   = 1
+	typeMX  = 15
+	typeTXT = 16
+)
+
+// startServer starts a DNS server that answers A, MX and TXT queries for "example.test" over UDP.
+func startServer(t *testing.T, delay time.Duration) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if response := answer(buf[:n]); response != nil {
+				time.Sleep(delay)
+				_, _ = conn.WriteTo(response, addr)
+			}
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+// answer builds the response to a query with a single question.
+func answer(query []byte) []byte {
+	if len(query) < 12 {
+		return nil
+	}
+
+	// Skip the labels of the question name.
+	end := 12
+	var labels []string
+	for end < len(query) && query[end] != 0 {
+		labels = append(labels, string(query[end+1:end+1+int(query[end])]))
+		end += int(query[end]) + 1
+	}
+	end += 5 // Terminating zero length label, type and class.
+	if end > len(query) {
+		return nil
+	}
+	name := strings.ToLower(strings.Join(labels, "."))
+	qtype := binary.BigEndian.Uint16(query[end-4:])
+
+	var rdatas [][]byte
+	if name == "example.test" {
+		switch qtype {
+		case typeA:
+			rdatas = [][]byte{{192, 0, 2, 1}, {192, 0, 2, 2}}
+		case typeMX:
+			rdatas = [][]byte{append([]byte{0, 10}, encodeName("mail.example.test")...)}
+		case typeTXT:
+			rdatas = [][]byte{append([]byte{11}, "v=spf1 -all"...)}
+		}
+	}
+
+	response := append([]byte{}, query[:end]...)
+	binary.BigEndian.PutUint16(response[2:], 0x8180) // Response, recursion desired and available.
+	if name != "example.test" {
+		response[3] |= 3 // NXDOMAIN
+	}
+	binary.BigEndian.PutUint16(response[6:], uint16(len(rdatas)))
+	binary.BigEndian.PutUint16(response[8:], 0)
+	binary.BigEndian.PutUint16(response[10:], 0)
+
+	for _, rdata := range rdatas {
+		record := []byte{0xc0, 12, 0, 0, 0, 1, 0, 0, 0, 60, 0, 0} // Pointer to the question name.
+		binary.BigEndian.PutUint16(record[2:], qtype)
+		binary.BigEndian.PutUint16(record[10:], uint16(len(rdata)))
+		response = append(append(response, record...), rdata...)
+	}
+
+	return response
+}
+
+func encodeName(name string) []byte {
+	var encoded []byte
+	for _, label := range strings.Split(name, ".") {
+		encoded = append(append(encoded, byte(len(label))), label...)
+	}
+	return append(encoded, 0)
+}
+
+func TestCheckWithExpectedAnswers(t *testing.T) {
+	// Arrange
+	address := startServer(t, 0)
+
+	// Act
+	a := New("example.test", WithServer(address), WithExpectedAnswers("192.0.2.2"))(context.Background())
+	mx := New("example.test", WithServer(address), WithRecordType(RecordMX),
+		WithExpectedAnswers("Mail.Example.Test."))(context.Background())
+	txt := New("example.test", WithServer(address), WithRecordType(RecordTXT),
+		WithExpectedAnswers("v=spf1 -all"))(context.Background())
+
+	// Assert
+	assert.NoError(t, a)
+	assert.NoError(t, mx)
+	assert.NoError(t, txt)
+}
+
+func TestCheckFailsIfAnswerIsMissing(t *testing.T) {
+	// Arrange
+	address := startServer(t, 0)
+
+	// Act
+	err := New("example.test", WithServer(address), WithExpectedAnswers("192.0.2.3"))(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, `A records of example.test do not contain "192.0.2.3" (got 192.0.2.1, 192.0.2.2)`)
+}
+
+func TestCheckFailsIfNameDoesNotExist(t *testing.T) {
+	// Arrange
+	address := startServer(t, 0)
+
+	// Act
+	err := New("missing.test", WithServer(address))(context.Background())
+
+	// Assert
+	var dnsErr *net.DNSError
+	require.ErrorAs(t, err, &dnsErr)
+	assert.True(t, dnsErr.IsNotFound)
+}
+
+func TestCheckFailsIfLatencyExceedsThreshold(t *testing.T) {
+	// Arrange
+	address := startServer(t, 100*time.Millisecond)
+
+	// Act
+	err := New("example.test", WithServer(address), WithMaxLatency(50*time.Millisecond))(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "exceeds 50ms")
+}
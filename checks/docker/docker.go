@@ -0,0 +1,178 @@
+// Package docker provides a health check for the Docker daemon that uses the Docker Engine API.
+package docker
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const defaultHost = "unix:///var/run/docker.sock"
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		host       string
+		tlsConfig  *tls.Config
+		containers []string
+	}
+
+	containerState struct {
+		State struct {
+			Status  string
+			Running bool
+			Health  *struct {
+				Status string
+			}
+		}
+	}
+)
+
+// WithHost sets the address of the Docker daemon, either a Unix socket (e.g., "unix:///var/run/docker.sock")
+// or a TCP address (e.g., "tcp://10.0.0.2:2375"). By default, the value of the DOCKER_HOST environment
+// variable is used or, if it is not set, the default socket "unix:///var/run/docker.sock".
+func WithHost(host string) Option {
+	return func(cfg *config) {
+		cfg.host = host
+	}
+}
+
+// WithTLSConfig sets the TLS configuration that is used to connect to a Docker daemon that listens on
+// a TCP address (e.g., to present the client certificate that the daemon requires).
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(cfg *config) {
+		cfg.tlsConfig = tlsConfig
+	}
+}
+
+// WithContainer configures the check to additionally verify that the container with the provided name
+// (or ID) is running and, if the container has a health check, that it is not unhealthy.
+// This option can be used multiple times to verify multiple containers.
+func WithContainer(name string) Option {
+	return func(cfg *config) {
+		cfg.containers = append(cfg.containers, name)
+	}
+}
+
+// New creates a new Docker health check function that pings the Docker daemon (GET /_ping).
+// The requests adhere to the deadline of the context that is passed to the check function.
+func New(options ...Option) func(ctx context.Context) error {
+	cfg := config{host: os.Getenv("DOCKER_HOST")}
+	if cfg.host == "" {
+		cfg.host = defaultHost
+	}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	client, baseURL, err := newClient(&cfg)
+
+	return func(ctx context.Context) error {
+		if err != nil {
+			return err
+		}
+
+		if err := ping(ctx, client, baseURL); err != nil {
+			return fmt.Errorf("docker daemon at %s is not available: %w", cfg.host, err)
+		}
+
+		for _, container := range cfg.containers {
+			if err := checkContainer(ctx, client, baseURL, container); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func newClient(cfg *config) (*http.Client, string, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = cfg.tlsConfig
+
+	scheme, address, ok := strings.Cut(cfg.host, "://")
+	if !ok {
+		return nil, "", fmt.Errorf("invalid docker host %q", cfg.host)
+	}
+
+	switch scheme {
+	case "unix":
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", address)
+		}
+		// The host name is ignored when connecting to a Unix socket.
+		return &http.Client{Transport: transport}, "http://docker", nil
+	case "tcp", "http", "https":
+		if scheme == "https" || cfg.tlsConfig != nil {
+			scheme = "https"
+		} else {
+			scheme = "http"
+		}
+		return &http.Client{Transport: transport}, scheme + "://" + strings.TrimSuffix(address, "/"), nil
+	default:
+		return nil, "", fmt.Errorf("unsupported docker host scheme %q", scheme)
+	}
+}
+
+func ping(ctx context.Context, client *http.Client, baseURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/_ping", nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+func checkContainer(ctx context.Context, client *http.Client, baseURL, container string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/containers/"+url.PathEscape(container)+"/json", nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot inspect container %s: %w", container, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("container %s does not exist", container)
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("cannot inspect container %s: unexpected status code %d", container, res.StatusCode)
+	}
+
+	var state containerState
+	if err := json.NewDecoder(res.Body).Decode(&state); err != nil {
+		return fmt.Errorf("cannot inspect container %s: %w", container, err)
+	}
+
+	if !state.State.Running {
+		return fmt.Errorf("container %s is not running (status: %s)", container, state.State.Status)
+	}
+
+	if state.State.Health != nil && state.State.Health.Status == "unhealthy" {
+		return fmt.Errorf("container %s is unhealthy", container)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package docker
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/_ping":
+		_, _ = w.Write([]byte("OK"))
+	case "/containers/envoy/json":
+		_, _ = w.Write([]byte(`{"State":{"Status":"running","Running":true,"Health":{"Status":"healthy"}}}`))
+	case "/containers/worker/json":
+		_, _ = w.Write([]byte(`{"State":{"Status":"running","Running":true,"Health":{"Status":"unhealthy"}}}`))
+	case "/containers/migrations/json":
+		_, _ = w.Write([]byte(`{"State":{"Status":"exited","Running":false}}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"No such container"}`))
+	}
+})
+
+// startSocketServer starts a fake Docker daemon that listens on a Unix socket.
+func startSocketServer(t *testing.T) string {
+	socket := filepath.Join(t.TempDir(), "docker.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(handler)
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return "unix://" + socket
+}
+
+func TestCheckWithContainers(t *testing.T) {
+	// Arrange
+	host := startSocketServer(t)
+
+	// Act
+	running := New(WithHost(host), WithContainer("envoy"))(context.Background())
+	unhealthy := New(WithHost(host), WithContainer("envoy"), WithContainer("worker"))(context.Background())
+	exited := New(WithHost(host), WithContainer("migrations"))(context.Background())
+	missing := New(WithHost(host), WithContainer("proxy"))(context.Background())
+
+	// Assert
+	assert.NoError(t, running)
+	assert.EqualError(t, unhealthy, "container worker is unhealthy")
+	assert.EqualError(t, exited, "container migrations is not running (status: exited)")
+	assert.EqualError(t, missing, "container proxy does not exist")
+}
+
+func TestCheckWithTCPHost(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	t.Setenv("DOCKER_HOST", "tcp://"+server.Listener.Addr().String())
+
+	// Act
+	err := New()(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestCheckFailsIfDaemonIsNotAvailable(t *testing.T) {
+	// Arrange
+	host := "unix://" + filepath.Join(t.TempDir(), "docker.sock")
+
+	// Act
+	err := New(WithHost(host))(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "docker daemon at "+host+" is not available")
+}
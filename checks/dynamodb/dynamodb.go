@@ -0,0 +1,68 @@
+// Package dynamodb provides a health check for Amazon DynamoDB tables that uses the AWS SDK for Go v2.
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type (
+	// Client is the subset of the DynamoDB client API that is used by the check.
+	// It is implemented by *dynamodb.Client.
+	Client interface {
+		DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
+		GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	}
+
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		probeKey map[string]types.AttributeValue
+	}
+)
+
+// WithProbeKey configures the check to additionally read the item with the provided primary key,
+// which verifies that the credentials permit reading items. The item does not need to exist.
+// Eventually consistent reads are used, so the probe consumes half a read capacity unit.
+func WithProbeKey(key map[string]types.AttributeValue) Option {
+	return func(cfg *config) {
+		cfg.probeKey = key
+	}
+}
+
+// New creates a new DynamoDB health check function that describes 'table' and fails if the table
+// cannot be described (e.g., because it does not exist or the credentials do not grant the
+// "dynamodb:DescribeTable" permission) or if its status is not ACTIVE.
+func New(client Client, table string, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		res, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: &table})
+		if err != nil {
+			return fmt.Errorf("cannot describe table %s: %w", table, err)
+		}
+
+		if res.Table == nil || res.Table.TableStatus != types.TableStatusActive {
+			var status types.TableStatus
+			if res.Table != nil {
+				status = res.Table.TableStatus
+			}
+			return fmt.Errorf("table %s is not active (status: %s)", table, status)
+		}
+
+		if cfg.probeKey != nil {
+			if _, err := client.GetItem(ctx, &dynamodb.GetItemInput{TableName: &table, Key: cfg.probeKey}); err != nil {
+				return fmt.Errorf("cannot read probe item from table %s: %w", table, err)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,79 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Client = (*dynamodb.Client)(nil)
+
+type clientMock struct {
+	status  types.TableStatus
+	getErr  error
+	getKeys []map[string]types.AttributeValue
+}
+
+func (c *clientMock) DescribeTable(_ context.Context, params *dynamodb.DescribeTableInput, _ ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	if *params.TableName != "sessions" {
+		return nil, &types.ResourceNotFoundException{Message: params.TableName}
+	}
+	return &dynamodb.DescribeTableOutput{Table: &types.TableDescription{TableName: params.TableName, TableStatus: c.status}}, nil
+}
+
+func (c *clientMock) GetItem(_ context.Context, params *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	c.getKeys = append(c.getKeys, params.Key)
+	return &dynamodb.GetItemOutput{}, c.getErr
+}
+
+func TestCheckWithProbeKey(t *testing.T) {
+	// Arrange
+	client := clientMock{status: types.TableStatusActive}
+	key := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "health"}}
+
+	// Act
+	err := New(&client, "sessions", WithProbeKey(key))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]types.AttributeValue{key}, client.getKeys)
+}
+
+func TestCheckFailsIfTableIsNotActive(t *testing.T) {
+	// Arrange
+	client := clientMock{status: types.TableStatusUpdating}
+
+	// Act
+	err := New(&client, "sessions")(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "table sessions is not active (status: UPDATING)")
+}
+
+func TestCheckFailsIfTableDoesNotExist(t *testing.T) {
+	// Arrange
+	client := clientMock{status: types.TableStatusActive}
+
+	// Act
+	err := New(&client, "users")(context.Background())
+
+	// Assert
+	var notFound *types.ResourceNotFoundException
+	assert.ErrorAs(t, err, &notFound)
+}
+
+func TestCheckFailsIfProbeKeyCannotBeRead(t *testing.T) {
+	// Arrange
+	client := clientMock{status: types.TableStatusActive, getErr: errors.New("AccessDeniedException")}
+	key := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "health"}}
+
+	// Act
+	err := New(&client, "sessions", WithProbeKey(key))(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "cannot read probe item from table sessions: AccessDeniedException")
+}
@@ -0,0 +1,160 @@
+// Package elasticsearch provides a health check for Elasticsearch (https://www.elastic.co/elasticsearch)
+// and OpenSearch (https://opensearch.org) clusters that uses the cluster health API.
+package elasticsearch
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type (
+	// ClusterStatus is the health status of a cluster as reported by the cluster health API.
+	ClusterStatus string
+
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		client    *http.Client
+		header    http.Header
+		minStatus ClusterStatus
+		minNodes  int
+		timeout   time.Duration
+	}
+
+	clusterHealth struct {
+		ClusterName   string        `json:"cluster_name"`
+		Status        ClusterStatus `json:"status"`
+		NumberOfNodes int           `json:"number_of_nodes"`
+	}
+)
+
+// Cluster status values. A cluster is green if all shards are allocated, yellow if all primary shards
+// but not all replicas are allocated and red if at least one primary shard is not allocated.
+const (
+	StatusGreen  ClusterStatus = "green"
+	StatusYellow ClusterStatus = "yellow"
+	StatusRed    ClusterStatus = "red"
+)
+
+var statusRank = map[ClusterStatus]int{StatusRed: 0, StatusYellow: 1, StatusGreen: 2}
+
+// WithHTTPClient sets the http.Client that is used to call the cluster health API.
+// By default, http.DefaultClient is used.
+func WithHTTPClient(client *http.Client) Option {
+	return func(cfg *config) {
+		cfg.client = client
+	}
+}
+
+// WithBasicAuth configures the check to authenticate using HTTP basic authentication.
+func WithBasicAuth(username, password string) Option {
+	return func(cfg *config) {
+		credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+		cfg.header.Set("Authorization", "Basic "+credentials)
+	}
+}
+
+// WithAPIKey configures the check to authenticate using the provided (base64 encoded) API key.
+func WithAPIKey(apiKey string) Option {
+	return func(cfg *config) {
+		cfg.header.Set("Authorization", "ApiKey "+apiKey)
+	}
+}
+
+// WithMinimumStatus sets the worst cluster status that is still considered healthy. For example,
+// StatusYellow accepts clusters with unallocated replicas (as it is usual for single node clusters).
+// Default is StatusGreen.
+func WithMinimumStatus(status ClusterStatus) Option {
+	return func(cfg *config) {
+		cfg.minStatus = status
+	}
+}
+
+// WithMinimumNodes configures the check to fail if the cluster has fewer nodes than the provided number.
+func WithMinimumNodes(n int) Option {
+	return func(cfg *config) {
+		cfg.minNodes = n
+	}
+}
+
+// WithTimeout sets the timeout for calls of the cluster health API. By default, only the deadline of the
+// context that is passed to the check function is used.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.timeout = timeout
+	}
+}
+
+// New creates a new Elasticsearch health check function that calls the cluster health API
+// (GET /_cluster/health) of the cluster at 'url' (e.g., http://localhost:9200).
+func New(url string, options ...Option) func(ctx context.Context) error {
+	cfg := config{client: http.DefaultClient, header: http.Header{}, minStatus: StatusGreen}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	endpoint := strings.TrimSuffix(url, "/") + "/_cluster/health"
+
+	return func(ctx context.Context) error {
+		if cfg.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, cfg.timeout)
+			defer cancel()
+		}
+
+		health, err := getClusterHealth(ctx, endpoint, &cfg)
+		if err != nil {
+			return err
+		}
+
+		rank, ok := statusRank[health.Status]
+		if !ok {
+			return fmt.Errorf("cluster %s reported unknown status %q", health.ClusterName, health.Status)
+		}
+		if rank < statusRank[cfg.minStatus] {
+			return fmt.Errorf("cluster %s is %s", health.ClusterName, health.Status)
+		}
+
+		if health.NumberOfNodes < cfg.minNodes {
+			return fmt.Errorf("cluster %s has %d nodes, expected at least %d",
+				health.ClusterName, health.NumberOfNodes, cfg.minNodes)
+		}
+
+		return nil
+	}
+}
+
+func getClusterHealth(ctx context.Context, endpoint string, cfg *config) (*clusterHealth, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header = cfg.header.Clone()
+	req.Header.Set("Accept", "application/json")
+
+	res, err := cfg.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get cluster health: %w", err)
+	}
+	defer res.Body.Close()
+
+	// The cluster health API responds with status code 408 if a "wait_for" condition was not met,
+	// but the response body still contains the cluster health.
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusRequestTimeout {
+		return nil, fmt.Errorf("cannot get cluster health: unexpected status code %d", res.StatusCode)
+	}
+
+	var health clusterHealth
+	if err := json.NewDecoder(res.Body).Decode(&health); err != nil {
+		return nil, fmt.Errorf("cannot parse cluster health: %w", err)
+	}
+
+	return &health, nil
+}
@@ -0,0 +1,59 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newServer(t *testing.T, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_cluster/health", r.URL.Path)
+		username, password, _ := r.BasicAuth()
+		if username != "elastic" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCheckWithMinimumStatus(t *testing.T) {
+	// Arrange
+	server := newServer(t, `{"cluster_name":"search","status":"yellow","number_of_nodes":1}`)
+
+	// Act
+	green := New(server.URL, WithBasicAuth("elastic", "secret"))(context.Background())
+	yellow := New(server.URL, WithBasicAuth("elastic", "secret"), WithMinimumStatus(StatusYellow))(context.Background())
+
+	// Assert
+	assert.EqualError(t, green, "cluster search is yellow")
+	assert.NoError(t, yellow)
+}
+
+func TestCheckWithMinimumNodes(t *testing.T) {
+	// Arrange
+	server := newServer(t, `{"cluster_name":"search","status":"green","number_of_nodes":2}`)
+
+	// Act
+	err := New(server.URL, WithBasicAuth("elastic", "secret"), WithMinimumNodes(3))(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "cluster search has 2 nodes, expected at least 3")
+}
+
+func TestCheckFailsOnUnexpectedStatusCode(t *testing.T) {
+	// Arrange
+	server := newServer(t, `{}`)
+
+	// Act
+	err := New(server.URL)(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "cannot get cluster health: unexpected status code 401")
+}
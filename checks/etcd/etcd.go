@@ -0,0 +1,94 @@
+// Package etcd provides a health check for etcd clusters (https://etcd.io) that uses the official
+// etcd client library.
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+type (
+	// Client is the subset of the etcd client API that is used by the check.
+	// It is implemented by *clientv3.Client.
+	Client interface {
+		Endpoints() []string
+		Status(ctx context.Context, endpoint string) (*clientv3.StatusResponse, error)
+		Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error)
+	}
+
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		endpoints []string
+		healthKey string
+	}
+)
+
+// WithEndpoints sets the endpoints that are checked. By default, all endpoints of the client are checked.
+func WithEndpoints(endpoints ...string) Option {
+	return func(cfg *config) {
+		cfg.endpoints = endpoints
+	}
+}
+
+// WithHealthKey sets the key that is read to verify that the cluster can serve linearizable reads.
+// The key does not need to exist. Default is "health", which is also used by etcdctl.
+func WithHealthKey(key string) Option {
+	return func(cfg *config) {
+		cfg.healthKey = key
+	}
+}
+
+// New creates a new etcd health check function. The check requests the status of each endpoint and
+// fails if an endpoint is not reachable, does not know the cluster leader or reports an error
+// (e.g., an active NOSPACE alarm). Finally, it performs a linearizable read, which only succeeds
+// if the cluster has quorum.
+func New(client Client, options ...Option) func(ctx context.Context) error {
+	cfg := config{healthKey: "health"}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		endpoints := cfg.endpoints
+		if len(endpoints) == 0 {
+			endpoints = client.Endpoints()
+		}
+
+		for _, endpoint := range endpoints {
+			if err := checkEndpoint(ctx, client, endpoint); err != nil {
+				return err
+			}
+		}
+
+		// A permission error shows that the cluster has processed the request, so it is considered healthy.
+		if _, err := client.Get(ctx, cfg.healthKey); err != nil && !errors.Is(err, rpctypes.ErrPermissionDenied) {
+			return fmt.Errorf("etcd cluster cannot serve linearizable reads: %w", err)
+		}
+
+		return nil
+	}
+}
+
+func checkEndpoint(ctx context.Context, client Client, endpoint string) error {
+	status, err := client.Status(ctx, endpoint)
+	if err != nil {
+		return fmt.Errorf("cannot get status of etcd endpoint %s: %w", endpoint, err)
+	}
+
+	if status.Leader == 0 {
+		return fmt.Errorf("etcd endpoint %s has no leader", endpoint)
+	}
+
+	if len(status.Errors) > 0 {
+		return fmt.Errorf("etcd endpoint %s is unhealthy: %s", endpoint, strings.Join(status.Errors, "; "))
+	}
+
+	return nil
+}
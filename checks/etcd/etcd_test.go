@@ -0,0 +1,81 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+var _ Client = (*clientv3.Client)(nil)
+
+type clientMock struct {
+	statuses map[string]*clientv3.StatusResponse
+	getErr   error
+	getKeys  []string
+}
+
+func (c *clientMock) Endpoints() []string {
+	return []string{"10.0.0.1:2379", "10.0.0.2:2379"}
+}
+
+func (c *clientMock) Status(_ context.Context, endpoint string) (*clientv3.StatusResponse, error) {
+	status, ok := c.statuses[endpoint]
+	if !ok {
+		return nil, errors.New("context deadline exceeded")
+	}
+	return status, nil
+}
+
+func (c *clientMock) Get(_ context.Context, key string, _ ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	c.getKeys = append(c.getKeys, key)
+	return &clientv3.GetResponse{}, c.getErr
+}
+
+func TestCheck(t *testing.T) {
+	// Arrange
+	client := clientMock{
+		statuses: map[string]*clientv3.StatusResponse{
+			"10.0.0.1:2379": {Leader: 42},
+			"10.0.0.2:2379": {Leader: 42},
+		},
+		getErr: rpctypes.ErrPermissionDenied,
+	}
+
+	// Act
+	err := New(&client, WithHealthKey("probe"))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"probe"}, client.getKeys)
+}
+
+func TestCheckFailsIfEndpointHasNoLeader(t *testing.T) {
+	// Arrange
+	client := clientMock{statuses: map[string]*clientv3.StatusResponse{"10.0.0.1:2379": {}}}
+
+	// Act
+	err := New(&client, WithEndpoints("10.0.0.1:2379"))(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "etcd endpoint 10.0.0.1:2379 has no leader")
+}
+
+func TestCheckFailsIfEndpointReportsErrors(t *testing.T) {
+	// Arrange
+	client := clientMock{
+		statuses: map[string]*clientv3.StatusResponse{
+			"10.0.0.1:2379": {Leader: 42},
+			"10.0.0.2:2379": {Leader: 42, Errors: []string{"memberID:42 alarm:NOSPACE"}},
+		},
+	}
+
+	// Act
+	err := New(&client)(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "etcd endpoint 10.0.0.2:2379 is unhealthy: memberID:42 alarm:NOSPACE")
+}
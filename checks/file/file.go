@@ -0,0 +1,84 @@
+// Package file provides a health check for local files, e.g., to detect stale heartbeat files that are
+// written by background workers or log files that grow without bounds.
+package file
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		maxAge  time.Duration
+		maxSize int64
+	}
+)
+
+// WithMaxAge configures the check to fail if a file has not been modified within the provided duration.
+func WithMaxAge(d time.Duration) Option {
+	return func(cfg *config) {
+		cfg.maxAge = d
+	}
+}
+
+// WithMaxSize configures the check to fail if a file is larger than the provided number of bytes.
+func WithMaxSize(bytes int64) Option {
+	return func(cfg *config) {
+		cfg.maxSize = bytes
+	}
+}
+
+// New creates a new file health check function that fails if no file matches 'pattern'. The pattern is
+// either a path (e.g., "/var/run/worker.heartbeat") or a glob pattern in the syntax of filepath.Match
+// (e.g., "/var/log/app/*.log"). If the pattern matches multiple files, each of them must satisfy
+// the limits of WithMaxAge and WithMaxSize.
+func New(pattern string, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		if len(paths) == 0 {
+			return fmt.Errorf("no file matches %s", pattern)
+		}
+
+		for _, path := range paths {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err := checkFile(&cfg, path); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func checkFile(cfg *config, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("cannot get file info: %w", err)
+	}
+
+	if age := time.Since(info.ModTime()); cfg.maxAge > 0 && age > cfg.maxAge {
+		return fmt.Errorf("file %s was last modified %v ago, which exceeds %v", path, age.Round(time.Second), cfg.maxAge)
+	}
+
+	if cfg.maxSize > 0 && info.Size() > cfg.maxSize {
+		return fmt.Errorf("file %s has a size of %d bytes, which exceeds %d bytes", path, info.Size(), cfg.maxSize)
+	}
+
+	return nil
+}
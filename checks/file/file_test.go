@@ -0,0 +1,57 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path string, size int, age time.Duration) {
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0o600))
+	modTime := time.Now().Add(-age)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestCheckWithGlob(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "worker-1.heartbeat"), 0, 10*time.Second)
+	writeFile(t, filepath.Join(dir, "worker-2.heartbeat"), 0, 2*time.Minute)
+
+	// Act
+	fresh := New(filepath.Join(dir, "worker-1.heartbeat"), WithMaxAge(1*time.Minute))(context.Background())
+	stale := New(filepath.Join(dir, "*.heartbeat"), WithMaxAge(1*time.Minute))(context.Background())
+	missing := New(filepath.Join(dir, "*.pid"))(context.Background())
+
+	// Assert
+	assert.NoError(t, fresh)
+	assert.EqualError(t, stale, "file "+filepath.Join(dir, "worker-2.heartbeat")+" was last modified 2m0s ago, which exceeds 1m0s")
+	assert.EqualError(t, missing, "no file matches "+filepath.Join(dir, "*.pid"))
+}
+
+func TestCheckWithMaxSize(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "app.log")
+	writeFile(t, path, 2048, 0)
+
+	// Act
+	withinLimit := New(path, WithMaxSize(2048))(context.Background())
+	exceedingLimit := New(path, WithMaxSize(1024))(context.Background())
+
+	// Assert
+	assert.NoError(t, withinLimit)
+	assert.EqualError(t, exceedingLimit, "file "+path+" has a size of 2048 bytes, which exceeds 1024 bytes")
+}
+
+func TestCheckFailsIfPatternIsInvalid(t *testing.T) {
+	// Act
+	err := New("[")(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, `invalid pattern "["`)
+}
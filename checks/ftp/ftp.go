@@ -0,0 +1,194 @@
+// Package ftp provides a health check for FTP servers that uses the github.com/jlaffaye/ftp client library.
+package ftp
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health/internal/netutil"
+	"github.com/jlaffaye/ftp"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		username    string
+		password    string
+		tlsConfig   *tls.Config
+		explicitTLS bool
+		statPath    string
+		listPath    string
+	}
+
+	// connections tracks the connections of a check, so that they can be interrupted when the context is done.
+	connections struct {
+		mtx         sync.Mutex
+		conns       []net.Conn
+		interrupted bool
+	}
+)
+
+// WithLogin sets the credentials that are used to log in. By default, the check logs in as
+// user "anonymous".
+func WithLogin(username, password string) Option {
+	return func(cfg *config) {
+		cfg.username = username
+		cfg.password = password
+	}
+}
+
+// WithTLS configures the check to connect using implicit TLS (FTPS, usually on port 990).
+func WithTLS(tlsConfig *tls.Config) Option {
+	return func(cfg *config) {
+		cfg.tlsConfig = tlsConfig
+		cfg.explicitTLS = false
+	}
+}
+
+// WithExplicitTLS configures the check to upgrade the connection using the AUTH TLS command (FTPES).
+func WithExplicitTLS(tlsConfig *tls.Config) Option {
+	return func(cfg *config) {
+		cfg.tlsConfig = tlsConfig
+		cfg.explicitTLS = true
+	}
+}
+
+// WithStat configures the check to fail if the provided path does not exist. The server must
+// support the MLST command (RFC 3659).
+func WithStat(path string) Option {
+	return func(cfg *config) {
+		cfg.statPath = path
+	}
+}
+
+// WithList configures the check to list the directory at the provided path. This additionally
+// verifies that data connections can be established (e.g., that passive mode works through firewalls).
+func WithList(path string) Option {
+	return func(cfg *config) {
+		cfg.listPath = path
+	}
+}
+
+// New creates a new FTP health check function that connects to the server at 'address'
+// (e.g., "ftp.example.com:21"), logs in (and optionally stats or lists a path) and quits.
+// The check adheres to the deadline of the context that is passed to the check function.
+func New(address string, options ...Option) func(ctx context.Context) error {
+	cfg := config{username: "anonymous", password: "anonymous"}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		if err := check(ctx, &cfg, address); err != nil {
+			if ctx.Err() != nil {
+				// Report the cause instead of the resulting I/O timeout.
+				err = ctx.Err()
+			}
+			return fmt.Errorf("ftp server %s is not available: %w", address, err)
+		}
+		return nil
+	}
+}
+
+func check(ctx context.Context, cfg *config, address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	var tlsConfig *tls.Config
+	if cfg.tlsConfig != nil {
+		tlsConfig = netutil.WithServerName(cfg.tlsConfig, host)
+	}
+
+	var conns connections
+	defer conns.close()
+
+	defer netutil.AfterDone(ctx, conns.interrupt)()
+
+	dialOptions := []ftp.DialOption{ftp.DialWithDialFunc(func(network, addr string) (net.Conn, error) {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		// The client does not use TLS for connections that are created by a custom dial function, except for
+		// upgrading the control connection with explicit TLS.
+		if tlsConfig != nil && (!cfg.explicitTLS || conns.len() > 0) {
+			conn = tls.Client(conn, tlsConfig)
+		}
+		conns.add(conn)
+		return conn, nil
+	})}
+	if tlsConfig != nil && cfg.explicitTLS {
+		dialOptions = append(dialOptions, ftp.DialWithExplicitTLS(tlsConfig))
+	} else if tlsConfig != nil {
+		dialOptions = append(dialOptions, ftp.DialWithTLS(tlsConfig))
+	}
+
+	conn, err := ftp.Dial(address, dialOptions...)
+	if err != nil {
+		return err
+	}
+	defer conn.Quit()
+
+	if err := conn.Login(cfg.username, cfg.password); err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+
+	if cfg.statPath != "" {
+		if _, err := conn.GetEntry(cfg.statPath); err != nil {
+			return fmt.Errorf("cannot stat %q: %w", cfg.statPath, err)
+		}
+	}
+
+	if cfg.listPath != "" {
+		if _, err := conn.List(cfg.listPath); err != nil {
+			return fmt.Errorf("cannot list %q: %w", cfg.listPath, err)
+		}
+	}
+
+	return conn.NoOp()
+}
+
+func (c *connections) add(conn net.Conn) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.conns = append(c.conns, conn)
+	if c.interrupted {
+		_ = conn.SetDeadline(time.Now())
+	}
+}
+
+func (c *connections) len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return len(c.conns)
+}
+
+func (c *connections) interrupt() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.interrupted = true
+	for _, conn := range c.conns {
+		_ = conn.SetDeadline(time.Now())
+	}
+}
+
+func (c *connections) close() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for _, conn := range c.conns {
+		_ = conn.Close()
+	}
+}
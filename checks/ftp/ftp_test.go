@@ -0,0 +1,122 @@
+package ftp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer starts a minimal FTP server that supports login as "health" with the password "secret",
+// MLST, passive mode and MLSD for the directory "/drop".
+func startServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serve(t, conn)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func serve(t *testing.T, conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+	var dataListener net.Listener
+
+	reply("220 Service ready")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+
+		switch command {
+		case "USER":
+			reply("331 Password required")
+		case "PASS":
+			if arg == "secret" {
+				reply("230 Logged in")
+			} else {
+				reply("530 Login incorrect")
+			}
+		case "FEAT":
+			reply("211-Features:\r\n MLST type*;size*;modify*;\r\n211 End")
+		case "TYPE", "OPTS", "NOOP":
+			reply("200 OK")
+		case "MLST":
+			if arg == "/drop" {
+				reply("250-Listing /drop\r\n type=dir;modify=20240101000000; /drop\r\n250 End")
+			} else {
+				reply("550 No such file or directory")
+			}
+		case "PASV":
+			dataListener, err = net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			port := dataListener.Addr().(*net.TCPAddr).Port
+			reply(fmt.Sprintf("227 Entering Passive Mode (127,0,0,1,%d,%d)", port/256, port%256))
+		case "MLSD":
+			reply("150 Opening data connection")
+			dataConn, err := dataListener.Accept()
+			if err == nil {
+				_, _ = dataConn.Write([]byte("type=file;size=3;modify=20240101000000; orders.csv\r\n"))
+				dataConn.Close()
+			}
+			dataListener.Close()
+			reply("226 Transfer complete")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func TestCheckWithStatAndList(t *testing.T) {
+	// Arrange
+	address := startServer(t)
+
+	// Act
+	err := New(address, WithLogin("health", "secret"), WithStat("/drop"), WithList("/drop"))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestCheckFailsIfPathDoesNotExist(t *testing.T) {
+	// Arrange
+	address := startServer(t)
+
+	// Act
+	err := New(address, WithLogin("health", "secret"), WithStat("/archive"))(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "cannot stat \"/archive\": 550")
+}
+
+func TestCheckFailsIfLoginFails(t *testing.T) {
+	// Arrange
+	address := startServer(t)
+
+	// Act
+	err := New(address, WithLogin("health", "wrong"))(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "ftp server "+address+" is not available: login failed: 530")
+}
@@ -0,0 +1,91 @@
+// Package gcs provides a health check for Google Cloud Storage buckets.
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/storage"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		probeObject string
+	}
+)
+
+// WithProbeObject configures the check to additionally upload a small object with the provided name,
+// to download it again and to delete it afterwards. This verifies that the credentials permit writes
+// and that the bucket accepts them (e.g., that no retention policy prevents deleting objects).
+func WithProbeObject(name string) Option {
+	return func(cfg *config) {
+		cfg.probeObject = name
+	}
+}
+
+// New creates a new Google Cloud Storage health check function that reads the attributes of 'bucket',
+// which fails if the bucket does not exist or the credentials of the client do not grant access to it
+// (the "storage.buckets.get" permission is required). All requests adhere to the deadline of the context
+// that is passed to the check function.
+func New(client *storage.Client, bucket string, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		handle := client.Bucket(bucket)
+
+		if _, err := handle.Attrs(ctx); err != nil {
+			return fmt.Errorf("cannot access bucket %s: %w", bucket, err)
+		}
+
+		if cfg.probeObject != "" {
+			return probe(ctx, handle.Object(cfg.probeObject))
+		}
+
+		return nil
+	}
+}
+
+func probe(ctx context.Context, object *storage.ObjectHandle) error {
+	content := []byte(strconv.FormatInt(time.Now().UnixNano(), 10))
+
+	// Writers of cancelled contexts are discarded, so the upload is cancelled if writing fails.
+	writeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	writer := object.NewWriter(writeCtx)
+	writer.ChunkSize = 0 // Upload the probe object in a single request.
+	if _, err := writer.Write(content); err != nil {
+		return fmt.Errorf("cannot upload probe object %s: %w", object.ObjectName(), err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("cannot upload probe object %s: %w", object.ObjectName(), err)
+	}
+
+	reader, err := object.NewReader(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot download probe object %s: %w", object.ObjectName(), err)
+	}
+	actual, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return fmt.Errorf("cannot download probe object %s: %w", object.ObjectName(), err)
+	}
+	if !bytes.Equal(actual, content) {
+		return fmt.Errorf("probe object %s has unexpected content %q", object.ObjectName(), actual)
+	}
+
+	if err := object.Delete(ctx); err != nil {
+		return fmt.Errorf("cannot delete probe object %s: %w", object.ObjectName(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newClient returns a client for a minimal Cloud Storage emulator that contains the bucket "health"
+// and records the requests that it receives.
+func newClient(t *testing.T) (*storage.Client, *[]string) {
+	var mu sync.Mutex
+	var requests []string
+	objects := map[string][]byte{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		path := strings.TrimPrefix(r.URL.Path, "/storage/v1")
+		switch {
+		case r.Method == http.MethodGet && path == "/b/health":
+			_ = json.NewEncoder(w).Encode(map[string]string{"name": "health"})
+		case r.Method == http.MethodPost && path == "/upload/storage/v1/b/health/o":
+			_, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			parts := multipart.NewReader(r.Body, params["boundary"])
+			var metadata struct{ Name string }
+			part, _ := parts.NextPart()
+			_ = json.NewDecoder(part).Decode(&metadata)
+			part, _ = parts.NextPart()
+			objects[metadata.Name], _ = io.ReadAll(part)
+			_ = json.NewEncoder(w).Encode(map[string]string{"bucket": "health", "name": metadata.Name})
+		case r.Method == http.MethodGet && strings.HasPrefix(path, "/health/"):
+			_, _ = w.Write(objects[strings.TrimPrefix(path, "/health/")])
+		case r.Method == http.MethodDelete && strings.HasPrefix(path, "/b/health/o/"):
+			delete(objects, strings.TrimPrefix(path, "/b/health/o/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"Not Found"}}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("STORAGE_EMULATOR_HOST", strings.TrimPrefix(server.URL, "http://"))
+	client, err := storage.NewClient(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	return client, &requests
+}
+
+func TestCheckWithProbeObject(t *testing.T) {
+	// Arrange
+	client, requests := newClient(t)
+
+	// Act
+	err := New(client, "health", WithProbeObject("probe"))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET /storage/v1/b/health",
+		"POST /upload/storage/v1/b/health/o",
+		"GET /health/probe",
+		"DELETE /storage/v1/b/health/o/probe",
+	}, *requests)
+}
+
+func TestCheckFailsIfBucketDoesNotExist(t *testing.T) {
+	// Arrange
+	client, _ := newClient(t)
+
+	// Act
+	err := New(client, "missing")(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "cannot access bucket missing")
+}
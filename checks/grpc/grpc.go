@@ -0,0 +1,71 @@
+// Package grpc provides a health check for gRPC servers that implement the gRPC health checking protocol
+// (https://github.com/grpc/grpc/blob/master/doc/health-checking.md).
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		service     string
+		callOptions []grpc.CallOption
+	}
+)
+
+// WithService sets the name of the service whose status is requested. By default, the empty service name
+// is used, which by convention represents the overall status of the server.
+func WithService(name string) Option {
+	return func(cfg *config) {
+		cfg.service = name
+	}
+}
+
+// WithCallOptions sets call options that are used for each health check call
+// (e.g., grpc.WaitForReady or per-RPC credentials).
+func WithCallOptions(options ...grpc.CallOption) Option {
+	return func(cfg *config) {
+		cfg.callOptions = append(cfg.callOptions, options...)
+	}
+}
+
+// New creates a new gRPC health check function that calls grpc.health.v1.Health/Check using the provided
+// connection (e.g., a *grpc.ClientConn) and fails unless the service is reported as SERVING.
+// The deadline of the context that is passed to the check function is propagated to the server.
+func New(conn grpc.ClientConnInterface, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	client := healthpb.NewHealthClient(conn)
+
+	return func(ctx context.Context) error {
+		res, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: cfg.service}, cfg.callOptions...)
+		if err != nil {
+			switch status.Code(err) {
+			case codes.NotFound:
+				return fmt.Errorf("grpc service %q is unknown to the server", cfg.service)
+			case codes.Unimplemented:
+				return fmt.Errorf("grpc server does not implement the health checking protocol")
+			default:
+				return fmt.Errorf("cannot check grpc service %q: %w", cfg.service, err)
+			}
+		}
+
+		if res.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("grpc service %q is %s", cfg.service, res.GetStatus())
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,63 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newConn(t *testing.T, healthServer *health.Server) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	if healthServer != nil {
+		healthpb.RegisterHealthServer(server, healthServer)
+	}
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func TestCheckWithService(t *testing.T) {
+	// Arrange
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("orders.v1.OrderService", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("invoices.v1.InvoiceService", healthpb.HealthCheckResponse_NOT_SERVING)
+	conn := newConn(t, healthServer)
+
+	// Act
+	serving := New(conn, WithService("orders.v1.OrderService"))(context.Background())
+	notServing := New(conn, WithService("invoices.v1.InvoiceService"))(context.Background())
+	unknown := New(conn, WithService("payments.v1.PaymentService"))(context.Background())
+
+	// Assert
+	assert.NoError(t, serving)
+	assert.EqualError(t, notServing, "grpc service \"invoices.v1.InvoiceService\" is NOT_SERVING")
+	assert.EqualError(t, unknown, "grpc service \"payments.v1.PaymentService\" is unknown to the server")
+}
+
+func TestCheckFailsIfHealthServiceIsNotImplemented(t *testing.T) {
+	// Arrange
+	conn := newConn(t, nil)
+
+	// Act
+	err := New(conn)(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "grpc server does not implement the health checking protocol")
+}
@@ -0,0 +1,260 @@
+// Package http provides a health check for HTTP endpoints.
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxBodySize = 1 << 20
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	// matcher validates the response body and returns a description of the mismatch (or an empty string).
+	matcher func(body []byte) string
+
+	config struct {
+		client       *http.Client
+		method       string
+		header       http.Header
+		body         []byte
+		minStatus    int
+		maxStatus    int
+		matchers     []matcher
+		maxRedirects int
+		tlsConfig    *tls.Config
+		maxLatency   time.Duration
+	}
+)
+
+// WithHTTPClient sets the http.Client that is used to call the endpoint. The client is copied, so that
+// other options (e.g., WithTLSConfig) do not modify it. By default, a client with the settings of
+// http.DefaultClient is used.
+func WithHTTPClient(client *http.Client) Option {
+	return func(cfg *config) {
+		cfg.client = client
+	}
+}
+
+// WithMethod sets the HTTP method of the request. Default is GET.
+func WithMethod(method string) Option {
+	return func(cfg *config) {
+		cfg.method = method
+	}
+}
+
+// WithHeader adds a header to the request. This option can be used multiple times.
+func WithHeader(key, value string) Option {
+	return func(cfg *config) {
+		cfg.header.Add(key, value)
+	}
+}
+
+// WithBody sets the body of the request.
+func WithBody(body []byte) Option {
+	return func(cfg *config) {
+		cfg.body = body
+	}
+}
+
+// WithExpectedStatus sets the range of status codes (inclusive) that are considered healthy.
+// Default is 200 to 299.
+func WithExpectedStatus(min, max int) Option {
+	return func(cfg *config) {
+		cfg.minStatus = min
+		cfg.maxStatus = max
+	}
+}
+
+// WithBodyContains configures the check to fail if the response body does not contain the provided string.
+func WithBodyContains(s string) Option {
+	return func(cfg *config) {
+		cfg.matchers = append(cfg.matchers, func(body []byte) string {
+			if !bytes.Contains(body, []byte(s)) {
+				return fmt.Sprintf("response body does not contain %q", s)
+			}
+			return ""
+		})
+	}
+}
+
+// WithBodyMatches configures the check to fail if the response body does not match the provided
+// regular expression.
+func WithBodyMatches(re *regexp.Regexp) Option {
+	return func(cfg *config) {
+		cfg.matchers = append(cfg.matchers, func(body []byte) string {
+			if !re.Match(body) {
+				return fmt.Sprintf("response body does not match %q", re.String())
+			}
+			return ""
+		})
+	}
+}
+
+// WithJSONValue configures the check to fail if the value at 'path' in the JSON response body is not
+// equal to 'expected'. The path consists of object keys and array indices separated by dots
+// (e.g., "status" or "checks.0.status"). Values are compared by their string representation,
+// so that numbers and booleans can be matched as well (e.g., "true" or "42").
+func WithJSONValue(path, expected string) Option {
+	return func(cfg *config) {
+		cfg.matchers = append(cfg.matchers, func(body []byte) string {
+			value, err := lookupJSON(body, path)
+			if err != nil {
+				return err.Error()
+			}
+			if actual := fmt.Sprint(value); actual != expected {
+				return fmt.Sprintf("JSON value at %q is %q, expected %q", path, actual, expected)
+			}
+			return ""
+		})
+	}
+}
+
+// WithMaxRedirects sets the maximum number of redirects that are followed. If the limit is reached,
+// the status code of the last redirect response is validated. Use 0 to not follow redirects at all.
+// By default, the redirect policy of the HTTP client is used (up to 10 redirects for http.DefaultClient).
+func WithMaxRedirects(n int) Option {
+	return func(cfg *config) {
+		cfg.maxRedirects = n
+	}
+}
+
+// WithTLSConfig sets the TLS configuration that is used to connect to the endpoint (e.g., to trust a
+// private CA or to present a client certificate). The transport of the HTTP client must be an
+// *http.Transport (or nil) for this option to take effect.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(cfg *config) {
+		cfg.tlsConfig = tlsConfig
+	}
+}
+
+// WithMaxLatency configures the check to fail if the endpoint takes longer than the provided duration
+// to respond, even if the response is valid otherwise. The latency includes reading the response body.
+func WithMaxLatency(d time.Duration) Option {
+	return func(cfg *config) {
+		cfg.maxLatency = d
+	}
+}
+
+// New creates a new HTTP health check function that sends a request to 'url' and validates the response.
+// By default, the check sends a GET request and expects a 2xx status code. The request adheres to the
+// deadline of the context that is passed to the check function.
+func New(url string, options ...Option) func(ctx context.Context) error {
+	cfg := config{
+		client:       http.DefaultClient,
+		method:       http.MethodGet,
+		header:       http.Header{},
+		minStatus:    200,
+		maxStatus:    299,
+		maxRedirects: -1,
+	}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	client := newClient(&cfg)
+
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, cfg.method, url, bytes.NewReader(cfg.body))
+		if err != nil {
+			return fmt.Errorf("cannot create request: %w", err)
+		}
+		req.Header = cfg.header.Clone()
+
+		start := time.Now()
+		res, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("request failed: %w", err)
+		}
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(io.LimitReader(res.Body, maxBodySize))
+		if err != nil {
+			return fmt.Errorf("cannot read response body: %w", err)
+		}
+		latency := time.Since(start)
+
+		if res.StatusCode < cfg.minStatus || res.StatusCode > cfg.maxStatus {
+			return fmt.Errorf("unexpected status code %d, expected %d-%d", res.StatusCode, cfg.minStatus, cfg.maxStatus)
+		}
+
+		for _, match := range cfg.matchers {
+			if mismatch := match(body); mismatch != "" {
+				return errors.New(mismatch)
+			}
+		}
+
+		if cfg.maxLatency > 0 && latency > cfg.maxLatency {
+			return fmt.Errorf("latency of %v exceeds %v", latency, cfg.maxLatency)
+		}
+
+		return nil
+	}
+}
+
+func newClient(cfg *config) *http.Client {
+	client := *cfg.client
+
+	if cfg.tlsConfig != nil {
+		transport := client.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		if t, ok := transport.(*http.Transport); ok {
+			t = t.Clone()
+			t.TLSClientConfig = cfg.tlsConfig
+			client.Transport = t
+		}
+	}
+
+	if cfg.maxRedirects >= 0 {
+		client.CheckRedirect = func(_ *http.Request, via []*http.Request) error {
+			if len(via) > cfg.maxRedirects {
+				return http.ErrUseLastResponse
+			}
+			return nil
+		}
+	}
+
+	return &client
+}
+
+func lookupJSON(body []byte, path string) (interface{}, error) {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil, fmt.Errorf("cannot parse response body as JSON: %w", err)
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			child, ok := v[key]
+			if !ok {
+				return nil, fmt.Errorf("JSON value at %q does not exist", path)
+			}
+			value = child
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, fmt.Errorf("JSON value at %q does not exist", path)
+			}
+			value = v[index]
+		default:
+			return nil, fmt.Errorf("JSON value at %q does not exist", path)
+		}
+	}
+
+	return value, nil
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"up","checks":[{"name":"db","status":"up"}],"version":3}`))
+		case "/moved":
+			http.Redirect(w, r, "/health", http.StatusFound)
+		case "/slow":
+			time.Sleep(100 * time.Millisecond)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCheckWithMatchers(t *testing.T) {
+	// Arrange
+	server := newServer(t)
+
+	// Act
+	err := New(server.URL+"/health",
+		WithHTTPClient(server.Client()),
+		WithMethod(http.MethodPost),
+		WithHeader("Authorization", "Bearer secret"),
+		WithBodyContains(`"status":"up"`),
+		WithBodyMatches(regexp.MustCompile(`"version":\d+`)),
+		WithJSONValue("checks.0.status", "up"),
+		WithJSONValue("version", "3"),
+	)(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestCheckFailsIfJSONValueDoesNotMatch(t *testing.T) {
+	// Arrange
+	server := newServer(t)
+	options := []Option{
+		WithHTTPClient(server.Client()),
+		WithMethod(http.MethodPost),
+		WithHeader("Authorization", "Bearer secret"),
+	}
+
+	// Act
+	mismatch := New(server.URL+"/health", append(options, WithJSONValue("checks.0.status", "down"))...)(context.Background())
+	missing := New(server.URL+"/health", append(options, WithJSONValue("checks.1.status", "up"))...)(context.Background())
+
+	// Assert
+	assert.EqualError(t, mismatch, "JSON value at \"checks.0.status\" is \"up\", expected \"down\"")
+	assert.EqualError(t, missing, "JSON value at \"checks.1.status\" does not exist")
+}
+
+func TestCheckWithMaxRedirects(t *testing.T) {
+	// Arrange
+	server := newServer(t)
+
+	// Act
+	noRedirects := New(server.URL+"/moved", WithHTTPClient(server.Client()), WithMaxRedirects(0))(context.Background())
+	redirectStatus := New(server.URL+"/moved", WithHTTPClient(server.Client()), WithMaxRedirects(0),
+		WithExpectedStatus(http.StatusFound, http.StatusFound))(context.Background())
+
+	// Assert
+	assert.EqualError(t, noRedirects, "unexpected status code 302, expected 200-299")
+	assert.NoError(t, redirectStatus)
+}
+
+func TestCheckWithTLSConfig(t *testing.T) {
+	// Arrange
+	server := newServer(t)
+	tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig
+
+	// Act
+	trusted := New(server.URL+"/slow", WithTLSConfig(tlsConfig))(context.Background())
+	untrusted := New(server.URL + "/slow")(context.Background())
+
+	// Assert
+	assert.NoError(t, trusted)
+	assert.ErrorContains(t, untrusted, "certificate")
+}
+
+func TestCheckFailsIfLatencyExceedsThreshold(t *testing.T) {
+	// Arrange
+	server := newServer(t)
+
+	// Act
+	err := New(server.URL+"/slow", WithHTTPClient(server.Client()), WithMaxLatency(50*time.Millisecond))(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "exceeds 50ms")
+}
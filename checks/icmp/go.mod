@@ -0,0 +1,18 @@
+module github.com/alexliesenfeld/health/checks/icmp
+
+go 1.18
+
+require (
+	github.com/alexliesenfeld/health v0.0.0
+	github.com/stretchr/testify v1.8.4
+	golang.org/x/net v0.29.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.25.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/alexliesenfeld/health => ../..
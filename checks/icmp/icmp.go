@@ -0,0 +1,228 @@
+// Package icmp provides a health check that verifies the network reachability of a host using ICMP echo
+// requests ("ping").
+package icmp
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/alexliesenfeld/health/internal/netutil"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+const (
+	protocolICMP     = 1
+	protocolICMPIPv6 = 58
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		count         int
+		timeout       time.Duration
+		maxPacketLoss float64
+		maxRTT        time.Duration
+	}
+
+	// pinger sends echo requests over a listening ICMP connection.
+	pinger struct {
+		conn        *icmp.PacketConn
+		dst         net.Addr
+		protocol    int
+		requestType icmp.Type
+		replyType   icmp.Type
+	}
+)
+
+// WithCount sets the number of echo requests that are sent for each check. Default is 3.
+func WithCount(count int) Option {
+	return func(cfg *config) {
+		cfg.count = count
+	}
+}
+
+// WithTimeout sets how long to wait for the reply to an echo request before it is considered lost.
+// Default is 1 second.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithMaxPacketLoss sets the highest percentage of lost echo requests (0-100) that is still considered
+// healthy. Default is 0, i.e., the check fails if any echo request is lost.
+func WithMaxPacketLoss(percent float64) Option {
+	return func(cfg *config) {
+		cfg.maxPacketLoss = percent
+	}
+}
+
+// WithMaxRTT configures the check to fail if the average round trip time of the echo requests
+// exceeds the provided duration.
+func WithMaxRTT(rtt time.Duration) Option {
+	return func(cfg *config) {
+		cfg.maxRTT = rtt
+	}
+}
+
+// New creates a new ICMP health check function that sends echo requests to 'host' (a host name or an
+// IP address) one after another and evaluates packet loss and round trip times. The check adheres to
+// the deadline of the context that is passed to the check function.
+//
+// Sending ICMP messages using raw sockets requires elevated privileges (e.g., root or CAP_NET_RAW on Linux).
+// If raw sockets are not permitted, the check falls back to unprivileged ICMP datagram sockets
+// (which on Linux must be allowed for the group of the process using the "net.ipv4.ping_group_range"
+// sysctl setting).
+func New(host string, options ...Option) func(ctx context.Context) error {
+	cfg := config{count: 3, timeout: 1 * time.Second}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		ip, err := resolve(ctx, host)
+		if err != nil {
+			return fmt.Errorf("cannot resolve %s: %w", host, err)
+		}
+
+		p, err := listen(ip)
+		if err != nil {
+			return fmt.Errorf("cannot open ICMP socket: %w", err)
+		}
+		defer p.conn.Close()
+
+		// Abort waiting for replies as soon as the context is done.
+		defer netutil.InterruptOnDone(ctx, p.conn)()
+
+		var received int
+		var totalRTT time.Duration
+		for seq := 1; seq <= cfg.count; seq++ {
+			deadline := time.Now().Add(cfg.timeout)
+			if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+				deadline = d
+			}
+
+			rtt, err := p.ping(seq, deadline)
+			if ctx.Err() != nil {
+				return fmt.Errorf("cannot ping %s: %w", host, ctx.Err())
+			}
+			if err != nil && !isTimeout(err) {
+				return fmt.Errorf("cannot ping %s: %w", host, err)
+			}
+			if err == nil {
+				received++
+				totalRTT += rtt
+			}
+		}
+
+		lost := cfg.count - received
+		if received == 0 {
+			return fmt.Errorf("%s is unreachable (%d of %d packets lost)", host, lost, cfg.count)
+		}
+
+		if loss := float64(lost) / float64(cfg.count) * 100; loss > cfg.maxPacketLoss {
+			return fmt.Errorf("packet loss of %.0f%% to %s exceeds %.0f%% (%d of %d packets lost)",
+				loss, host, cfg.maxPacketLoss, lost, cfg.count)
+		}
+
+		if avg := totalRTT / time.Duration(received); cfg.maxRTT > 0 && avg > cfg.maxRTT {
+			return fmt.Errorf("average round trip time of %v to %s exceeds %v", avg, host, cfg.maxRTT)
+		}
+
+		return nil
+	}
+}
+
+func resolve(ctx context.Context, host string) (net.IP, error) {
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, addr := range addrs {
+		if ip := addr.IP.To4(); ip != nil {
+			return ip, nil
+		}
+	}
+
+	return addrs[0].IP, nil
+}
+
+func listen(ip net.IP) (*pinger, error) {
+	p := pinger{protocol: protocolICMP, requestType: ipv4.ICMPTypeEcho, replyType: ipv4.ICMPTypeEchoReply}
+	rawNetwork, rawAddress, udpNetwork := "ip4:icmp", "0.0.0.0", "udp4"
+	if ip.To4() == nil {
+		p = pinger{protocol: protocolICMPIPv6, requestType: ipv6.ICMPTypeEchoRequest, replyType: ipv6.ICMPTypeEchoReply}
+		rawNetwork, rawAddress, udpNetwork = "ip6:ipv6-icmp", "::", "udp6"
+	}
+
+	conn, err := icmp.ListenPacket(rawNetwork, rawAddress)
+	if err == nil {
+		p.conn, p.dst = conn, &net.IPAddr{IP: ip}
+		return &p, nil
+	}
+
+	conn, udpErr := icmp.ListenPacket(udpNetwork, rawAddress)
+	if udpErr != nil {
+		return nil, fmt.Errorf("%v (unprivileged: %w)", err, udpErr)
+	}
+	p.conn, p.dst = conn, &net.UDPAddr{IP: ip}
+	return &p, nil
+}
+
+// ping sends an echo request and waits for the matching reply until the deadline. Replies are matched
+// by their payload, because the identifier of echo requests is overwritten by the kernel for
+// unprivileged sockets.
+func (p *pinger) ping(seq int, deadline time.Time) (time.Duration, error) {
+	payload := make([]byte, 16)
+	if _, err := rand.Read(payload); err != nil {
+		return 0, err
+	}
+
+	request, err := (&icmp.Message{
+		Type: p.requestType,
+		Body: &icmp.Echo{ID: os.Getpid() & 0xffff, Seq: seq, Data: payload},
+	}).Marshal(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := p.conn.SetReadDeadline(deadline); err != nil {
+		return 0, err
+	}
+	start := time.Now()
+	if _, err := p.conn.WriteTo(request, p.dst); err != nil {
+		return 0, err
+	}
+
+	buf := make([]byte, 1500)
+	for {
+		n, _, err := p.conn.ReadFrom(buf)
+		if err != nil {
+			return 0, err
+		}
+
+		reply, err := icmp.ParseMessage(p.protocol, buf[:n])
+		if err != nil || reply.Type != p.replyType {
+			continue
+		}
+		if echo, ok := reply.Body.(*icmp.Echo); ok && bytes.Equal(echo.Data, payload) {
+			return time.Since(start), nil
+		}
+	}
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,41 @@
+package icmp
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// skipIfNotPermitted skips the test if the environment permits neither raw nor unprivileged ICMP sockets.
+func skipIfNotPermitted(t *testing.T, err error) {
+	if err != nil && strings.Contains(err.Error(), "cannot open ICMP socket") {
+		t.Skipf("ICMP sockets are not permitted: %v", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	// Arrange
+	check := New("127.0.0.1", WithCount(2), WithMaxRTT(1*time.Second))
+
+	// Act
+	err := check(context.Background())
+
+	// Assert
+	skipIfNotPermitted(t, err)
+	assert.NoError(t, err)
+}
+
+func TestCheckFailsIfRTTExceedsThreshold(t *testing.T) {
+	// Arrange
+	check := New("127.0.0.1", WithCount(1), WithMaxRTT(1*time.Nanosecond))
+
+	// Act
+	err := check(context.Background())
+
+	// Assert
+	skipIfNotPermitted(t, err)
+	assert.ErrorContains(t, err, "exceeds 1ns")
+}
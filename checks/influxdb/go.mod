@@ -0,0 +1,19 @@
+module github.com/alexliesenfeld/health/checks/influxdb
+
+go 1.18
+
+require (
+	github.com/influxdata/influxdb-client-go/v2 v2.14.0
+	github.com/stretchr/testify v1.8.4
+)
+
+require (
+	github.com/apapsch/go-jsonmerge/v2 v2.0.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/uuid v1.3.1 // indirect
+	github.com/influxdata/line-protocol v0.0.0-20200327222509-2487e7298839 // indirect
+	github.com/oapi-codegen/runtime v1.0.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.23.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
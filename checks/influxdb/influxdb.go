@@ -0,0 +1,101 @@
+// Package influxdb provides a health check for InfluxDB 2.x (https://www.influxdata.com) that uses the
+// official client library.
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		versionPrefix string
+		org           string
+		bucket        string
+	}
+)
+
+// WithVersionPrefix configures the check to fail if the version reported by InfluxDB does not start
+// with the provided prefix (e.g., "v2.7").
+func WithVersionPrefix(prefix string) Option {
+	return func(cfg *config) {
+		cfg.versionPrefix = prefix
+	}
+}
+
+// WithReadProbe configures the check to additionally query a single point that was written to the provided
+// bucket within the last minute. The query succeeds even if there is no such point, but it verifies that the
+// bucket exists and that the token of the client has read access to it. The time range is limited, so that
+// the query does not need to scan the whole retention period of the bucket.
+func WithReadProbe(org, bucket string) Option {
+	return func(cfg *config) {
+		cfg.org = org
+		cfg.bucket = bucket
+	}
+}
+
+// New creates a new InfluxDB health check function that calls the /health endpoint of InfluxDB and
+// validates that the reported status is "pass".
+func New(client influxdb2.Client, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		health, err := client.Health(ctx)
+		if err != nil {
+			return fmt.Errorf("cannot get influxdb health: %w", err)
+		}
+
+		if health.Status != domain.HealthCheckStatusPass {
+			message := ""
+			if health.Message != nil {
+				message = *health.Message
+			}
+			return fmt.Errorf("influxdb health status is %q: %s", health.Status, message)
+		}
+
+		if cfg.versionPrefix != "" {
+			if health.Version == nil || !strings.HasPrefix(*health.Version, cfg.versionPrefix) {
+				version := "unknown"
+				if health.Version != nil {
+					version = *health.Version
+				}
+				return fmt.Errorf("influxdb version %s does not match %s", version, cfg.versionPrefix)
+			}
+		}
+
+		if cfg.bucket != "" {
+			return readProbe(ctx, client, &cfg)
+		}
+
+		return nil
+	}
+}
+
+func readProbe(ctx context.Context, client influxdb2.Client, cfg *config) error {
+	query := fmt.Sprintf("from(bucket: %q) |> range(start: -1m) |> limit(n: 1)", cfg.bucket)
+
+	result, err := client.QueryAPI(cfg.org).Query(ctx, query)
+	if err != nil {
+		return fmt.Errorf("cannot read from influxdb bucket %q: %w", cfg.bucket, err)
+	}
+	defer result.Close()
+
+	for result.Next() {
+	}
+
+	if err := result.Err(); err != nil {
+		return fmt.Errorf("cannot read from influxdb bucket %q: %w", cfg.bucket, err)
+	}
+
+	return nil
+}
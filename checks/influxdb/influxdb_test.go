@@ -0,0 +1,73 @@
+package influxdb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newServer(t *testing.T, health string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(health))
+		case "/api/v2/query":
+			if r.URL.Query().Get("org") != "acme" {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"code":"not found","message":"organization name \"other\" not found"}`))
+				return
+			}
+			// The probe must not scan the whole retention period of the bucket.
+			var body struct{ Query string }
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || !strings.Contains(body.Query, "range(start: -1m)") {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"code":"invalid","message":"unexpected query"}`))
+				return
+			}
+			w.Header().Set("Content-Type", "text/csv")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCheckWithVersionAndReadProbe(t *testing.T) {
+	// Arrange
+	server := newServer(t, `{"name":"influxdb","status":"pass","version":"v2.7.5"}`)
+	client := influxdb2.NewClient(server.URL, "token")
+	defer client.Close()
+
+	// Act
+	ok := New(client, WithVersionPrefix("v2.7"), WithReadProbe("acme", "metrics"))(context.Background())
+	wrongVersion := New(client, WithVersionPrefix("v2.6"))(context.Background())
+	wrongOrg := New(client, WithReadProbe("other", "metrics"))(context.Background())
+
+	// Assert
+	assert.NoError(t, ok)
+	assert.EqualError(t, wrongVersion, "influxdb version v2.7.5 does not match v2.6")
+	assert.ErrorContains(t, wrongOrg, "cannot read from influxdb bucket \"metrics\"")
+}
+
+func TestCheckFailsIfStatusIsNotPass(t *testing.T) {
+	// Arrange
+	server := newServer(t, `{"name":"influxdb","status":"fail","message":"database is starting"}`)
+	client := influxdb2.NewClient(server.URL, "token")
+	defer client.Close()
+
+	// Act
+	err := New(client)(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "influxdb health status is \"fail\": database is starting")
+}
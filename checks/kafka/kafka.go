@@ -0,0 +1,206 @@
+// Package kafka provides a health check for Apache Kafka (https://kafka.apache.org) that works with
+// clients of the segmentio/kafka-go library (see New) as well as with clients of the IBM/sarama library
+// (see NewSarama).
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type (
+	// Client is the subset of the kafka-go client API that is used by the check.
+	// It is implemented by *kafka.Client.
+	Client interface {
+		Metadata(ctx context.Context, req *kafka.MetadataRequest) (*kafka.MetadataResponse, error)
+		OffsetFetch(ctx context.Context, req *kafka.OffsetFetchRequest) (*kafka.OffsetFetchResponse, error)
+		ListOffsets(ctx context.Context, req *kafka.ListOffsetsRequest) (*kafka.ListOffsetsResponse, error)
+	}
+
+	// Option is a configuration option for New and NewSarama.
+	Option func(cfg *config)
+
+	config struct {
+		topics     []string
+		group      string
+		groupTopic string
+		maxLag     int64
+	}
+
+	// cluster abstracts the client libraries that are supported by this package.
+	cluster interface {
+		// metadata returns the number of brokers and the partitions of all requested topics that exist.
+		metadata(ctx context.Context, topics []string) (int, map[string][]int, error)
+		// committedOffsets returns the committed offsets of a consumer group (-1 if there is none).
+		committedOffsets(ctx context.Context, group, topic string, partitions []int) (map[int]int64, error)
+		// lastOffsets returns the offsets of the next message that will be written to the partitions.
+		lastOffsets(ctx context.Context, topic string, partitions []int) (map[int]int64, error)
+	}
+
+	kafkaGoCluster struct {
+		client Client
+	}
+)
+
+// WithTopic configures the check to verify that the topic with the provided name exists.
+// This option can be used multiple times to verify multiple topics.
+func WithTopic(name string) Option {
+	return func(cfg *config) {
+		cfg.topics = append(cfg.topics, name)
+	}
+}
+
+// WithConsumerGroupLag configures the check to fail if the lag of the consumer group for the provided topic
+// (the number of messages that have not been consumed yet, summed up over all partitions) exceeds 'maxLag'.
+// Partitions without a committed offset are not taken into account.
+func WithConsumerGroupLag(group, topic string, maxLag int64) Option {
+	return func(cfg *config) {
+		cfg.group = group
+		cfg.groupTopic = topic
+		cfg.maxLag = maxLag
+	}
+}
+
+// New creates a new Kafka health check function that uses a kafka-go client (e.g., *kafka.Client).
+// The check requests the cluster metadata to verify broker connectivity.
+func New(client Client, options ...Option) func(ctx context.Context) error {
+	return newCheck(&kafkaGoCluster{client: client}, options)
+}
+
+func newCheck(cluster cluster, options []Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	topics := cfg.topics
+	if cfg.groupTopic != "" {
+		topics = append(append([]string(nil), topics...), cfg.groupTopic)
+	}
+
+	return func(ctx context.Context) error {
+		brokers, partitions, err := cluster.metadata(ctx, topics)
+		if err != nil {
+			return fmt.Errorf("cannot get kafka metadata: %w", err)
+		}
+
+		if brokers == 0 {
+			return fmt.Errorf("no kafka brokers available")
+		}
+
+		var missing []string
+		for _, topic := range topics {
+			if _, ok := partitions[topic]; !ok {
+				missing = append(missing, topic)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			return fmt.Errorf("kafka topics do not exist: %s", strings.Join(missing, ", "))
+		}
+
+		if cfg.group != "" {
+			return checkLag(ctx, cluster, &cfg, partitions[cfg.groupTopic])
+		}
+
+		return nil
+	}
+}
+
+func checkLag(ctx context.Context, cluster cluster, cfg *config, partitions []int) error {
+	committed, err := cluster.committedOffsets(ctx, cfg.group, cfg.groupTopic, partitions)
+	if err != nil {
+		return fmt.Errorf("cannot get committed offsets of kafka consumer group %s: %w", cfg.group, err)
+	}
+
+	last, err := cluster.lastOffsets(ctx, cfg.groupTopic, partitions)
+	if err != nil {
+		return fmt.Errorf("cannot get offsets of kafka topic %s: %w", cfg.groupTopic, err)
+	}
+
+	var lag int64
+	for _, partition := range partitions {
+		if offset, ok := committed[partition]; ok && offset >= 0 && last[partition] > offset {
+			lag += last[partition] - offset
+		}
+	}
+
+	if lag > cfg.maxLag {
+		return fmt.Errorf("lag of kafka consumer group %s on topic %s is %d, which exceeds %d",
+			cfg.group, cfg.groupTopic, lag, cfg.maxLag)
+	}
+
+	return nil
+}
+
+func (c *kafkaGoCluster) metadata(ctx context.Context, topics []string) (int, map[string][]int, error) {
+	res, err := c.client.Metadata(ctx, &kafka.MetadataRequest{Topics: topics})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	partitions := make(map[string][]int, len(res.Topics))
+	for _, topic := range res.Topics {
+		if topic.Error != nil {
+			continue
+		}
+		ids := make([]int, 0, len(topic.Partitions))
+		for _, partition := range topic.Partitions {
+			ids = append(ids, partition.ID)
+		}
+		partitions[topic.Name] = ids
+	}
+
+	return len(res.Brokers), partitions, nil
+}
+
+func (c *kafkaGoCluster) committedOffsets(ctx context.Context, group, topic string, partitions []int) (map[int]int64, error) {
+	res, err := c.client.OffsetFetch(ctx, &kafka.OffsetFetchRequest{
+		GroupID: group,
+		Topics:  map[string][]int{topic: partitions},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	offsets := make(map[int]int64, len(partitions))
+	for _, partition := range res.Topics[topic] {
+		if partition.Error != nil {
+			return nil, partition.Error
+		}
+		offsets[partition.Partition] = partition.CommittedOffset
+	}
+
+	return offsets, nil
+}
+
+func (c *kafkaGoCluster) lastOffsets(ctx context.Context, topic string, partitions []int) (map[int]int64, error) {
+	requests := make([]kafka.OffsetRequest, 0, len(partitions))
+	for _, partition := range partitions {
+		requests = append(requests, kafka.LastOffsetOf(partition))
+	}
+
+	res, err := c.client.ListOffsets(ctx, &kafka.ListOffsetsRequest{
+		Topics: map[string][]kafka.OffsetRequest{topic: requests},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	offsets := make(map[int]int64, len(partitions))
+	for _, partition := range res.Topics[topic] {
+		if partition.Error != nil {
+			return nil, partition.Error
+		}
+		offsets[partition.Partition] = partition.LastOffset
+	}
+
+	return offsets, nil
+}
@@ -0,0 +1,85 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Client = (*kafka.Client)(nil)
+
+type clientMock struct {
+	metadata  *kafka.MetadataResponse
+	committed map[int]int64
+	last      map[int]int64
+}
+
+func (c *clientMock) Metadata(ctx context.Context, req *kafka.MetadataRequest) (*kafka.MetadataResponse, error) {
+	return c.metadata, nil
+}
+
+func (c *clientMock) OffsetFetch(ctx context.Context, req *kafka.OffsetFetchRequest) (*kafka.OffsetFetchResponse, error) {
+	res := kafka.OffsetFetchResponse{Topics: map[string][]kafka.OffsetFetchPartition{}}
+	for topic, partitions := range req.Topics {
+		for _, partition := range partitions {
+			res.Topics[topic] = append(res.Topics[topic], kafka.OffsetFetchPartition{
+				Partition:       partition,
+				CommittedOffset: c.committed[partition],
+			})
+		}
+	}
+	return &res, nil
+}
+
+func (c *clientMock) ListOffsets(ctx context.Context, req *kafka.ListOffsetsRequest) (*kafka.ListOffsetsResponse, error) {
+	res := kafka.ListOffsetsResponse{Topics: map[string][]kafka.PartitionOffsets{}}
+	for topic, requests := range req.Topics {
+		for _, r := range requests {
+			res.Topics[topic] = append(res.Topics[topic], kafka.PartitionOffsets{
+				Partition:  r.Partition,
+				LastOffset: c.last[r.Partition],
+			})
+		}
+	}
+	return &res, nil
+}
+
+func newClientMock() *clientMock {
+	return &clientMock{
+		metadata: &kafka.MetadataResponse{
+			Brokers: []kafka.Broker{{ID: 1}},
+			Topics: []kafka.Topic{
+				{Name: "orders", Partitions: []kafka.Partition{{ID: 0}, {ID: 1}}},
+				{Name: "invoices", Error: kafka.UnknownTopicOrPartition},
+			},
+		},
+		committed: map[int]int64{0: 90, 1: -1},
+		last:      map[int]int64{0: 100, 1: 500},
+	}
+}
+
+func TestCheckWithConsumerGroupLag(t *testing.T) {
+	// Arrange
+	client := newClientMock()
+
+	// Act
+	withinLimit := New(client, WithConsumerGroupLag("billing", "orders", 10))(context.Background())
+	exceedingLimit := New(client, WithConsumerGroupLag("billing", "orders", 5))(context.Background())
+
+	// Assert
+	assert.NoError(t, withinLimit)
+	assert.EqualError(t, exceedingLimit, "lag of kafka consumer group billing on topic orders is 10, which exceeds 5")
+}
+
+func TestCheckWithTopic(t *testing.T) {
+	// Arrange
+	client := newClientMock()
+
+	// Act
+	err := New(client, WithTopic("orders"), WithTopic("invoices"))(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "kafka topics do not exist: invoices")
+}
@@ -0,0 +1,86 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/IBM/sarama"
+)
+
+type saramaCluster struct {
+	client sarama.Client
+}
+
+// NewSarama creates a new Kafka health check function that uses a sarama client. The check refreshes the
+// cluster metadata to verify broker connectivity.
+// Attention: Sarama does not support contexts. Requests are therefore bound by the timeouts that are
+// configured in the sarama.Config of the client (see sarama.Config.Net) rather than by the deadline of
+// the context that is passed to the check function.
+func NewSarama(client sarama.Client, options ...Option) func(ctx context.Context) error {
+	return newCheck(&saramaCluster{client: client}, options)
+}
+
+func (c *saramaCluster) metadata(_ context.Context, topics []string) (int, map[string][]int, error) {
+	if err := c.client.RefreshMetadata(topics...); err != nil && err != sarama.ErrUnknownTopicOrPartition {
+		return 0, nil, err
+	}
+
+	partitions := make(map[string][]int, len(topics))
+	for _, topic := range topics {
+		ids, err := c.client.Partitions(topic)
+		if err == sarama.ErrUnknownTopicOrPartition {
+			continue
+		} else if err != nil {
+			return 0, nil, err
+		}
+		partitions[topic] = make([]int, 0, len(ids))
+		for _, id := range ids {
+			partitions[topic] = append(partitions[topic], int(id))
+		}
+	}
+
+	return len(c.client.Brokers()), partitions, nil
+}
+
+func (c *saramaCluster) committedOffsets(_ context.Context, group, topic string, partitions []int) (map[int]int64, error) {
+	coordinator, err := c.client.Coordinator(group)
+	if err != nil {
+		return nil, err
+	}
+
+	req := sarama.OffsetFetchRequest{Version: 1, ConsumerGroup: group}
+	for _, partition := range partitions {
+		req.AddPartition(topic, int32(partition))
+	}
+
+	res, err := coordinator.FetchOffset(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	offsets := make(map[int]int64, len(partitions))
+	for _, partition := range partitions {
+		block := res.GetBlock(topic, int32(partition))
+		if block == nil {
+			continue
+		}
+		if block.Err != sarama.ErrNoError {
+			return nil, block.Err
+		}
+		offsets[partition] = block.Offset
+	}
+
+	return offsets, nil
+}
+
+func (c *saramaCluster) lastOffsets(_ context.Context, topic string, partitions []int) (map[int]int64, error) {
+	offsets := make(map[int]int64, len(partitions))
+	for _, partition := range partitions {
+		offset, err := c.client.GetOffset(topic, int32(partition), sarama.OffsetNewest)
+		if err != nil {
+			return nil, err
+		}
+		offsets[partition] = offset
+	}
+
+	return offsets, nil
+}
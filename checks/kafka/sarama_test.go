@@ -0,0 +1,42 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaramaCheckWithConsumerGroupLag(t *testing.T) {
+	// Arrange
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader("orders", 0, broker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetOffset("orders", 0, sarama.OffsetNewest, 100),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, "billing", broker),
+		"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(t).
+			SetOffset("billing", "orders", 0, 40, "", sarama.ErrNoError),
+	})
+
+	config := sarama.NewConfig()
+	config.Metadata.Retry.Max = 0
+	client, err := sarama.NewClient([]string{broker.Addr()}, config)
+	require.NoError(t, err)
+	defer client.Close()
+
+	// Act
+	withinLimit := NewSarama(client, WithTopic("orders"), WithConsumerGroupLag("billing", "orders", 60))(context.Background())
+	exceedingLimit := NewSarama(client, WithConsumerGroupLag("billing", "orders", 50))(context.Background())
+
+	// Assert
+	assert.NoError(t, withinLimit)
+	assert.EqualError(t, exceedingLimit, "lag of kafka consumer group billing on topic orders is 60, which exceeds 50")
+}
@@ -0,0 +1,75 @@
+// Package kubernetes provides a health check for the Kubernetes API server, which is useful for operators
+// and controllers that cannot do their work without access to it.
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		resource  *schema.GroupVersionResource
+		namespace string
+	}
+)
+
+// WithList configures the check to list (at most one object of) the provided resource in 'namespace'
+// instead of requesting the /readyz endpoint of the API server. Besides reachability, this verifies
+// that the service account is permitted to list the resource, and it works with service accounts that
+// are not permitted to access /readyz. Use schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+// for resources of the core API group.
+func WithList(resource schema.GroupVersionResource, namespace string) Option {
+	return func(cfg *config) {
+		cfg.resource = &resource
+		cfg.namespace = namespace
+	}
+}
+
+// New creates a new Kubernetes health check function that requests the /readyz endpoint of the API
+// server, which fails if the API server is not reachable or not ready to serve requests (e.g., because
+// it cannot reach etcd). The request adheres to the deadline of the context that is passed to the
+// check function, so use a tight timeout for the check (e.g., using health.WithTimeout).
+func New(client kubernetes.Interface, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		restClient := client.Discovery().RESTClient()
+
+		if cfg.resource != nil {
+			resourcePath := listPath(cfg.resource, cfg.namespace)
+			if err := restClient.Get().AbsPath(resourcePath).Param("limit", "1").Do(ctx).Error(); err != nil {
+				return fmt.Errorf("cannot list %s: %w", resourcePath, err)
+			}
+			return nil
+		}
+
+		body, err := restClient.Get().AbsPath("/readyz").Do(ctx).Raw()
+		if err != nil {
+			return fmt.Errorf("kubernetes API server is not ready: %w", err)
+		}
+		if string(body) != "ok" {
+			return fmt.Errorf("kubernetes API server is not ready: %s", body)
+		}
+
+		return nil
+	}
+}
+
+func listPath(resource *schema.GroupVersionResource, namespace string) string {
+	prefix := "/apis/" + resource.Group
+	if resource.Group == "" {
+		prefix = "/api"
+	}
+	return path.Join(prefix, resource.Version, "namespaces", namespace, resource.Resource)
+}
@@ -0,0 +1,95 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// newClient returns a client for a fake API server that permits listing pods and leases in the namespace
+// "default" and records the requests that it receives.
+func newClient(t *testing.T, ready bool) (kubernetes.Interface, *[]string) {
+	var mu sync.Mutex
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.URL.RequestURI())
+
+		switch r.URL.Path {
+		case "/readyz":
+			if !ready {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte("[-]etcd failed: reason withheld\nreadyz check failed"))
+				return
+			}
+			_, _ = w.Write([]byte("ok"))
+		case "/api/v1/namespaces/default/pods", "/apis/coordination.k8s.io/v1/namespaces/default/leases":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"kind": "List", "apiVersion": "v1", "items": []interface{}{}})
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(metav1.Status{
+				TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+				Status:   metav1.StatusFailure,
+				Reason:   metav1.StatusReasonForbidden,
+				Code:     http.StatusForbidden,
+				Message:  "forbidden",
+			})
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+
+	return client, &requests
+}
+
+func TestCheckReadyz(t *testing.T) {
+	// Arrange
+	readyClient, _ := newClient(t, true)
+	notReadyClient, _ := newClient(t, false)
+
+	// Act
+	ready := New(readyClient)(context.Background())
+	notReady := New(notReadyClient)(context.Background())
+
+	// Assert
+	assert.NoError(t, ready)
+	assert.ErrorContains(t, notReady, "kubernetes API server is not ready")
+}
+
+func TestCheckWithList(t *testing.T) {
+	// Arrange
+	client, requests := newClient(t, false)
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	leases := schema.GroupVersionResource{Group: "coordination.k8s.io", Version: "v1", Resource: "leases"}
+
+	// Act
+	podsErr := New(client, WithList(pods, "default"))(context.Background())
+	leasesErr := New(client, WithList(leases, "default"))(context.Background())
+	forbiddenErr := New(client, WithList(pods, "kube-system"))(context.Background())
+
+	// Assert
+	assert.NoError(t, podsErr)
+	assert.NoError(t, leasesErr)
+	assert.ErrorContains(t, forbiddenErr, "cannot list /api/v1/namespaces/kube-system/pods: forbidden")
+	assert.Equal(t, []string{
+		"/api/v1/namespaces/default/pods?limit=1",
+		"/apis/coordination.k8s.io/v1/namespaces/default/leases?limit=1",
+		"/api/v1/namespaces/kube-system/pods?limit=1",
+	}, *requests)
+}
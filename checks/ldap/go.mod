@@ -0,0 +1,18 @@
+module github.com/alexliesenfeld/health/checks/ldap
+
+go 1.18
+
+require (
+	github.com/go-asn1-ber/asn1-ber v1.5.7
+	github.com/go-ldap/ldap/v3 v3.4.10
+	github.com/stretchr/testify v1.8.4
+)
+
+require (
+	github.com/Azure/go-ntlmssp v0.0.0-20221128193559-754e69321358 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/crypto v0.31.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,167 @@
+// Package ldap provides a health check for LDAP directories (e.g., OpenLDAP or Active Directory)
+// that uses the go-ldap client library.
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		tlsConfig *tls.Config
+		startTLS  bool
+		bindDN    string
+		password  string
+		searchDN  string
+		filter    string
+	}
+)
+
+// WithTLSConfig sets the TLS configuration that is used for "ldaps://" URLs and for StartTLS.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(cfg *config) {
+		cfg.tlsConfig = tlsConfig
+	}
+}
+
+// WithStartTLS configures the check to upgrade "ldap://" connections using the StartTLS operation before
+// binding (see WithTLSConfig).
+func WithStartTLS() Option {
+	return func(cfg *config) {
+		cfg.startTLS = true
+	}
+}
+
+// WithBind configures the check to bind using the provided DN (or user principal name for
+// Active Directory) and password. Use an "ldaps://" URL or WithStartTLS to not send the password in
+// plain text. By default, the check performs an anonymous bind.
+func WithBind(dn, password string) Option {
+	return func(cfg *config) {
+		cfg.bindDN = dn
+		cfg.password = password
+	}
+}
+
+// WithSearch configures the check to additionally read the entry with the provided DN using a base
+// scope search with the provided filter (e.g., "(objectClass=*)"). The check fails if the entry does
+// not exist or does not match the filter.
+func WithSearch(dn, filter string) Option {
+	return func(cfg *config) {
+		cfg.searchDN = dn
+		cfg.filter = filter
+	}
+}
+
+// New creates a new LDAP health check function that connects to the directory at 'url'
+// (e.g., "ldaps://ldap.example.com:636") and binds. The check adheres to the deadline of the context
+// that is passed to the check function.
+func New(url string, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		if err := check(ctx, &cfg, url); err != nil {
+			if ctx.Err() != nil {
+				// Report the cause instead of the resulting network error.
+				err = ctx.Err()
+			}
+			return fmt.Errorf("ldap server %s is not available: %w", url, err)
+		}
+		return nil
+	}
+}
+
+func check(ctx context.Context, cfg *config, url string) error {
+	dialer := net.Dialer{}
+	if deadline, ok := ctx.Deadline(); ok {
+		dialer.Deadline = deadline
+	}
+
+	dialOptions := []ldap.DialOpt{ldap.DialWithDialer(&dialer)}
+	if cfg.tlsConfig != nil {
+		dialOptions = append(dialOptions, ldap.DialWithTLSConfig(cfg.tlsConfig))
+	}
+
+	conn, err := ldap.DialURL(url, dialOptions...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetTimeout(time.Until(deadline))
+	}
+
+	// The client does not support contexts, so close the connection to abort pending requests.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	if cfg.startTLS {
+		if err := conn.StartTLS(startTLSConfig(cfg.tlsConfig, url)); err != nil {
+			return err
+		}
+	}
+
+	if cfg.bindDN != "" {
+		err = conn.Bind(cfg.bindDN, cfg.password)
+	} else {
+		err = conn.UnauthenticatedBind("")
+	}
+	if err != nil {
+		return fmt.Errorf("bind failed: %w", err)
+	}
+
+	if cfg.searchDN != "" {
+		return search(conn, cfg)
+	}
+
+	return nil
+}
+
+func search(conn *ldap.Conn, cfg *config) error {
+	res, err := conn.Search(ldap.NewSearchRequest(
+		cfg.searchDN, ldap.ScopeBaseObject, ldap.NeverDerefAliases, 1, 0, false,
+		cfg.filter, []string{"1.1"}, nil,
+	))
+	if err != nil {
+		return fmt.Errorf("search for %q failed: %w", cfg.searchDN, err)
+	}
+
+	if len(res.Entries) == 0 {
+		return fmt.Errorf("entry %q does not match filter %q", cfg.searchDN, cfg.filter)
+	}
+
+	return nil
+}
+
+// startTLSConfig returns a copy of the TLS configuration that verifies the host name of the URL,
+// unless the configuration sets a server name already.
+func startTLSConfig(tlsConfig *tls.Config, rawURL string) *tls.Config {
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	tlsConfig = tlsConfig.Clone()
+	if u, err := url.Parse(rawURL); err == nil && tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = u.Hostname()
+	}
+	return tlsConfig
+}
@@ -0,0 +1,118 @@
+package ldap
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer starts a minimal LDAP server that supports simple binds and base scope searches.
+// Binds succeed anonymously or for "cn=health,dc=example,dc=com" with the password "secret".
+// Searches find the entry "dc=example,dc=com" only.
+func startServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				for {
+					packet, err := ber.ReadPacket(conn)
+					if err != nil {
+						return
+					}
+					messageID := packet.Children[0].Value.(int64)
+					request := packet.Children[1]
+
+					switch request.Tag {
+					case ldap.ApplicationBindRequest:
+						dn, password := request.Children[1].Value.(string), request.Children[2].Data.String()
+						resultCode := ldap.LDAPResultInvalidCredentials
+						if (dn == "" && password == "") || (dn == "cn=health,dc=example,dc=com" && password == "secret") {
+							resultCode = ldap.LDAPResultSuccess
+						}
+						writeResponse(conn, messageID, ldap.ApplicationBindResponse, resultCode)
+					case ldap.ApplicationSearchRequest:
+						if dn := request.Children[0].Value.(string); dn == "dc=example,dc=com" {
+							entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Entry")
+							entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, "DN"))
+							entry.AppendChild(ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes"))
+							write(conn, messageID, entry)
+							writeResponse(conn, messageID, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess)
+						} else {
+							writeResponse(conn, messageID, ldap.ApplicationSearchResultDone, ldap.LDAPResultNoSuchObject)
+						}
+					case ldap.ApplicationUnbindRequest:
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	return "ldap://" + listener.Addr().String()
+}
+
+func writeResponse(conn net.Conn, messageID int64, tag ber.Tag, resultCode int) {
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Response")
+	response.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(resultCode), "Result Code"))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	write(conn, messageID, response)
+}
+
+func write(conn net.Conn, messageID int64, op *ber.Packet) {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "Message ID"))
+	packet.AppendChild(op)
+	_, _ = conn.Write(packet.Bytes())
+}
+
+func TestCheckWithBindAndSearch(t *testing.T) {
+	// Arrange
+	url := startServer(t)
+
+	// Act
+	err := New(url,
+		WithBind("cn=health,dc=example,dc=com", "secret"),
+		WithSearch("dc=example,dc=com", "(objectClass=*)"),
+	)(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestCheckWithAnonymousBind(t *testing.T) {
+	// Arrange
+	url := startServer(t)
+
+	// Act
+	err := New(url)(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestCheckFailsIfBindOrSearchFails(t *testing.T) {
+	// Arrange
+	url := startServer(t)
+
+	// Act
+	bindErr := New(url, WithBind("cn=health,dc=example,dc=com", "wrong"))(context.Background())
+	searchErr := New(url, WithSearch("dc=example,dc=org", "(objectClass=*)"))(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, bindErr, "bind failed")
+	assert.ErrorContains(t, searchErr, "search for \"dc=example,dc=org\" failed")
+}
@@ -0,0 +1,141 @@
+// Package memcached provides a health check for memcached servers that uses the memcached text protocol.
+package memcached
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alexliesenfeld/health/internal/netutil"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		probeKey string
+	}
+)
+
+// WithProbeKey configures the check to additionally store a value under the provided key on each
+// server, read it back and compare it. The value expires after one minute.
+// Use a key that is not used by your application (e.g., "health:<instance-id>").
+func WithProbeKey(key string) Option {
+	return func(cfg *config) {
+		cfg.probeKey = key
+	}
+}
+
+// New creates a new memcached health check function that requests the version of each of the provided
+// servers (e.g., "localhost:11211"). The check fails if any of the servers is not available.
+// Connecting to a server and all requests adhere to the deadline of the context that is passed to
+// the check function.
+func New(servers []string, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		for _, server := range servers {
+			if err := checkServer(ctx, &cfg, server); err != nil {
+				if ctx.Err() != nil {
+					// Report the cause instead of the resulting I/O timeout.
+					err = ctx.Err()
+				}
+				return fmt.Errorf("memcached server %s is not available: %w", server, err)
+			}
+		}
+		return nil
+	}
+}
+
+func checkServer(ctx context.Context, cfg *config, server string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", server)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Interrupt pending reads and writes when the context is done.
+	defer netutil.InterruptOnDone(ctx, conn)()
+
+	rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+
+	line, err := request(rw, "version\r\n")
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "VERSION ") {
+		return fmt.Errorf("unexpected response to version command: %q", line)
+	}
+
+	if cfg.probeKey != "" {
+		return probe(rw, cfg.probeKey)
+	}
+
+	return nil
+}
+
+func probe(rw *bufio.ReadWriter, key string) error {
+	value := strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	line, err := request(rw, fmt.Sprintf("set %s 0 60 %d\r\n%s\r\n", key, len(value), value))
+	if err != nil {
+		return err
+	}
+	if line != "STORED" {
+		return fmt.Errorf("cannot store probe key %q: %s", key, line)
+	}
+
+	line, err = request(rw, fmt.Sprintf("get %s\r\n", key))
+	if err != nil {
+		return err
+	}
+	if line == "END" {
+		return fmt.Errorf("probe key %q was not found", key)
+	}
+	if !strings.HasPrefix(line, "VALUE ") {
+		return fmt.Errorf("unexpected response to get command: %q", line)
+	}
+
+	data, err := readLine(rw)
+	if err != nil {
+		return err
+	}
+	if data != value {
+		return fmt.Errorf("probe key %q has unexpected value %q", key, data)
+	}
+
+	if end, err := readLine(rw); err != nil {
+		return err
+	} else if end != "END" {
+		return fmt.Errorf("unexpected response to get command: %q", end)
+	}
+
+	return nil
+}
+
+func request(rw *bufio.ReadWriter, command string) (string, error) {
+	if _, err := rw.WriteString(command); err != nil {
+		return "", err
+	}
+	if err := rw.Flush(); err != nil {
+		return "", err
+	}
+	return readLine(rw)
+}
+
+func readLine(rw *bufio.ReadWriter) (string, error) {
+	line, err := rw.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(line, "\r\n"), nil
+}
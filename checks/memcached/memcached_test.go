@@ -0,0 +1,106 @@
+package memcached
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer starts a minimal memcached server that supports the version, set and get commands.
+// If 'readOnly' is set, the server responds with a server error to set commands.
+func startServer(t *testing.T, readOnly bool) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	var mu sync.Mutex
+	items := map[string]string{}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					fields := strings.Fields(line)
+					switch fields[0] {
+					case "version":
+						fmt.Fprint(conn, "VERSION 1.6.21\r\n")
+					case "set":
+						data, _ := reader.ReadString('\n')
+						if readOnly {
+							fmt.Fprint(conn, "SERVER_ERROR out of memory storing object\r\n")
+							continue
+						}
+						mu.Lock()
+						items[fields[1]] = strings.TrimSuffix(data, "\r\n")
+						mu.Unlock()
+						fmt.Fprint(conn, "STORED\r\n")
+					case "get":
+						mu.Lock()
+						value, ok := items[fields[1]]
+						mu.Unlock()
+						if ok {
+							fmt.Fprintf(conn, "VALUE %s 0 %d\r\n%s\r\n", fields[1], len(value), value)
+						}
+						fmt.Fprint(conn, "END\r\n")
+					}
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestCheckWithProbeKey(t *testing.T) {
+	// Arrange
+	servers := []string{startServer(t, false), startServer(t, false)}
+
+	// Act
+	err := New(servers, WithProbeKey("health:orders-1"))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestCheckFailsIfProbeKeyCannotBeStored(t *testing.T) {
+	// Arrange
+	server := startServer(t, true)
+
+	// Act
+	err := New([]string{server}, WithProbeKey("health:orders-1"))(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "memcached server "+server+" is not available: "+
+		"cannot store probe key \"health:orders-1\": SERVER_ERROR out of memory storing object")
+}
+
+func TestCheckFailsIfAnyServerIsUnavailable(t *testing.T) {
+	// Arrange
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unavailable := listener.Addr().String()
+	listener.Close()
+
+	// Act
+	err = New([]string{startServer(t, false), unavailable})(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "memcached server "+unavailable+" is not available")
+}
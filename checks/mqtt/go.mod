@@ -0,0 +1,17 @@
+module github.com/alexliesenfeld/health/checks/mqtt
+
+go 1.18
+
+require (
+	github.com/eclipse/paho.mqtt.golang v1.4.3
+	github.com/stretchr/testify v1.8.4
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/gorilla/websocket v1.5.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.8.0 // indirect
+	golang.org/x/sync v0.1.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,132 @@
+// Package mqtt provides a health check for MQTT brokers that uses the Eclipse Paho MQTT client library.
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+type (
+	// Client is the subset of the MQTT client API that is used by the check.
+	// It is implemented by mqtt.Client.
+	Client interface {
+		IsConnectionOpen() bool
+		Publish(topic string, qos byte, retained bool, payload interface{}) mqtt.Token
+		Subscribe(topic string, qos byte, callback mqtt.MessageHandler) mqtt.Token
+		Unsubscribe(topics ...string) mqtt.Token
+	}
+
+	// Option is a configuration option for New and NewDial.
+	Option func(cfg *config)
+
+	config struct {
+		probeTopic string
+		qos        byte
+	}
+)
+
+// WithProbeTopic configures the check to additionally subscribe to the provided topic, publish a message
+// to it and wait until the message has been received. This verifies that the broker routes messages.
+// Use a topic that is not used by other clients (e.g., "health/<instance-id>").
+func WithProbeTopic(topic string) Option {
+	return func(cfg *config) {
+		cfg.probeTopic = topic
+	}
+}
+
+// WithQoS sets the quality of service level of the probe message (see WithProbeTopic). Default is 1.
+func WithQoS(qos byte) Option {
+	return func(cfg *config) {
+		cfg.qos = qos
+	}
+}
+
+// New creates a new MQTT health check function that verifies that the provided client is connected.
+func New(client Client, options ...Option) func(ctx context.Context) error {
+	cfg := newConfig(options)
+
+	return func(ctx context.Context) error {
+		return check(ctx, client, &cfg)
+	}
+}
+
+// NewDial creates a new MQTT health check function that connects a new client that is created using the
+// provided client options for each check. Waiting for the connection adheres to the deadline of the
+// context that is passed to the check function. Prefer New if your application already holds a
+// connected client.
+func NewDial(clientOptions *mqtt.ClientOptions, options ...Option) func(ctx context.Context) error {
+	cfg := newConfig(options)
+
+	return func(ctx context.Context) error {
+		client := mqtt.NewClient(clientOptions)
+		if err := wait(ctx, client.Connect()); err != nil {
+			return fmt.Errorf("cannot connect to mqtt broker: %w", err)
+		}
+		defer client.Disconnect(0)
+
+		return check(ctx, client, &cfg)
+	}
+}
+
+func newConfig(options []Option) config {
+	cfg := config{qos: 1}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+func check(ctx context.Context, client Client, cfg *config) error {
+	if !client.IsConnectionOpen() {
+		return fmt.Errorf("mqtt client is not connected")
+	}
+
+	if cfg.probeTopic != "" {
+		return roundTrip(ctx, client, cfg)
+	}
+
+	return nil
+}
+
+func roundTrip(ctx context.Context, client Client, cfg *config) error {
+	payload := strconv.FormatInt(time.Now().UnixNano(), 10)
+	received := make(chan struct{}, 1)
+
+	subscribed := client.Subscribe(cfg.probeTopic, cfg.qos, func(_ mqtt.Client, msg mqtt.Message) {
+		if string(msg.Payload()) == payload {
+			select {
+			case received <- struct{}{}:
+			default:
+			}
+		}
+	})
+	if err := wait(ctx, subscribed); err != nil {
+		return fmt.Errorf("cannot subscribe to mqtt probe topic %q: %w", cfg.probeTopic, err)
+	}
+	defer client.Unsubscribe(cfg.probeTopic)
+
+	if err := wait(ctx, client.Publish(cfg.probeTopic, cfg.qos, false, payload)); err != nil {
+		return fmt.Errorf("cannot publish to mqtt probe topic %q: %w", cfg.probeTopic, err)
+	}
+
+	select {
+	case <-received:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("probe message was not received on mqtt topic %q: %w", cfg.probeTopic, ctx.Err())
+	}
+}
+
+// wait waits until the token has completed or the context is done.
+func wait(ctx context.Context, token mqtt.Token) error {
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,96 @@
+package mqtt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Client = (mqtt.Client)(nil)
+
+type doneToken struct {
+	mqtt.Token
+	err error
+}
+
+func (t *doneToken) Done() <-chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}
+
+func (t *doneToken) Error() error { return t.err }
+
+type message struct {
+	mqtt.Message
+	payload []byte
+}
+
+func (m *message) Payload() []byte { return m.payload }
+
+// brokerMock delivers published messages to subscribers, unless 'dropMessages' is set.
+type brokerMock struct {
+	connected     bool
+	dropMessages  bool
+	subscriptions map[string]mqtt.MessageHandler
+}
+
+func (b *brokerMock) IsConnectionOpen() bool { return b.connected }
+
+func (b *brokerMock) Publish(topic string, qos byte, retained bool, payload interface{}) mqtt.Token {
+	if handler, ok := b.subscriptions[topic]; ok && !b.dropMessages {
+		go handler(nil, &message{payload: []byte(payload.(string))})
+	}
+	return &doneToken{}
+}
+
+func (b *brokerMock) Subscribe(topic string, qos byte, callback mqtt.MessageHandler) mqtt.Token {
+	b.subscriptions[topic] = callback
+	return &doneToken{}
+}
+
+func (b *brokerMock) Unsubscribe(topics ...string) mqtt.Token {
+	for _, topic := range topics {
+		delete(b.subscriptions, topic)
+	}
+	return &doneToken{}
+}
+
+func TestCheckWithProbeTopic(t *testing.T) {
+	// Arrange
+	broker := brokerMock{connected: true, subscriptions: map[string]mqtt.MessageHandler{}}
+
+	// Act
+	err := New(&broker, WithProbeTopic("health/orders-1"))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, broker.subscriptions)
+}
+
+func TestCheckWithProbeTopicFailsIfMessageIsNotReceived(t *testing.T) {
+	// Arrange
+	broker := brokerMock{connected: true, dropMessages: true, subscriptions: map[string]mqtt.MessageHandler{}}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := New(&broker, WithProbeTopic("health/orders-1"))(ctx)
+
+	// Assert
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestCheckFailsIfClientIsNotConnected(t *testing.T) {
+	// Arrange
+	broker := brokerMock{}
+
+	// Act
+	err := New(&broker)(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "mqtt client is not connected")
+}
@@ -0,0 +1,80 @@
+// Package mssql provides a health check for Microsoft SQL Server databases that are accessed
+// using the database/sql package.
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	// ReplicaRole is the role of an availability replica in an Always On availability group.
+	ReplicaRole string
+
+	config struct {
+		role ReplicaRole
+	}
+)
+
+// Replica roles as reported by sys.dm_hadr_availability_replica_states.
+const (
+	RolePrimary   ReplicaRole = "PRIMARY"
+	RoleSecondary ReplicaRole = "SECONDARY"
+)
+
+// WithReplicaRole configures the check to fail if the local replica of the Always On availability group
+// does not have the provided role (e.g., to take a former primary out of rotation after a failover).
+// The check also fails if the server is not part of an availability group.
+// Attention: The database user requires the VIEW SERVER STATE permission.
+func WithReplicaRole(role ReplicaRole) Option {
+	return func(cfg *config) {
+		cfg.role = role
+	}
+}
+
+// New creates a new SQL Server health check function that pings the database and executes "SELECT @@VERSION".
+func New(db *sql.DB, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		if err := db.PingContext(ctx); err != nil {
+			return fmt.Errorf("cannot ping sql server: %w", err)
+		}
+
+		var version string
+		if err := db.QueryRowContext(ctx, "SELECT @@VERSION").Scan(&version); err != nil {
+			return fmt.Errorf("sql server probe query failed: %w", err)
+		}
+
+		if cfg.role != "" {
+			return checkReplicaRole(ctx, db, cfg.role)
+		}
+
+		return nil
+	}
+}
+
+func checkReplicaRole(ctx context.Context, db *sql.DB, expected ReplicaRole) error {
+	var role sql.NullString
+	err := db.QueryRowContext(ctx,
+		"SELECT role_desc FROM sys.dm_hadr_availability_replica_states WHERE is_local = 1").Scan(&role)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("sql server is not part of an availability group")
+	} else if err != nil {
+		return fmt.Errorf("cannot read sql server replica role: %w", err)
+	}
+
+	if actual := ReplicaRole(strings.ToUpper(role.String)); actual != expected {
+		return fmt.Errorf("sql server replica role is %q, expected %q", actual, expected)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package mssql
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/alexliesenfeld/health/internal/sqltest"
+	"github.com/stretchr/testify/assert"
+)
+
+const roleQuery = "SELECT role_desc FROM sys.dm_hadr_availability_replica_states WHERE is_local = 1"
+
+var selectVersion = sqltest.Result{
+	Columns: []string{""},
+	Rows:    [][]driver.Value{{"Microsoft SQL Server 2022 (RTM) - 16.0.1000.6 (X64)"}},
+}
+
+func TestCheckWithReplicaRole(t *testing.T) {
+	// Arrange
+	db := sqltest.Open(sqltest.Config{Results: map[string]sqltest.Result{
+		"SELECT @@VERSION": selectVersion,
+		roleQuery:          {Columns: []string{"role_desc"}, Rows: [][]driver.Value{{"SECONDARY"}}},
+	}})
+	defer db.Close()
+
+	// Act
+	secondary := New(db, WithReplicaRole(RoleSecondary))(context.Background())
+	primary := New(db, WithReplicaRole(RolePrimary))(context.Background())
+
+	// Assert
+	assert.NoError(t, secondary)
+	assert.EqualError(t, primary, "sql server replica role is \"SECONDARY\", expected \"PRIMARY\"")
+}
+
+func TestCheckWithReplicaRoleFailsWithoutAvailabilityGroup(t *testing.T) {
+	// Arrange
+	db := sqltest.Open(sqltest.Config{Results: map[string]sqltest.Result{
+		"SELECT @@VERSION": selectVersion,
+		roleQuery:          {Columns: []string{"role_desc"}},
+	}})
+	defer db.Close()
+
+	// Act
+	err := New(db, WithReplicaRole(RolePrimary))(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "sql server is not part of an availability group")
+}
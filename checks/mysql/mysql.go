@@ -0,0 +1,124 @@
+// Package mysql provides a health check for MySQL and MariaDB databases that are accessed
+// using the database/sql package.
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		maxReplicationLag time.Duration
+	}
+)
+
+// WithMaxReplicationLag configures the check to fail if the database is a replica and its replication lag
+// (Seconds_Behind_Source) exceeds the provided duration, or if replication is not running. The lag is read
+// using SHOW REPLICA STATUS (MySQL 8.0.22 or newer), falling back to SHOW SLAVE STATUS for older versions
+// and MariaDB. Databases that are not configured as replicas pass this part of the check.
+// Attention: The database user requires the REPLICATION CLIENT privilege.
+func WithMaxReplicationLag(lag time.Duration) Option {
+	return func(cfg *config) {
+		cfg.maxReplicationLag = lag
+	}
+}
+
+// New creates a new MySQL health check function that pings the database and executes "SELECT 1".
+func New(db *sql.DB, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		if err := db.PingContext(ctx); err != nil {
+			return fmt.Errorf("cannot ping mysql: %w", err)
+		}
+
+		var one int
+		if err := db.QueryRowContext(ctx, "SELECT 1").Scan(&one); err != nil {
+			return fmt.Errorf("mysql probe query failed: %w", err)
+		}
+
+		if cfg.maxReplicationLag > 0 {
+			return checkReplicationLag(ctx, db, cfg.maxReplicationLag)
+		}
+
+		return nil
+	}
+}
+
+func checkReplicationLag(ctx context.Context, db *sql.DB, maxLag time.Duration) error {
+	status, err := replicaStatus(ctx, db, "SHOW REPLICA STATUS")
+	if err != nil {
+		if status, err = replicaStatus(ctx, db, "SHOW SLAVE STATUS"); err != nil {
+			return fmt.Errorf("cannot read mysql replica status: %w", err)
+		}
+	}
+
+	if status == nil {
+		return nil
+	}
+
+	value, ok := status["Seconds_Behind_Source"]
+	if !ok {
+		value, ok = status["Seconds_Behind_Master"]
+	}
+	if !ok || !value.Valid {
+		return fmt.Errorf("mysql replication is not running")
+	}
+
+	seconds, err := strconv.ParseInt(value.String, 10, 64)
+	if err != nil {
+		return fmt.Errorf("cannot parse mysql replication lag %q: %w", value.String, err)
+	}
+
+	if lag := time.Duration(seconds) * time.Second; lag > maxLag {
+		return fmt.Errorf("mysql replication lag of %s exceeds %s", lag, maxLag)
+	}
+
+	return nil
+}
+
+// replicaStatus returns the first row of the replica status as a map of column names to values.
+// It returns nil if the database is not configured as a replica.
+func replicaStatus(ctx context.Context, db *sql.DB, query string) (map[string]sql.NullString, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	status := make(map[string]sql.NullString, len(columns))
+	for i, column := range columns {
+		status[column] = values[i]
+	}
+
+	return status, nil
+}
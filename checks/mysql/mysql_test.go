@@ -0,0 +1,68 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health/internal/sqltest"
+	"github.com/stretchr/testify/assert"
+)
+
+var selectOne = sqltest.Result{Columns: []string{"1"}, Rows: [][]driver.Value{{int64(1)}}}
+
+func TestCheckWithReplicationLag(t *testing.T) {
+	// Arrange
+	db := sqltest.Open(sqltest.Config{Results: map[string]sqltest.Result{
+		"SELECT 1": selectOne,
+		"SHOW REPLICA STATUS": {
+			Columns: []string{"Replica_IO_State", "Seconds_Behind_Source"},
+			Rows:    [][]driver.Value{{"Waiting for source to send event", int64(42)}},
+		},
+	}})
+	defer db.Close()
+
+	// Act
+	withinLimit := New(db, WithMaxReplicationLag(1*time.Minute))(context.Background())
+	exceedingLimit := New(db, WithMaxReplicationLag(10*time.Second))(context.Background())
+
+	// Assert
+	assert.NoError(t, withinLimit)
+	assert.EqualError(t, exceedingLimit, "mysql replication lag of 42s exceeds 10s")
+}
+
+func TestCheckFallsBackToShowSlaveStatus(t *testing.T) {
+	// Arrange
+	db := sqltest.Open(sqltest.Config{Results: map[string]sqltest.Result{
+		"SELECT 1":            selectOne,
+		"SHOW REPLICA STATUS": {Err: errors.New("syntax error")},
+		"SHOW SLAVE STATUS": {
+			Columns: []string{"Slave_IO_State", "Seconds_Behind_Master"},
+			Rows:    [][]driver.Value{{"", nil}},
+		},
+	}})
+	defer db.Close()
+
+	// Act
+	err := New(db, WithMaxReplicationLag(1*time.Minute))(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "mysql replication is not running")
+}
+
+func TestCheckPassesIfNotAReplica(t *testing.T) {
+	// Arrange
+	db := sqltest.Open(sqltest.Config{Results: map[string]sqltest.Result{
+		"SELECT 1":            selectOne,
+		"SHOW REPLICA STATUS": {Columns: []string{"Seconds_Behind_Source"}},
+	}})
+	defer db.Close()
+
+	// Act
+	err := New(db, WithMaxReplicationLag(1*time.Minute))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
@@ -0,0 +1,103 @@
+// Package nats provides a health check for NATS (https://nats.io) that uses the nats.go library.
+package nats
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+type (
+	// Option is a configuration option for New and NewDial.
+	Option func(cfg *config)
+
+	config struct {
+		maxRTT time.Duration
+	}
+
+	// contextDialer dials connections using the deadline of a context.
+	contextDialer struct {
+		ctx context.Context
+	}
+)
+
+// WithMaxRTT configures the check to fail if the round trip time to the server exceeds the provided duration.
+func WithMaxRTT(rtt time.Duration) Option {
+	return func(cfg *config) {
+		cfg.maxRTT = rtt
+	}
+}
+
+// New creates a new NATS health check function that verifies that the provided connection is connected
+// and performs a round trip to the server (PING/PONG). The round trip adheres to the deadline of the
+// context that is passed to the check function.
+func New(conn *nats.Conn, options ...Option) func(ctx context.Context) error {
+	cfg := newConfig(options)
+
+	return func(ctx context.Context) error {
+		return check(ctx, conn, &cfg)
+	}
+}
+
+// NewDial creates a new NATS health check function that opens a new connection to the server at 'url'
+// (e.g., nats://localhost:4222) for each check. Dialing the connection adheres to the deadline of the
+// context that is passed to the check function. Additional connection options (such as credentials)
+// can be passed in 'natsOptions'. Prefer New if your application already holds a connection to the server.
+func NewDial(url string, natsOptions []nats.Option, options ...Option) func(ctx context.Context) error {
+	cfg := newConfig(options)
+
+	return func(ctx context.Context) error {
+		opts := append([]nats.Option{nats.SetCustomDialer(&contextDialer{ctx: ctx}), nats.NoReconnect()}, natsOptions...)
+		if deadline, ok := ctx.Deadline(); ok {
+			opts = append(opts, nats.Timeout(time.Until(deadline)))
+		}
+
+		conn, err := nats.Connect(url, opts...)
+		if err != nil {
+			return fmt.Errorf("cannot connect to nats: %w", err)
+		}
+		defer conn.Close()
+
+		return check(ctx, conn, &cfg)
+	}
+}
+
+func newConfig(options []Option) config {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+func check(ctx context.Context, conn *nats.Conn, cfg *config) error {
+	if status := conn.Status(); status != nats.CONNECTED {
+		return fmt.Errorf("nats connection is %s", status)
+	}
+
+	// The NATS client requires a deadline for the round trip.
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+	}
+
+	start := time.Now()
+	if err := conn.FlushWithContext(ctx); err != nil {
+		return fmt.Errorf("nats round trip failed: %w", err)
+	}
+
+	if rtt := time.Since(start); cfg.maxRTT > 0 && rtt > cfg.maxRTT {
+		return fmt.Errorf("nats round trip time of %s exceeds %s", rtt, cfg.maxRTT)
+	}
+
+	return nil
+}
+
+// Dial implements nats.CustomDialer.
+func (d *contextDialer) Dial(network, address string) (net.Conn, error) {
+	return (&net.Dialer{}).DialContext(d.ctx, network, address)
+}
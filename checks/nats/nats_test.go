@@ -0,0 +1,86 @@
+package nats
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer starts a minimal NATS server that only supports the handshake and PING/PONG.
+// Each PONG is delayed by the provided duration.
+func startServer(t *testing.T, delay time.Duration) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = conn.Write([]byte(`INFO {"server_id":"test","version":"2.10.0","max_payload":1048576}` + "\r\n"))
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if strings.HasPrefix(line, "PING") {
+						time.Sleep(delay)
+						_, _ = conn.Write([]byte("PONG\r\n"))
+					}
+				}
+			}()
+		}
+	}()
+
+	return "nats://" + listener.Addr().String()
+}
+
+func TestCheckWithMaxRTT(t *testing.T) {
+	// Arrange
+	url := startServer(t, 0)
+	conn, err := nats.Connect(url)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// Act
+	err = New(conn, WithMaxRTT(1*time.Second))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestDialCheckFailsIfRTTExceedsThreshold(t *testing.T) {
+	// Arrange
+	url := startServer(t, 100*time.Millisecond)
+
+	// Act
+	err := NewDial(url, []nats.Option{nats.Timeout(5 * time.Second)}, WithMaxRTT(50*time.Millisecond))(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "exceeds 50ms")
+}
+
+func TestCheckFailsIfConnectionIsClosed(t *testing.T) {
+	// Arrange
+	conn, err := nats.Connect(startServer(t, 0))
+	require.NoError(t, err)
+	conn.Close()
+
+	// Act
+	err = New(conn)(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "nats connection is CLOSED")
+}
@@ -0,0 +1,14 @@
+module github.com/alexliesenfeld/health/checks/neo4j
+
+go 1.18
+
+require (
+	github.com/neo4j/neo4j-go-driver/v5 v5.28.4
+	github.com/stretchr/testify v1.8.4
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
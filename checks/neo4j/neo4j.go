@@ -0,0 +1,93 @@
+// Package neo4j provides a health check for Neo4j (https://neo4j.com) that uses the official driver.
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		database string
+		role     string
+	}
+)
+
+// WithDatabase sets the name of the database that the check runs against.
+// By default, the default database of the server is used.
+func WithDatabase(name string) Option {
+	return func(cfg *config) {
+		cfg.database = name
+	}
+}
+
+// WithClusterRole configures the check to fail if the server does not have the provided role for the
+// database in a causal cluster (e.g., "LEADER", "FOLLOWER" or "READ_REPLICA"). The role is read using
+// the procedure dbms.cluster.role. If no database is configured (see WithDatabase), the role for the
+// database "neo4j" is validated.
+func WithClusterRole(role string) Option {
+	return func(cfg *config) {
+		cfg.role = role
+	}
+}
+
+// New creates a new Neo4j health check function that runs the query "RETURN 1". The query is
+// aborted when the context passed to the check function is done (e.g., because the check timed out).
+func New(driver neo4j.DriverWithContext, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		session := driver.NewSession(ctx, neo4j.SessionConfig{
+			AccessMode:   neo4j.AccessModeRead,
+			DatabaseName: cfg.database,
+		})
+		defer session.Close(ctx)
+
+		result, err := session.Run(ctx, "RETURN 1", nil)
+		if err == nil {
+			_, err = result.Consume(ctx)
+		}
+		if err != nil {
+			return fmt.Errorf("neo4j probe query failed: %w", err)
+		}
+
+		if cfg.role != "" {
+			return checkClusterRole(ctx, session, &cfg)
+		}
+
+		return nil
+	}
+}
+
+func checkClusterRole(ctx context.Context, session neo4j.SessionWithContext, cfg *config) error {
+	database := cfg.database
+	if database == "" {
+		database = "neo4j"
+	}
+
+	result, err := session.Run(ctx, "CALL dbms.cluster.role($database)", map[string]any{"database": database})
+	if err != nil {
+		return fmt.Errorf("cannot read neo4j cluster role: %w", err)
+	}
+
+	record, err := result.Single(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot read neo4j cluster role: %w", err)
+	}
+
+	role, _ := record.Values[0].(string)
+	if !strings.EqualFold(role, cfg.role) {
+		return fmt.Errorf("neo4j cluster role for database %s is %q, expected %q", database, role, cfg.role)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package neo4j
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+)
+
+type driverMock struct {
+	neo4j.DriverWithContext
+	session sessionMock
+}
+
+func (d *driverMock) NewSession(ctx context.Context, config neo4j.SessionConfig) neo4j.SessionWithContext {
+	d.session.config = config
+	return &d.session
+}
+
+type sessionMock struct {
+	neo4j.SessionWithContext
+	config  neo4j.SessionConfig
+	results map[string]*resultMock
+	closed  bool
+}
+
+func (s *sessionMock) Run(ctx context.Context, cypher string, params map[string]any, configurers ...func(*neo4j.TransactionConfig)) (neo4j.ResultWithContext, error) {
+	result, ok := s.results[cypher]
+	if !ok {
+		return nil, errors.New("unexpected query")
+	}
+	return result, nil
+}
+
+func (s *sessionMock) Close(ctx context.Context) error {
+	s.closed = true
+	return nil
+}
+
+type resultMock struct {
+	neo4j.ResultWithContext
+	record *neo4j.Record
+	err    error
+}
+
+func (r *resultMock) Consume(ctx context.Context) (neo4j.ResultSummary, error) {
+	return nil, r.err
+}
+
+func (r *resultMock) Single(ctx context.Context) (*neo4j.Record, error) {
+	return r.record, r.err
+}
+
+func TestCheckWithClusterRole(t *testing.T) {
+	// Arrange
+	driver := driverMock{session: sessionMock{results: map[string]*resultMock{
+		"RETURN 1":                          {},
+		"CALL dbms.cluster.role($database)": {record: &neo4j.Record{Keys: []string{"role"}, Values: []any{"FOLLOWER"}}},
+	}}}
+
+	// Act
+	follower := New(&driver, WithDatabase("movies"), WithClusterRole("follower"))(context.Background())
+	leader := New(&driver, WithClusterRole("LEADER"))(context.Background())
+
+	// Assert
+	assert.NoError(t, follower)
+	assert.EqualError(t, leader, "neo4j cluster role for database neo4j is \"FOLLOWER\", expected \"LEADER\"")
+	assert.Equal(t, neo4j.AccessModeRead, driver.session.config.AccessMode)
+	assert.True(t, driver.session.closed)
+}
+
+func TestCheckFailsIfProbeQueryFails(t *testing.T) {
+	// Arrange
+	driver := driverMock{session: sessionMock{results: map[string]*resultMock{
+		"RETURN 1": {err: errors.New("connection refused")},
+	}}}
+
+	// Act
+	err := New(&driver)(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "neo4j probe query failed: connection refused")
+}
@@ -0,0 +1,138 @@
+// Package ntp provides a health check that verifies that the local clock is synchronized with an NTP server.
+package ntp
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/alexliesenfeld/health/internal/netutil"
+)
+
+const (
+	packetSize = 48
+
+	leapNotSynchronized = 3
+	modeClient          = 3
+	modeServer          = 4
+	version             = 4
+)
+
+// ntpEpochOffset is the number of seconds between the NTP epoch (1900) and the Unix epoch (1970).
+const ntpEpochOffset = 2208988800
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		maxOffset time.Duration
+	}
+)
+
+// WithMaxOffset sets the largest difference between the local clock and the clock of the NTP server
+// that is still considered healthy. Default is 1 second.
+func WithMaxOffset(offset time.Duration) Option {
+	return func(cfg *config) {
+		cfg.maxOffset = offset
+	}
+}
+
+// New creates a new NTP health check function that queries the NTP server at 'server'
+// (e.g., "pool.ntp.org" or "time.example.com:123") and fails if the offset of the local clock exceeds
+// the threshold (see WithMaxOffset) or if the server is not synchronized itself. The query adheres to
+// the deadline of the context that is passed to the check function.
+func New(server string, options ...Option) func(ctx context.Context) error {
+	cfg := config{maxOffset: 1 * time.Second}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	address := server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		address = net.JoinHostPort(server, "123")
+	}
+
+	return func(ctx context.Context) error {
+		offset, err := query(ctx, address)
+		if err != nil {
+			if ctx.Err() != nil {
+				// Report the cause instead of the resulting I/O timeout.
+				err = ctx.Err()
+			}
+			return fmt.Errorf("cannot query ntp server %s: %w", server, err)
+		}
+
+		if offset < -cfg.maxOffset || offset > cfg.maxOffset {
+			return fmt.Errorf("clock offset of %v to ntp server %s exceeds %v", offset, server, cfg.maxOffset)
+		}
+
+		return nil
+	}
+}
+
+// query sends a client request (RFC 5905) and returns the offset of the local clock to the clock
+// of the server.
+func query(ctx context.Context, address string) (time.Duration, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", address)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	// Abort waiting for the response when the context is done.
+	defer netutil.InterruptOnDone(ctx, conn)()
+
+	request := make([]byte, packetSize)
+	request[0] = version<<3 | modeClient
+	originTime := time.Now()
+	binary.BigEndian.PutUint64(request[40:], toTimestamp(originTime))
+
+	if _, err := conn.Write(request); err != nil {
+		return 0, err
+	}
+
+	response := make([]byte, packetSize)
+	for {
+		n, err := conn.Read(response)
+		if err != nil {
+			return 0, err
+		}
+		destinationTime := time.Now()
+
+		// Ignore responses that do not belong to the request (e.g., late responses to earlier requests).
+		if n < packetSize || binary.BigEndian.Uint64(response[24:]) != binary.BigEndian.Uint64(request[40:]) {
+			continue
+		}
+
+		if mode := response[0] & 0x7; mode != modeServer {
+			return 0, fmt.Errorf("unexpected mode %d in response", mode)
+		}
+		if stratum := response[1]; stratum == 0 {
+			return 0, fmt.Errorf("server sent kiss-of-death code %q", string(response[12:16]))
+		}
+		if leap := response[0] >> 6; leap == leapNotSynchronized {
+			return 0, fmt.Errorf("server clock is not synchronized")
+		}
+
+		receiveTime := fromTimestamp(binary.BigEndian.Uint64(response[32:]))
+		transmitTime := fromTimestamp(binary.BigEndian.Uint64(response[40:]))
+
+		return (receiveTime.Sub(originTime) + transmitTime.Sub(destinationTime)) / 2, nil
+	}
+}
+
+func toTimestamp(t time.Time) uint64 {
+	seconds := uint64(t.Unix() + ntpEpochOffset)
+	fraction := uint64(t.Nanosecond()) << 32 / uint64(time.Second)
+	return seconds<<32 | fraction
+}
+
+func fromTimestamp(ts uint64) time.Time {
+	seconds := int64(ts>>32) - ntpEpochOffset
+	nanos := int64((ts & 0xffffffff) * uint64(time.Second) >> 32)
+	return time.Unix(seconds, nanos)
+}
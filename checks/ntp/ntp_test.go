@@ -0,0 +1,77 @@
+package ntp
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer starts an NTP server whose clock is ahead of the local clock by 'offset'.
+// If 'stratum' is 0, the server responds with a kiss-of-death packet.
+func startServer(t *testing.T, offset time.Duration, stratum byte) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, packetSize)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if n < packetSize {
+				continue
+			}
+			now := toTimestamp(time.Now().Add(offset))
+			response := make([]byte, packetSize)
+			response[0] = version<<3 | modeServer
+			response[1] = stratum
+			copy(response[12:16], "RATE")
+			copy(response[24:32], buf[40:48])
+			binary.BigEndian.PutUint64(response[32:], now)
+			binary.BigEndian.PutUint64(response[40:], now)
+			_, _ = conn.WriteTo(response, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestCheck(t *testing.T) {
+	// Arrange
+	server := startServer(t, 0, 2)
+
+	// Act
+	err := New(server, WithMaxOffset(100*time.Millisecond))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestCheckFailsIfOffsetExceedsThreshold(t *testing.T) {
+	// Arrange
+	server := startServer(t, 5*time.Second, 2)
+
+	// Act
+	err := New(server)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "exceeds 1s")
+}
+
+func TestCheckFailsOnKissOfDeath(t *testing.T) {
+	// Arrange
+	server := startServer(t, 0, 0)
+
+	// Act
+	err := New(server)(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "cannot query ntp server "+server+": server sent kiss-of-death code \"RATE\"")
+}
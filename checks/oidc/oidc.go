@@ -0,0 +1,167 @@
+// Package oidc provides a health check for OpenID Connect identity providers that validates the
+// discovery document and the JSON Web Key Set (JWKS) of the provider.
+package oidc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		client *http.Client
+	}
+
+	discoveryDocument struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+
+	jsonWebKey struct {
+		KeyID   string `json:"kid"`
+		KeyType string `json:"kty"`
+		Curve   string `json:"crv"`
+		N       string `json:"n"`
+		E       string `json:"e"`
+		X       string `json:"x"`
+		Y       string `json:"y"`
+	}
+)
+
+// coordinateSizes holds the size of the coordinates (in bytes) of the supported elliptic curves.
+var coordinateSizes = map[string]int{"P-256": 32, "P-384": 48, "P-521": 66, "Ed25519": 32}
+
+// WithHTTPClient sets the http.Client that is used to call the identity provider.
+// By default, http.DefaultClient is used.
+func WithHTTPClient(client *http.Client) Option {
+	return func(cfg *config) {
+		cfg.client = client
+	}
+}
+
+// New creates a new OpenID Connect health check function that fetches the discovery document of
+// 'issuer' (e.g., https://accounts.example.com) from {issuer}/.well-known/openid-configuration and the
+// JWKS that it references. The check fails if the issuer in the discovery document does not match,
+// if the JWKS contains no keys or if any of the keys cannot be parsed.
+func New(issuer string, options ...Option) func(ctx context.Context) error {
+	cfg := config{client: http.DefaultClient}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	discoveryURL := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+
+	return func(ctx context.Context) error {
+		var discovery discoveryDocument
+		if err := get(ctx, &cfg, discoveryURL, &discovery); err != nil {
+			return fmt.Errorf("cannot get openid configuration: %w", err)
+		}
+
+		if discovery.Issuer != issuer {
+			return fmt.Errorf("openid configuration has issuer %q, expected %q", discovery.Issuer, issuer)
+		}
+		if discovery.JWKSURI == "" {
+			return fmt.Errorf("openid configuration has no jwks_uri")
+		}
+
+		var jwks struct {
+			Keys []jsonWebKey `json:"keys"`
+		}
+		if err := get(ctx, &cfg, discovery.JWKSURI, &jwks); err != nil {
+			return fmt.Errorf("cannot get JWKS: %w", err)
+		}
+
+		if len(jwks.Keys) == 0 {
+			return fmt.Errorf("JWKS at %s contains no keys", discovery.JWKSURI)
+		}
+		for _, key := range jwks.Keys {
+			if err := validateKey(&key); err != nil {
+				return fmt.Errorf("JWKS key %q is invalid: %w", key.KeyID, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+func validateKey(key *jsonWebKey) error {
+	switch key.KeyType {
+	case "RSA":
+		n, err := decodeInt(key.N)
+		if err != nil || n.BitLen() < 1024 {
+			return fmt.Errorf("invalid RSA modulus")
+		}
+		e, err := decodeInt(key.E)
+		if err != nil || e.Cmp(big.NewInt(1)) <= 0 {
+			return fmt.Errorf("invalid RSA exponent")
+		}
+	case "EC":
+		if err := validateCoordinate(key.Curve, key.X); err != nil {
+			return err
+		}
+		if err := validateCoordinate(key.Curve, key.Y); err != nil {
+			return err
+		}
+	case "OKP":
+		if key.Curve != "Ed25519" {
+			return fmt.Errorf("unsupported curve %q", key.Curve)
+		}
+		if err := validateCoordinate(key.Curve, key.X); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported key type %q", key.KeyType)
+	}
+
+	return nil
+}
+
+func validateCoordinate(curve, coordinate string) error {
+	size, ok := coordinateSizes[curve]
+	if !ok {
+		return fmt.Errorf("unsupported curve %q", curve)
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(coordinate)
+	if err != nil || len(data) != size {
+		return fmt.Errorf("invalid %s coordinate", curve)
+	}
+
+	return nil
+}
+
+func decodeInt(s string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+func get(ctx context.Context, cfg *config, url string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := cfg.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	return json.NewDecoder(res.Body).Decode(result)
+}
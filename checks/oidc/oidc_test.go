@@ -0,0 +1,79 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func newServer(t *testing.T, keys []map[string]string) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(map[string]string{"issuer": server.URL, "jwks_uri": server.URL + "/keys"})
+		case "/keys":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCheck(t *testing.T) {
+	// Arrange
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	server := newServer(t, []map[string]string{
+		{"kid": "rsa-1", "kty": "RSA", "n": encode(rsaKey.N.Bytes()), "e": encode(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{"kid": "ec-1", "kty": "EC", "crv": "P-256", "x": encode(ecKey.X.FillBytes(make([]byte, 32))), "y": encode(ecKey.Y.FillBytes(make([]byte, 32)))},
+	})
+
+	// Act
+	err = New(server.URL)(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestCheckFailsIfIssuerDoesNotMatch(t *testing.T) {
+	// Arrange
+	server := newServer(t, nil)
+
+	// Act
+	err := New(server.URL + "/")(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "openid configuration has issuer \""+server.URL+"\", expected \""+server.URL+"/\"")
+}
+
+func TestCheckFailsIfKeyIsInvalid(t *testing.T) {
+	// Arrange
+	server := newServer(t, []map[string]string{{"kid": "ec-1", "kty": "EC", "crv": "P-256", "x": "AQAB", "y": "AQAB"}})
+
+	// Act
+	err := New(server.URL)(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "JWKS key \"ec-1\" is invalid: invalid P-256 coordinate")
+}
@@ -0,0 +1,73 @@
+// Package oracle provides a health check for Oracle databases that are accessed using the database/sql
+// package (e.g., with the godror or go-ora drivers).
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		maxTablespaceUsage float64
+	}
+)
+
+// WithMaxTablespaceUsage configures the check to fail if the usage of any tablespace exceeds the provided
+// percentage (between 0 and 100). The usage is read from the view dba_tablespace_usage_metrics.
+// Attention: The database user requires the privilege to read this view (e.g., SELECT_CATALOG_ROLE).
+func WithMaxTablespaceUsage(percent float64) Option {
+	return func(cfg *config) {
+		cfg.maxTablespaceUsage = percent
+	}
+}
+
+// New creates a new Oracle health check function that executes "SELECT 1 FROM dual".
+func New(db *sql.DB, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		var one int
+		if err := db.QueryRowContext(ctx, "SELECT 1 FROM dual").Scan(&one); err != nil {
+			return fmt.Errorf("oracle probe query failed: %w", err)
+		}
+
+		if cfg.maxTablespaceUsage > 0 {
+			return checkTablespaceUsage(ctx, db, cfg.maxTablespaceUsage)
+		}
+
+		return nil
+	}
+}
+
+func checkTablespaceUsage(ctx context.Context, db *sql.DB, maxUsage float64) error {
+	rows, err := db.QueryContext(ctx,
+		"SELECT tablespace_name, used_percent FROM dba_tablespace_usage_metrics ORDER BY used_percent DESC")
+	if err != nil {
+		return fmt.Errorf("cannot read oracle tablespace usage: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			name  string
+			usage float64
+		)
+		if err := rows.Scan(&name, &usage); err != nil {
+			return fmt.Errorf("cannot read oracle tablespace usage: %w", err)
+		}
+
+		if usage > maxUsage {
+			return fmt.Errorf("oracle tablespace %s is %.1f%% full, which exceeds %.1f%%", name, usage, maxUsage)
+		}
+	}
+
+	return rows.Err()
+}
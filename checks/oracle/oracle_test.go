@@ -0,0 +1,42 @@
+package oracle
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/alexliesenfeld/health/internal/sqltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckWithMaxTablespaceUsage(t *testing.T) {
+	// Arrange
+	db := sqltest.Open(sqltest.Config{Results: map[string]sqltest.Result{
+		"SELECT 1 FROM dual": {Columns: []string{"1"}, Rows: [][]driver.Value{{int64(1)}}},
+		"SELECT tablespace_name, used_percent FROM dba_tablespace_usage_metrics ORDER BY used_percent DESC": {
+			Columns: []string{"tablespace_name", "used_percent"},
+			Rows:    [][]driver.Value{{"USERS", 91.25}, {"SYSTEM", 63.5}},
+		},
+	}})
+	defer db.Close()
+
+	// Act
+	withinLimit := New(db, WithMaxTablespaceUsage(95))(context.Background())
+	exceedingLimit := New(db, WithMaxTablespaceUsage(90))(context.Background())
+
+	// Assert
+	assert.NoError(t, withinLimit)
+	assert.EqualError(t, exceedingLimit, "oracle tablespace USERS is 91.2% full, which exceeds 90.0%")
+}
+
+func TestCheckFailsIfProbeQueryFails(t *testing.T) {
+	// Arrange
+	db := sqltest.Open(sqltest.Config{})
+	defer db.Close()
+
+	// Act
+	err := New(db)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "oracle probe query failed")
+}
@@ -0,0 +1,153 @@
+// Package pop3 provides a health check for POP3 servers.
+package pop3
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/alexliesenfeld/health/internal/netutil"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		implicitTLS *tls.Config
+		startTLS    *tls.Config
+		username    string
+		password    string
+	}
+
+	// client sends commands over a POP3 connection.
+	client struct {
+		conn   net.Conn
+		reader *bufio.Reader
+	}
+)
+
+// WithTLS configures the check to connect using implicit TLS (usually on port 995).
+func WithTLS(tlsConfig *tls.Config) Option {
+	return func(cfg *config) {
+		cfg.implicitTLS = tlsConfig
+	}
+}
+
+// WithStartTLS configures the check to upgrade the connection using the STLS command.
+func WithStartTLS(tlsConfig *tls.Config) Option {
+	return func(cfg *config) {
+		cfg.startTLS = tlsConfig
+	}
+}
+
+// WithLogin configures the check to log in using the USER and PASS commands. Use a TLS connection
+// (see WithTLS and WithStartTLS) to not send the password in plain text.
+func WithLogin(username, password string) Option {
+	return func(cfg *config) {
+		cfg.username = username
+		cfg.password = password
+	}
+}
+
+// New creates a new POP3 health check function that connects to the server at 'address'
+// (e.g., "mail.example.com:110"), validates the greeting (and optionally performs STLS and logs in)
+// and quits. The check adheres to the deadline of the context that is passed to the check function.
+func New(address string, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		if err := check(ctx, &cfg, address); err != nil {
+			if ctx.Err() != nil {
+				// Report the cause instead of the resulting I/O timeout.
+				err = ctx.Err()
+			}
+			return fmt.Errorf("pop3 server %s is not available: %w", address, err)
+		}
+		return nil
+	}
+}
+
+func check(ctx context.Context, cfg *config, address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	var conn net.Conn
+	if cfg.implicitTLS != nil {
+		dialer := tls.Dialer{Config: netutil.WithServerName(cfg.implicitTLS, host)}
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	} else {
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return err
+	}
+
+	c := client{conn: conn, reader: bufio.NewReader(conn)}
+	// The connection may be replaced by STLS, so close the current one.
+	defer func() { c.conn.Close() }()
+
+	// Interrupt pending reads and writes when the context is done.
+	defer netutil.InterruptOnDone(ctx, conn)()
+
+	if _, err := c.response(); err != nil {
+		return fmt.Errorf("unexpected greeting: %w", err)
+	}
+
+	if cfg.startTLS != nil {
+		if err := c.command("STLS"); err != nil {
+			return fmt.Errorf("STLS failed: %w", err)
+		}
+		tlsConn := tls.Client(conn, netutil.WithServerName(cfg.startTLS, host))
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return err
+		}
+		c = client{conn: tlsConn, reader: bufio.NewReader(tlsConn)}
+	}
+
+	if cfg.username != "" {
+		if err := c.command("USER " + cfg.username); err != nil {
+			return fmt.Errorf("login failed: %w", err)
+		}
+		if err := c.command("PASS " + cfg.password); err != nil {
+			return fmt.Errorf("login failed: %w", err)
+		}
+	}
+
+	return c.command("QUIT")
+}
+
+func (c *client) command(command string) error {
+	if _, err := c.conn.Write([]byte(command + "\r\n")); err != nil {
+		return err
+	}
+	_, err := c.response()
+	return err
+}
+
+func (c *client) response() (string, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+
+	line = strings.TrimRight(line, "\r\n")
+	if !strings.HasPrefix(line, "+OK") {
+		return "", errors.New(line)
+	}
+
+	return line, nil
+}
+
+// withServerName returns a copy of the TLS configuration that verifies the provided host name,
+// unless the configuration sets a server name already.
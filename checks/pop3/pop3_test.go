@@ -0,0 +1,90 @@
+package pop3
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer starts a minimal POP3 server that supports STLS, USER, PASS and QUIT. Login succeeds for
+// the user "health" with the password "secret". It returns the address of the server and a TLS client
+// configuration that trusts the certificate of the server.
+func startServer(t *testing.T) (string, *tls.Config) {
+	certServer := httptest.NewTLSServer(http.NotFoundHandler())
+	t.Cleanup(certServer.Close)
+	serverConfig := certServer.TLS.Clone()
+	pool := x509.NewCertPool()
+	pool.AddCert(certServer.Certificate())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				_, _ = conn.Write([]byte("+OK POP3 server ready\r\n"))
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					switch line = strings.TrimSpace(line); {
+					case line == "STLS":
+						_, _ = conn.Write([]byte("+OK Begin TLS negotiation\r\n"))
+						tlsConn := tls.Server(conn, serverConfig)
+						conn, reader = tlsConn, bufio.NewReader(tlsConn)
+					case line == "USER health":
+						_, _ = conn.Write([]byte("+OK\r\n"))
+					case line == "PASS secret":
+						_, _ = conn.Write([]byte("+OK Logged in\r\n"))
+					case line == "QUIT":
+						_, _ = conn.Write([]byte("+OK Bye\r\n"))
+						return
+					default:
+						_, _ = conn.Write([]byte("-ERR Authentication failed\r\n"))
+					}
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String(), &tls.Config{RootCAs: pool}
+}
+
+func TestCheckWithStartTLSAndLogin(t *testing.T) {
+	// Arrange
+	address, tlsConfig := startServer(t)
+
+	// Act
+	err := New(address, WithStartTLS(tlsConfig), WithLogin("health", "secret"))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestCheckFailsIfLoginFails(t *testing.T) {
+	// Arrange
+	address, _ := startServer(t)
+
+	// Act
+	err := New(address, WithLogin("health", "wrong"))(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "pop3 server "+address+" is not available: login failed: -ERR Authentication failed")
+}
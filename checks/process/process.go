@@ -0,0 +1,235 @@
+//go:build linux
+
+// Package process provides health checks that verify that a local process is running, which is useful
+// for applications that depend on a sidecar (e.g., a local Envoy proxy). The checks read the proc
+// file system and are therefore only available on Linux.
+package process
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Option is a configuration option for NewPort.
+	Option func(cfg *config)
+
+	config struct {
+		name string
+	}
+)
+
+// procDir is the mount point of the proc file system.
+const procDir = "/proc"
+
+// WithName configures the check created by NewPort to additionally verify that the port is owned by
+// a process with the provided executable name. Determining the owner of a socket requires permission
+// to inspect the file descriptors of the process, i.e., the process must run as the same user or
+// the check must run with elevated privileges (e.g., CAP_SYS_PTRACE).
+func WithName(name string) Option {
+	return func(cfg *config) {
+		cfg.name = name
+	}
+}
+
+// NewPIDFile creates a new health check function that reads a process ID from the file at 'path'
+// (e.g., "/run/envoy.pid") and fails if the file does not exist or the process is not running.
+func NewPIDFile(path string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("cannot read PID file: %w", err)
+		}
+
+		pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+		if err != nil || pid <= 0 {
+			return fmt.Errorf("PID file %s does not contain a valid process ID", path)
+		}
+
+		if !isRunning(pid) {
+			return fmt.Errorf("process %d from PID file %s is not running", pid, path)
+		}
+
+		return nil
+	}
+}
+
+// NewName creates a new health check function that fails if no process with the executable name
+// 'name' (e.g., "envoy") is running. The name is compared with the base name of the first command
+// line argument and with the command name of the process.
+func NewName(name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		pids, err := listPIDs()
+		if err != nil {
+			return err
+		}
+
+		for _, pid := range pids {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if processName(pid, name) == name && isRunning(pid) {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("no process with name %s is running", name)
+	}
+}
+
+// NewPort creates a new health check function that fails if no process is listening on TCP 'port'
+// (IPv4 or IPv6). Use WithName to verify that the port is owned by the expected process.
+func NewPort(port int, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		inodes, err := listeningSockets(port)
+		if err != nil {
+			return err
+		}
+		if len(inodes) == 0 {
+			return fmt.Errorf("no process is listening on port %d", port)
+		}
+
+		if cfg.name == "" {
+			return nil
+		}
+
+		pid, err := socketOwner(ctx, inodes)
+		if err != nil {
+			return err
+		}
+		if pid == 0 {
+			return fmt.Errorf("cannot determine the process that listens on port %d", port)
+		}
+		if actual := processName(pid, cfg.name); actual != cfg.name {
+			return fmt.Errorf("port %d is owned by process %d (%s) instead of %s", port, pid, actual, cfg.name)
+		}
+
+		return nil
+	}
+}
+
+func listPIDs() ([]int, error) {
+	entries, err := os.ReadDir(procDir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list processes: %w", err)
+	}
+
+	var pids []int
+	for _, entry := range entries {
+		if pid, err := strconv.Atoi(entry.Name()); err == nil && entry.IsDir() {
+			pids = append(pids, pid)
+		}
+	}
+
+	return pids, nil
+}
+
+// isRunning reports whether the process exists and is not a zombie.
+func isRunning(pid int) bool {
+	stat, err := os.ReadFile(filepath.Join(procDir, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return false
+	}
+
+	// The state follows the command name, which is enclosed in parentheses and may contain spaces.
+	i := bytes.LastIndexByte(stat, ')')
+	if i < 0 || i+2 >= len(stat) {
+		return false
+	}
+	state := stat[i+2]
+
+	return state != 'Z' && state != 'X'
+}
+
+// processName returns 'expected' if it equals the base name of the first command line argument or
+// the command name of the process, and the command name otherwise. The command name is truncated to
+// 15 characters by the kernel, while the command line argument is not available for kernel threads.
+func processName(pid int, expected string) string {
+	dir := filepath.Join(procDir, strconv.Itoa(pid))
+
+	if cmdline, err := os.ReadFile(filepath.Join(dir, "cmdline")); err == nil && len(cmdline) > 0 {
+		argv0 := string(bytes.SplitN(cmdline, []byte{0}, 2)[0])
+		if filepath.Base(argv0) == expected {
+			return expected
+		}
+	}
+
+	comm, _ := os.ReadFile(filepath.Join(dir, "comm"))
+	return strings.TrimSpace(string(comm))
+}
+
+// listeningSockets returns the inodes of the TCP sockets that listen on the provided port.
+func listeningSockets(port int) (map[string]bool, error) {
+	const stateListen = "0A"
+
+	inodes := map[string]bool{}
+	for _, file := range []string{"tcp", "tcp6"} {
+		content, err := os.ReadFile(filepath.Join(procDir, "net", file))
+		if errors.Is(err, os.ErrNotExist) {
+			continue // IPv6 is disabled.
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot list sockets: %w", err)
+		}
+
+		// Skip the header line. The fields are: sl local_address rem_address st tx_queue:rx_queue
+		// tr:tm->when retrnsmt uid timeout inode ...
+		lines := strings.Split(string(content), "\n")
+		for _, line := range lines[1:] {
+			fields := strings.Fields(line)
+			if len(fields) < 10 || fields[3] != stateListen {
+				continue
+			}
+			_, hexPort, _ := strings.Cut(fields[1], ":")
+			if p, err := strconv.ParseInt(hexPort, 16, 32); err == nil && int(p) == port {
+				inodes[fields[9]] = true
+			}
+		}
+	}
+
+	return inodes, nil
+}
+
+// socketOwner returns the ID of the first process that has a file descriptor for one of the sockets
+// or 0 if no such process is visible.
+func socketOwner(ctx context.Context, inodes map[string]bool) (int, error) {
+	pids, err := listPIDs()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, pid := range pids {
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+
+		fdDir := filepath.Join(procDir, strconv.Itoa(pid), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue // The process has exited or belongs to another user.
+		}
+
+		for _, fd := range fds {
+			target, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+			if err != nil || !strings.HasPrefix(target, "socket:[") {
+				continue
+			}
+			if inodes[strings.TrimSuffix(strings.TrimPrefix(target, "socket:["), "]")] {
+				return pid, nil
+			}
+		}
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,72 @@
+//go:build linux
+
+package process
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPIDFile(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	running := filepath.Join(dir, "running.pid")
+	require.NoError(t, os.WriteFile(running, []byte(strconv.Itoa(os.Getpid())+"\n"), 0o600))
+	stopped := filepath.Join(dir, "stopped.pid")
+	require.NoError(t, os.WriteFile(stopped, []byte("4194305"), 0o600)) // Exceeds the maximum PID on Linux.
+
+	// Act
+	runningErr := NewPIDFile(running)(context.Background())
+	stoppedErr := NewPIDFile(stopped)(context.Background())
+	missingErr := NewPIDFile(filepath.Join(dir, "missing.pid"))(context.Background())
+
+	// Assert
+	assert.NoError(t, runningErr)
+	assert.EqualError(t, stoppedErr, "process 4194305 from PID file "+stopped+" is not running")
+	assert.ErrorIs(t, missingErr, os.ErrNotExist)
+}
+
+func TestNewName(t *testing.T) {
+	// Arrange
+	name := filepath.Base(os.Args[0])
+
+	// Act
+	runningErr := NewName(name)(context.Background())
+	missingErr := NewName("health-missing-process")(context.Background())
+
+	// Assert
+	assert.NoError(t, runningErr)
+	assert.EqualError(t, missingErr, "no process with name health-missing-process is running")
+}
+
+func TestNewPort(t *testing.T) {
+	// Arrange
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedPort := closed.Addr().(*net.TCPAddr).Port
+	closed.Close()
+
+	// Act
+	listening := NewPort(port)(context.Background())
+	owned := NewPort(port, WithName(filepath.Base(os.Args[0])))(context.Background())
+	ownedByOther := NewPort(port, WithName("envoy"))(context.Background())
+	notListening := NewPort(closedPort)(context.Background())
+
+	// Assert
+	assert.NoError(t, listening)
+	assert.NoError(t, owned)
+	assert.ErrorContains(t, ownedByOther, "instead of envoy")
+	assert.EqualError(t, notListening, "no process is listening on port "+strconv.Itoa(closedPort))
+}
@@ -0,0 +1,70 @@
+// Package pubsub provides a health check for Google Cloud Pub/Sub topics and subscriptions.
+package pubsub
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/pubsub"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		topics        []string
+		subscriptions []string
+	}
+)
+
+// WithTopic configures the check to verify that the topic with the provided ID exists in the project
+// of the client. This option can be used multiple times.
+func WithTopic(id string) Option {
+	return func(cfg *config) {
+		cfg.topics = append(cfg.topics, id)
+	}
+}
+
+// WithSubscription configures the check to verify that the subscription with the provided ID exists
+// in the project of the client. This option can be used multiple times.
+func WithSubscription(id string) Option {
+	return func(cfg *config) {
+		cfg.subscriptions = append(cfg.subscriptions, id)
+	}
+}
+
+// New creates a new Pub/Sub health check function that verifies that the topics and subscriptions that
+// are configured using WithTopic and WithSubscription exist. The check fails if Pub/Sub is not reachable
+// or the credentials of the client do not grant the "pubsub.topics.get" and "pubsub.subscriptions.get"
+// permissions. All requests adhere to the deadline of the context that is passed to the check function.
+func New(client *pubsub.Client, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		for _, id := range cfg.topics {
+			exists, err := client.Topic(id).Exists(ctx)
+			if err != nil {
+				return fmt.Errorf("cannot get topic %s: %w", id, err)
+			}
+			if !exists {
+				return fmt.Errorf("topic %s does not exist", id)
+			}
+		}
+
+		for _, id := range cfg.subscriptions {
+			exists, err := client.Subscription(id).Exists(ctx)
+			if err != nil {
+				return fmt.Errorf("cannot get subscription %s: %w", id, err)
+			}
+			if !exists {
+				return fmt.Errorf("subscription %s does not exist", id)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,61 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// newClient returns a client for a fake Pub/Sub server that contains the topic "orders"
+// and its subscription "orders-worker".
+func newClient(t *testing.T) *pubsub.Client {
+	ctx := context.Background()
+
+	server := pstest.NewServer()
+	t.Cleanup(func() { server.Close() })
+
+	conn, err := grpc.Dial(server.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	client, err := pubsub.NewClient(ctx, "project", option.WithGRPCConn(conn))
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	topic, err := client.CreateTopic(ctx, "orders")
+	require.NoError(t, err)
+	_, err = client.CreateSubscription(ctx, "orders-worker", pubsub.SubscriptionConfig{Topic: topic})
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestCheck(t *testing.T) {
+	// Arrange
+	client := newClient(t)
+
+	// Act
+	err := New(client, WithTopic("orders"), WithSubscription("orders-worker"))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestCheckFailsIfTopicOrSubscriptionDoesNotExist(t *testing.T) {
+	// Arrange
+	client := newClient(t)
+
+	// Act
+	missingTopic := New(client, WithTopic("payments"))(context.Background())
+	missingSubscription := New(client, WithTopic("orders"), WithSubscription("orders-audit"))(context.Background())
+
+	// Assert
+	assert.EqualError(t, missingTopic, "topic payments does not exist")
+	assert.EqualError(t, missingSubscription, "subscription orders-audit does not exist")
+}
@@ -0,0 +1,51 @@
+// Package pulsar provides a health check for Apache Pulsar (https://pulsar.apache.org) that uses the
+// official Go client library.
+package pulsar
+
+import (
+	"context"
+	"fmt"
+)
+
+type (
+	// Client is the subset of the Pulsar client API that is used by the check.
+	// It is implemented by pulsar.Client.
+	Client interface {
+		TopicPartitions(topic string) ([]string, error)
+	}
+
+	lookupResult struct {
+		partitions []string
+		err        error
+	}
+)
+
+// New creates a new Pulsar health check function that looks up the partitions of the provided topic
+// (e.g., "persistent://public/default/orders"). This verifies that the client can reach the brokers and
+// that the topic exists (if the namespace does not allow automatic topic creation).
+// The Pulsar client does not support contexts. The check therefore returns as soon as the context that
+// is passed to the check function is done, while the lookup itself is bound by the operation timeout
+// of the client (see pulsar.ClientOptions.OperationTimeout).
+func New(client Client, topic string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		// The channel is buffered, so that the goroutine can finish even if the check returned already.
+		result := make(chan lookupResult, 1)
+		go func() {
+			partitions, err := client.TopicPartitions(topic)
+			result <- lookupResult{partitions: partitions, err: err}
+		}()
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("cannot look up pulsar topic %q: %w", topic, ctx.Err())
+		case res := <-result:
+			if res.err != nil {
+				return fmt.Errorf("cannot look up pulsar topic %q: %w", topic, res.err)
+			}
+			if len(res.partitions) == 0 {
+				return fmt.Errorf("pulsar topic %q has no partitions", topic)
+			}
+			return nil
+		}
+	}
+}
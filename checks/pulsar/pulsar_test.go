@@ -0,0 +1,59 @@
+package pulsar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Client = (pulsar.Client)(nil)
+
+type clientMock struct {
+	delay      time.Duration
+	partitions []string
+	err        error
+}
+
+func (c *clientMock) TopicPartitions(topic string) ([]string, error) {
+	time.Sleep(c.delay)
+	return c.partitions, c.err
+}
+
+func TestCheck(t *testing.T) {
+	// Arrange
+	client := clientMock{partitions: []string{"persistent://public/default/orders-partition-0"}}
+
+	// Act
+	err := New(&client, "persistent://public/default/orders")(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestCheckFailsIfLookupFails(t *testing.T) {
+	// Arrange
+	client := clientMock{err: errors.New("connection refused")}
+
+	// Act
+	err := New(&client, "orders")(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "cannot look up pulsar topic \"orders\": connection refused")
+}
+
+func TestCheckAdheresToContextDeadline(t *testing.T) {
+	// Arrange
+	client := clientMock{delay: 1 * time.Second}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := New(&client, "orders")(ctx)
+
+	// Assert
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,73 @@
+package rabbitmq
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Connection = (*amqp.Connection)(nil)
+
+type closedConnection struct{}
+
+func (c closedConnection) IsClosed() bool                  { return true }
+func (c closedConnection) Channel() (*amqp.Channel, error) { return nil, amqp.ErrClosed }
+
+func TestCheckFailsIfConnectionIsClosed(t *testing.T) {
+	// Act
+	err := New(closedConnection{})(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "rabbitmq connection is closed")
+}
+
+func TestDialCheckAdheresToContextDeadline(t *testing.T) {
+	// Arrange
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	// The server accepts connections but never answers the AMQP handshake.
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	// Act
+	start := time.Now()
+	err = NewDial("amqp://guest:guest@" + listener.Addr().String() + "/")(ctx)
+
+	// Assert
+	assert.ErrorContains(t, err, "cannot connect to rabbitmq")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+// TestDialCheckWithQueue requires a running broker. The test is skipped if the environment
+// variable RABBITMQ_URL is not set.
+func TestDialCheckWithQueue(t *testing.T) {
+	// Arrange
+	url := os.Getenv("RABBITMQ_URL")
+	if url == "" {
+		t.Skip("RABBITMQ_URL is not set")
+	}
+
+	// Act
+	err := NewDial(url, WithQueue("health-check-queue-that-does-not-exist"))(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "cannot declare rabbitmq queue")
+}
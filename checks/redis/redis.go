@@ -0,0 +1,84 @@
+// Package redis provides a health check for Redis (https://redis.io).
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		probeKey string
+		probeTTL time.Duration
+	}
+)
+
+// WithProbeKey configures the check to additionally write a value into the provided key and to read it
+// back afterwards. This verifies that Redis accepts writes (e.g., that it is not a read-only replica and
+// that it has not reached its memory limit). The key expires after 1 minute.
+func WithProbeKey(key string) Option {
+	return func(cfg *config) {
+		cfg.probeKey = key
+	}
+}
+
+// New creates a new Redis health check function that sends a PING command to Redis.
+// If the client is a *redis.ClusterClient, the PING command is sent to every node of the cluster
+// and the check fails if any of them does not respond.
+func New(client redis.UniversalClient, options ...Option) func(ctx context.Context) error {
+	cfg := config{probeTTL: 1 * time.Minute}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		if cluster, ok := client.(*redis.ClusterClient); ok {
+			if err := cluster.ForEachShard(ctx, func(ctx context.Context, shard *redis.Client) error {
+				return ping(ctx, shard)
+			}); err != nil {
+				return err
+			}
+		} else if err := ping(ctx, client); err != nil {
+			return err
+		}
+
+		if cfg.probeKey != "" {
+			return probe(ctx, client, &cfg)
+		}
+
+		return nil
+	}
+}
+
+func ping(ctx context.Context, client redis.Cmdable) error {
+	if err := client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("redis ping failed: %w", err)
+	}
+	return nil
+}
+
+func probe(ctx context.Context, client redis.Cmdable, cfg *config) error {
+	value := strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	if err := client.Set(ctx, cfg.probeKey, value, cfg.probeTTL).Err(); err != nil {
+		return fmt.Errorf("cannot write redis probe key %q: %w", cfg.probeKey, err)
+	}
+
+	actual, err := client.Get(ctx, cfg.probeKey).Result()
+	if err != nil {
+		return fmt.Errorf("cannot read redis probe key %q: %w", cfg.probeKey, err)
+	}
+
+	if actual != value {
+		return fmt.Errorf("redis probe key %q contains unexpected value %q", cfg.probeKey, actual)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package redis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckWithProbeKey(t *testing.T) {
+	// Arrange
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	// Act
+	err := New(client, WithProbeKey("health:probe"))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, server.Exists("health:probe"))
+}
+
+func TestCheckFailsIfRedisIsUnavailable(t *testing.T) {
+	// Arrange
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	defer client.Close()
+	server.Close()
+
+	// Act
+	err := New(client)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "redis ping failed")
+}
@@ -0,0 +1,95 @@
+// Package s3 provides a health check for Amazon S3 and S3-compatible object storage (e.g., MinIO)
+// that uses the AWS SDK for Go v2.
+package s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+type (
+	// Client is the subset of the S3 client API that is used by the check.
+	// It is implemented by *s3.Client.
+	Client interface {
+		HeadBucket(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error)
+		PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+		GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+		DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+	}
+
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		probeKey string
+	}
+)
+
+// WithProbeObject configures the check to additionally upload a small object with the provided key,
+// to download it again and to delete it afterwards. This verifies that the credentials permit writes
+// and that the storage accepts them (e.g., that a quota has not been exceeded).
+func WithProbeObject(key string) Option {
+	return func(cfg *config) {
+		cfg.probeKey = key
+	}
+}
+
+// New creates a new S3 health check function that sends a HeadBucket request for 'bucket', which fails
+// if the bucket does not exist or the credentials of the client do not grant access to it.
+// To check S3-compatible storage, configure the client accordingly
+// (e.g., with s3.Options.BaseEndpoint and s3.Options.UsePathStyle for MinIO).
+func New(client Client, bucket string, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		if _, err := client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: &bucket}); err != nil {
+			return fmt.Errorf("cannot access bucket %s: %w", bucket, err)
+		}
+
+		if cfg.probeKey != "" {
+			return probe(ctx, client, bucket, cfg.probeKey)
+		}
+
+		return nil
+	}
+}
+
+func probe(ctx context.Context, client Client, bucket, key string) error {
+	content := []byte(strconv.FormatInt(time.Now().UnixNano(), 10))
+
+	if _, err := client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: &bucket,
+		Key:    &key,
+		Body:   bytes.NewReader(content),
+	}); err != nil {
+		return fmt.Errorf("cannot upload probe object %s to bucket %s: %w", key, bucket, err)
+	}
+
+	res, err := client.GetObject(ctx, &s3.GetObjectInput{Bucket: &bucket, Key: &key})
+	if err != nil {
+		return fmt.Errorf("cannot download probe object %s from bucket %s: %w", key, bucket, err)
+	}
+	actual, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return fmt.Errorf("cannot download probe object %s from bucket %s: %w", key, bucket, err)
+	}
+	if !bytes.Equal(actual, content) {
+		return fmt.Errorf("probe object %s in bucket %s has unexpected content %q", key, bucket, actual)
+	}
+
+	if _, err := client.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: &bucket, Key: &key}); err != nil {
+		return fmt.Errorf("cannot delete probe object %s from bucket %s: %w", key, bucket, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package s3
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Client = (*s3.Client)(nil)
+
+// newClient returns a client for a minimal S3-compatible server that contains the bucket "health"
+// and records the requests that it receives.
+func newClient(t *testing.T) (*s3.Client, *[]string) {
+	var mu sync.Mutex
+	var requests []string
+	objects := map[string][]byte{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		if bucket != "health" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodHead:
+		case http.MethodPut:
+			objects[key], _ = io.ReadAll(r.Body)
+		case http.MethodGet:
+			_, _ = w.Write(objects[key])
+		case http.MethodDelete:
+			delete(objects, key)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(server.URL),
+		UsePathStyle: true,
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "minio", SecretAccessKey: "minio123"}, nil
+		}),
+	})
+
+	return client, &requests
+}
+
+func TestCheckWithProbeObject(t *testing.T) {
+	// Arrange
+	client, requests := newClient(t)
+
+	// Act
+	err := New(client, "health", WithProbeObject("probe"))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"HEAD /health",
+		"PUT /health/probe",
+		"GET /health/probe",
+		"DELETE /health/probe",
+	}, *requests)
+}
+
+func TestCheckFailsIfBucketDoesNotExist(t *testing.T) {
+	// Arrange
+	client, _ := newClient(t)
+
+	// Act
+	err := New(client, "missing")(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "cannot access bucket missing")
+}
@@ -0,0 +1,20 @@
+module github.com/alexliesenfeld/health/checks/sftp
+
+go 1.20
+
+require (
+	github.com/alexliesenfeld/health v0.0.0
+	github.com/pkg/sftp v1.13.9
+	github.com/stretchr/testify v1.8.4
+	golang.org/x/crypto v0.31.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/kr/fs v0.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.28.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/alexliesenfeld/health => ../..
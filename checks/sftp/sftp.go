@@ -0,0 +1,99 @@
+// Package sftp provides a health check for SFTP servers that uses the github.com/pkg/sftp client library.
+package sftp
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/alexliesenfeld/health/internal/netutil"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		statPath string
+		listPath string
+	}
+)
+
+// WithStat configures the check to fail if the provided path does not exist.
+func WithStat(path string) Option {
+	return func(cfg *config) {
+		cfg.statPath = path
+	}
+}
+
+// WithList configures the check to list the directory at the provided path.
+func WithList(path string) Option {
+	return func(cfg *config) {
+		cfg.listPath = path
+	}
+}
+
+// New creates a new SFTP health check function that connects to the server at 'address'
+// (e.g., "files.example.com:22") using the provided SSH client configuration (which holds the
+// credentials and the host key callback), starts an SFTP session and optionally stats or lists a path.
+// The check adheres to the deadline of the context that is passed to the check function.
+func New(address string, sshConfig *ssh.ClientConfig, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		if err := check(ctx, &cfg, address, sshConfig); err != nil {
+			if ctx.Err() != nil {
+				// Report the cause instead of the resulting I/O timeout.
+				err = ctx.Err()
+			}
+			return fmt.Errorf("sftp server %s is not available: %w", address, err)
+		}
+		return nil
+	}
+}
+
+func check(ctx context.Context, cfg *config, address string, sshConfig *ssh.ClientConfig) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Interrupt pending requests when the context is done.
+	defer netutil.InterruptOnDone(ctx, conn)()
+
+	clientConn, channels, requests, err := ssh.NewClientConn(conn, address, sshConfig)
+	if err != nil {
+		return err
+	}
+	sshClient := ssh.NewClient(clientConn, channels, requests)
+	defer sshClient.Close()
+
+	client, err := sftp.NewClient(sshClient)
+	if err != nil {
+		return fmt.Errorf("cannot start sftp session: %w", err)
+	}
+	defer client.Close()
+
+	if cfg.statPath != "" {
+		if _, err := client.Stat(cfg.statPath); err != nil {
+			return fmt.Errorf("cannot stat %q: %w", cfg.statPath, err)
+		}
+	}
+
+	if cfg.listPath != "" {
+		if _, err := client.ReadDir(cfg.listPath); err != nil {
+			return fmt.Errorf("cannot list %q: %w", cfg.listPath, err)
+		}
+	}
+
+	// Perform at least one request to verify that the server processes requests.
+	_, err = client.Getwd()
+	return err
+}
@@ -0,0 +1,111 @@
+package sftp
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// startServer starts an SFTP server that serves the local file system and accepts the user "health"
+// with the password "secret".
+func startServer(t *testing.T) (string, ssh.PublicKey) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostKey, err := ssh.NewSignerFromKey(key)
+	require.NoError(t, err)
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(meta ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if meta.User() == "health" && string(password) == "secret" {
+				return &ssh.Permissions{}, nil
+			}
+			return nil, os.ErrPermission
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, channels, requests, err := ssh.NewServerConn(conn, config)
+				if err != nil {
+					return
+				}
+				go ssh.DiscardRequests(requests)
+				for newChannel := range channels {
+					channel, channelRequests, err := newChannel.Accept()
+					if err != nil {
+						return
+					}
+					go func() {
+						for req := range channelRequests {
+							_ = req.Reply(req.Type == "subsystem" && string(req.Payload[4:]) == "sftp", nil)
+						}
+					}()
+					server, err := sftp.NewServer(channel)
+					if err != nil {
+						return
+					}
+					_ = server.Serve()
+					_ = channel.Close()
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String(), hostKey.PublicKey()
+}
+
+func TestCheckWithStatAndList(t *testing.T) {
+	// Arrange
+	address, hostKey := startServer(t)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "orders.csv"), []byte("id\n"), 0o600))
+	sshConfig := &ssh.ClientConfig{
+		User:            "health",
+		Auth:            []ssh.AuthMethod{ssh.Password("secret")},
+		HostKeyCallback: ssh.FixedHostKey(hostKey),
+	}
+
+	// Act
+	existing := New(address, sshConfig, WithStat(filepath.Join(dir, "orders.csv")), WithList(dir))(context.Background())
+	missing := New(address, sshConfig, WithStat(filepath.Join(dir, "invoices.csv")))(context.Background())
+
+	// Assert
+	assert.NoError(t, existing)
+	assert.ErrorContains(t, missing, "cannot stat")
+}
+
+func TestCheckFailsIfAuthenticationFails(t *testing.T) {
+	// Arrange
+	address, hostKey := startServer(t)
+	sshConfig := &ssh.ClientConfig{
+		User:            "health",
+		Auth:            []ssh.AuthMethod{ssh.Password("wrong")},
+		HostKeyCallback: ssh.FixedHostKey(hostKey),
+	}
+
+	// Act
+	err := New(address, sshConfig)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "unable to authenticate")
+}
@@ -0,0 +1,128 @@
+// Package smtp provides a health check for SMTP servers.
+package smtp
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+
+	"github.com/alexliesenfeld/health/internal/netutil"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		localName   string
+		implicitTLS *tls.Config
+		startTLS    *tls.Config
+		auth        smtp.Auth
+	}
+)
+
+// WithLocalName sets the host name that is sent to the server with the EHLO command. Default is "localhost".
+func WithLocalName(name string) Option {
+	return func(cfg *config) {
+		cfg.localName = name
+	}
+}
+
+// WithTLS configures the check to connect using implicit TLS (usually on port 465).
+func WithTLS(tlsConfig *tls.Config) Option {
+	return func(cfg *config) {
+		cfg.implicitTLS = tlsConfig
+	}
+}
+
+// WithStartTLS configures the check to upgrade the connection using the STARTTLS command. The check fails
+// if the server does not support STARTTLS.
+func WithStartTLS(tlsConfig *tls.Config) Option {
+	return func(cfg *config) {
+		cfg.startTLS = tlsConfig
+	}
+}
+
+// WithAuth configures the check to authenticate using the provided mechanism (e.g., smtp.PlainAuth).
+// Most mechanisms require a TLS connection (see WithTLS and WithStartTLS).
+func WithAuth(auth smtp.Auth) Option {
+	return func(cfg *config) {
+		cfg.auth = auth
+	}
+}
+
+// New creates a new SMTP health check function that connects to the server at 'address'
+// (e.g., "mail.example.com:587"), sends EHLO (and optionally performs STARTTLS and AUTH) and quits.
+// The check adheres to the deadline of the context that is passed to the check function.
+func New(address string, options ...Option) func(ctx context.Context) error {
+	cfg := config{localName: "localhost"}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		if err := check(ctx, &cfg, address); err != nil {
+			if ctx.Err() != nil {
+				// Report the cause instead of the resulting I/O timeout.
+				err = ctx.Err()
+			}
+			return fmt.Errorf("smtp server %s is not available: %w", address, err)
+		}
+		return nil
+	}
+}
+
+func check(ctx context.Context, cfg *config, address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	var conn net.Conn
+	if cfg.implicitTLS != nil {
+		dialer := tls.Dialer{Config: netutil.WithServerName(cfg.implicitTLS, host)}
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	} else {
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Interrupt pending reads and writes when the context is done.
+	defer netutil.InterruptOnDone(ctx, conn)()
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.Hello(cfg.localName); err != nil {
+		return err
+	}
+
+	if cfg.startTLS != nil {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("server does not support STARTTLS")
+		}
+		if err := client.StartTLS(netutil.WithServerName(cfg.startTLS, host)); err != nil {
+			return err
+		}
+	}
+
+	if cfg.auth != nil {
+		if err := client.Auth(cfg.auth); err != nil {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+	}
+
+	return client.Quit()
+}
+
+// withServerName returns a copy of the TLS configuration that verifies the provided host name,
+// unless the configuration sets a server name already.
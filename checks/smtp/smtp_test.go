@@ -0,0 +1,86 @@
+package smtp
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"net"
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer starts a minimal SMTP server that supports EHLO, AUTH PLAIN and QUIT.
+// Authentication succeeds for the user "health" with the password "secret".
+func startServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	validCredentials := base64.StdEncoding.EncodeToString([]byte("\x00health\x00secret"))
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				_, _ = conn.Write([]byte("220 mail.example.com ESMTP\r\n"))
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					switch fields := strings.Fields(line); strings.ToUpper(fields[0]) {
+					case "EHLO":
+						_, _ = conn.Write([]byte("250-mail.example.com\r\n250 AUTH PLAIN\r\n"))
+					case "AUTH":
+						if len(fields) == 3 && fields[2] == validCredentials {
+							_, _ = conn.Write([]byte("235 2.7.0 Authentication successful\r\n"))
+						} else {
+							_, _ = conn.Write([]byte("535 5.7.8 Authentication credentials invalid\r\n"))
+						}
+					case "QUIT":
+						_, _ = conn.Write([]byte("221 2.0.0 Bye\r\n"))
+						return
+					default:
+						_, _ = conn.Write([]byte("502 5.5.2 Command not recognized\r\n"))
+					}
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestCheckWithAuth(t *testing.T) {
+	// Arrange
+	address := startServer(t)
+
+	// Act
+	valid := New(address, WithAuth(smtp.PlainAuth("", "health", "secret", "127.0.0.1")))(context.Background())
+	invalid := New(address, WithAuth(smtp.PlainAuth("", "health", "wrong", "127.0.0.1")))(context.Background())
+
+	// Assert
+	assert.NoError(t, valid)
+	assert.ErrorContains(t, invalid, "authentication failed: 535")
+}
+
+func TestCheckFailsIfStartTLSIsNotSupported(t *testing.T) {
+	// Arrange
+	address := startServer(t)
+
+	// Act
+	err := New(address, WithStartTLS(&tls.Config{}))(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "smtp server "+address+" is not available: server does not support STARTTLS")
+}
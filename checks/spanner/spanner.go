@@ -0,0 +1,92 @@
+// Package spanner provides a health check for Google Cloud Spanner databases.
+package spanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		sessionTimeout time.Duration
+	}
+)
+
+// WithSessionTimeout configures the check to fail with a dedicated error if the session pool of the
+// client cannot provide a session within the provided duration, which is the case if all sessions are
+// checked out (e.g., because of a session leak or because MaxOpened is too low for the load).
+// Without this option, an exhausted session pool is only reported when the context of the check is done.
+func WithSessionTimeout(d time.Duration) Option {
+	return func(cfg *config) {
+		cfg.sessionTimeout = d
+	}
+}
+
+// New creates a new Cloud Spanner health check function that executes "SELECT 1" in a single-use
+// read-only transaction. The query adheres to the deadline of the context that is passed to the
+// check function.
+func New(client *spanner.Client, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		queryCtx := ctx
+		if cfg.sessionTimeout > 0 {
+			var cancel context.CancelFunc
+			queryCtx, cancel = context.WithTimeout(ctx, cfg.sessionTimeout)
+			defer cancel()
+		}
+
+		err := query(queryCtx, client)
+		if err != nil && ctx.Err() == nil && isSessionTimeout(err) {
+			return fmt.Errorf("spanner session pool is exhausted, no session available within %v: %w",
+				cfg.sessionTimeout, err)
+		}
+		if err != nil {
+			return fmt.Errorf("spanner query failed: %w", err)
+		}
+
+		return nil
+	}
+}
+
+func query(ctx context.Context, client *spanner.Client) error {
+	iter := client.Single().Query(ctx, spanner.Statement{SQL: "SELECT 1"})
+	defer iter.Stop()
+
+	row, err := iter.Next()
+	if errors.Is(err, iterator.Done) {
+		return errors.New("query returned no rows")
+	}
+	if err != nil {
+		return err
+	}
+
+	var value int64
+	if err := row.Columns(&value); err != nil {
+		return err
+	}
+	if value != 1 {
+		return fmt.Errorf("query returned %d instead of 1", value)
+	}
+
+	return nil
+}
+
+// isSessionTimeout reports whether the error was returned because no session could be taken from
+// the session pool before the context was done. The client does not provide a sentinel error for
+// this case, so the description of the error is inspected.
+func isSessionTimeout(err error) bool {
+	return spanner.ErrCode(err) != 0 && strings.Contains(spanner.ErrDesc(err), "during getting session")
+}
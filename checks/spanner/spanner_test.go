@@ -0,0 +1,64 @@
+package spanner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/spannertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// newClient returns a client for an in-memory fake of Cloud Spanner that uses at most one session.
+func newClient(t *testing.T) *spanner.Client {
+	server, err := spannertest.NewServer("localhost:0")
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	client, err := spanner.NewClientWithConfig(context.Background(),
+		"projects/project/instances/instance/databases/database",
+		spanner.ClientConfig{SessionPoolConfig: spanner.SessionPoolConfig{MaxOpened: 1}},
+		option.WithEndpoint(server.Addr),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	return client
+}
+
+func TestCheck(t *testing.T) {
+	// Arrange
+	client := newClient(t)
+
+	// Act
+	err := New(client, WithSessionTimeout(1*time.Second))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestCheckFailsIfSessionPoolIsExhausted(t *testing.T) {
+	// Arrange
+	client := newClient(t)
+
+	// Check out the only session of the pool.
+	tx := client.ReadOnlyTransaction()
+	defer tx.Close()
+	iter := tx.Query(context.Background(), spanner.Statement{SQL: "SELECT 1"})
+	_, err := iter.Next()
+	require.NoError(t, err)
+	iter.Stop()
+
+	// Act
+	err = New(client, WithSessionTimeout(100*time.Millisecond))(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "spanner session pool is exhausted, no session available within 100ms")
+}
@@ -0,0 +1,66 @@
+// Package sql provides a driver-agnostic health check for SQL databases that are accessed using the
+// database/sql package (e.g., MySQL, MariaDB, PostgreSQL, CockroachDB or SQL Server).
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		query    string
+		expected *string
+	}
+)
+
+// WithQuery configures a validation query that is executed after the database has been pinged
+// (e.g., "SELECT 1"). The check fails if the query fails or does not return any rows.
+func WithQuery(query string) Option {
+	return func(cfg *config) {
+		cfg.query = query
+	}
+}
+
+// WithExpectedResult configures the value that the first column of the first row returned by the
+// validation query (see WithQuery) is expected to have. The value is compared by its string
+// representation (e.g., "1" for the numeric value 1). A NULL value is represented as an empty string.
+func WithExpectedResult(expected string) Option {
+	return func(cfg *config) {
+		cfg.expected = &expected
+	}
+}
+
+// New creates a new SQL database health check function that pings the database and optionally
+// executes a validation query (see WithQuery).
+func New(db *sql.DB, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		if err := db.PingContext(ctx); err != nil {
+			return fmt.Errorf("cannot ping database: %w", err)
+		}
+
+		if cfg.query == "" {
+			return nil
+		}
+
+		var value sql.NullString
+		if err := db.QueryRowContext(ctx, cfg.query).Scan(&value); err != nil {
+			return fmt.Errorf("validation query failed: %w", err)
+		}
+
+		if cfg.expected != nil && value.String != *cfg.expected {
+			return fmt.Errorf("validation query returned %q, expected %q", value.String, *cfg.expected)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,51 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/alexliesenfeld/health/internal/sqltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckWithValidationQuery(t *testing.T) {
+	// Arrange
+	db := sqltest.Open(sqltest.Config{Results: map[string]sqltest.Result{
+		"SELECT 1": {Columns: []string{"1"}, Rows: [][]driver.Value{{int64(1)}}},
+	}})
+	defer db.Close()
+
+	// Act
+	err := New(db, WithQuery("SELECT 1"), WithExpectedResult("1"))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestCheckFailsOnUnexpectedResult(t *testing.T) {
+	// Arrange
+	db := sqltest.Open(sqltest.Config{Results: map[string]sqltest.Result{
+		"SELECT status FROM app_state": {Columns: []string{"status"}, Rows: [][]driver.Value{{"maintenance"}}},
+	}})
+	defer db.Close()
+
+	// Act
+	err := New(db, WithQuery("SELECT status FROM app_state"), WithExpectedResult("ok"))(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "validation query returned \"maintenance\", expected \"ok\"")
+}
+
+func TestCheckFailsIfPingFails(t *testing.T) {
+	// Arrange
+	db := sqltest.Open(sqltest.Config{PingErr: errors.New("connection refused")})
+	defer db.Close()
+
+	// Act
+	err := New(db)(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "cannot ping database: connection refused")
+}
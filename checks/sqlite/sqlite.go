@@ -0,0 +1,102 @@
+// Package sqlite provides a health check for SQLite databases that are accessed using the database/sql
+// package (e.g., with the mattn/go-sqlite3 or modernc.org/sqlite drivers).
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		quickCheck bool
+		maxErrors  int
+		maxBytes   int64
+	}
+)
+
+// WithQuickCheck configures the check to verify the integrity of the database using PRAGMA quick_check.
+// Because this reads the whole database, it can take a long time for large databases. To keep the check
+// within a budget, 'maxErrors' limits the number of reported errors (rows) and 'maxBytes' skips the
+// integrity check if the database is larger than the provided number of bytes (0 means no limit).
+func WithQuickCheck(maxErrors int, maxBytes int64) Option {
+	return func(cfg *config) {
+		cfg.quickCheck = true
+		cfg.maxErrors = maxErrors
+		cfg.maxBytes = maxBytes
+	}
+}
+
+// New creates a new SQLite health check function that pings the database and reads the schema version
+// from the database file header. This verifies that the database file is reachable and readable.
+func New(db *sql.DB, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+	if cfg.maxErrors <= 0 {
+		cfg.maxErrors = 1
+	}
+
+	return func(ctx context.Context) error {
+		if err := db.PingContext(ctx); err != nil {
+			return fmt.Errorf("cannot ping sqlite database: %w", err)
+		}
+
+		var version int64
+		if err := db.QueryRowContext(ctx, "PRAGMA schema_version").Scan(&version); err != nil {
+			return fmt.Errorf("cannot read sqlite database: %w", err)
+		}
+
+		if cfg.quickCheck {
+			return quickCheck(ctx, db, &cfg)
+		}
+
+		return nil
+	}
+}
+
+func quickCheck(ctx context.Context, db *sql.DB, cfg *config) error {
+	if cfg.maxBytes > 0 {
+		var size int64
+		err := db.QueryRowContext(ctx,
+			"SELECT page_count * page_size FROM pragma_page_count(), pragma_page_size()").Scan(&size)
+		if err != nil {
+			return fmt.Errorf("cannot read sqlite database size: %w", err)
+		}
+		if size > cfg.maxBytes {
+			return nil
+		}
+	}
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("PRAGMA quick_check(%d)", cfg.maxErrors))
+	if err != nil {
+		return fmt.Errorf("sqlite integrity check failed: %w", err)
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var problem string
+		if err := rows.Scan(&problem); err != nil {
+			return fmt.Errorf("sqlite integrity check failed: %w", err)
+		}
+		if problem != "ok" {
+			problems = append(problems, problem)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("sqlite integrity check failed: %w", err)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("sqlite database is corrupt: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/alexliesenfeld/health/internal/sqltest"
+	"github.com/stretchr/testify/assert"
+)
+
+const sizeQuery = "SELECT page_count * page_size FROM pragma_page_count(), pragma_page_size()"
+
+var schemaVersion = sqltest.Result{Columns: []string{"schema_version"}, Rows: [][]driver.Value{{int64(3)}}}
+
+func TestCheckWithQuickCheck(t *testing.T) {
+	// Arrange
+	db := sqltest.Open(sqltest.Config{Results: map[string]sqltest.Result{
+		"PRAGMA schema_version": schemaVersion,
+		sizeQuery:               {Columns: []string{"size"}, Rows: [][]driver.Value{{int64(4096)}}},
+		"PRAGMA quick_check(2)": {
+			Columns: []string{"quick_check"},
+			Rows:    [][]driver.Value{{"row 1 missing from index idx_orders"}, {"wrong # of entries in index idx_orders"}},
+		},
+	}})
+	defer db.Close()
+
+	// Act
+	err := New(db, WithQuickCheck(2, 1<<20))(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "sqlite database is corrupt: row 1 missing from index idx_orders; "+
+		"wrong # of entries in index idx_orders")
+}
+
+func TestCheckSkipsQuickCheckIfDatabaseExceedsBudget(t *testing.T) {
+	// Arrange
+	db := sqltest.Open(sqltest.Config{Results: map[string]sqltest.Result{
+		"PRAGMA schema_version": schemaVersion,
+		sizeQuery:               {Columns: []string{"size"}, Rows: [][]driver.Value{{int64(1 << 30)}}},
+	}})
+	defer db.Close()
+
+	// Act
+	err := New(db, WithQuickCheck(1, 1<<20))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
@@ -0,0 +1,72 @@
+// Package sqs provides a health check for Amazon SQS queues that uses the AWS SDK for Go v2.
+package sqs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+type (
+	// Client is the subset of the SQS client API that is used by the check.
+	// It is implemented by *sqs.Client.
+	Client interface {
+		GetQueueAttributes(ctx context.Context, params *sqs.GetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error)
+	}
+
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		maxMessages int64
+	}
+)
+
+// WithMaxMessages configures the check to fail if the approximate number of messages that are
+// available for retrieval exceeds the provided threshold (e.g., because consumers cannot keep up).
+// Messages that are in flight or delayed are not taken into account.
+func WithMaxMessages(n int64) Option {
+	return func(cfg *config) {
+		cfg.maxMessages = n
+	}
+}
+
+// New creates a new SQS health check function that requests the attributes of the queue at 'queueURL',
+// which fails if the queue does not exist or the credentials of the client do not grant the
+// "sqs:GetQueueAttributes" permission.
+func New(client Client, queueURL string, options ...Option) func(ctx context.Context) error {
+	cfg := config{maxMessages: -1}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		res, err := client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+			QueueUrl:       &queueURL,
+			AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameApproximateNumberOfMessages},
+		})
+		if err != nil {
+			return fmt.Errorf("cannot get attributes of queue %s: %w", queueURL, err)
+		}
+
+		if cfg.maxMessages < 0 {
+			return nil
+		}
+
+		attribute := res.Attributes[string(types.QueueAttributeNameApproximateNumberOfMessages)]
+		messages, err := strconv.ParseInt(attribute, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid approximate number of messages %q of queue %s", attribute, queueURL)
+		}
+
+		if messages > cfg.maxMessages {
+			return fmt.Errorf("queue %s contains approximately %d messages, which exceeds %d",
+				queueURL, messages, cfg.maxMessages)
+		}
+
+		return nil
+	}
+}
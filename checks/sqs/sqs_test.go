@@ -0,0 +1,59 @@
+package sqs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const queueURL = "https://sqs.eu-central-1.amazonaws.com/123456789012/orders"
+
+var _ Client = (*sqs.Client)(nil)
+
+type clientMock struct {
+	messages string
+	err      error
+}
+
+func (c *clientMock) GetQueueAttributes(_ context.Context, params *sqs.GetQueueAttributesInput, _ ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	attributes := map[string]string{}
+	for _, name := range params.AttributeNames {
+		if name == types.QueueAttributeNameApproximateNumberOfMessages {
+			attributes[string(name)] = c.messages
+		}
+	}
+	return &sqs.GetQueueAttributesOutput{Attributes: attributes}, nil
+}
+
+func TestCheckWithMaxMessages(t *testing.T) {
+	// Arrange
+	client := clientMock{messages: "100"}
+
+	// Act
+	withinLimit := New(&client, queueURL, WithMaxMessages(100))(context.Background())
+	exceedingLimit := New(&client, queueURL, WithMaxMessages(99))(context.Background())
+	withoutLimit := New(&client, queueURL)(context.Background())
+
+	// Assert
+	assert.NoError(t, withinLimit)
+	assert.EqualError(t, exceedingLimit, "queue "+queueURL+" contains approximately 100 messages, which exceeds 99")
+	assert.NoError(t, withoutLimit)
+}
+
+func TestCheckFailsIfQueueDoesNotExist(t *testing.T) {
+	// Arrange
+	notFound := &types.QueueDoesNotExist{}
+	client := clientMock{err: notFound}
+
+	// Act
+	err := New(&client, queueURL)(context.Background())
+
+	// Assert
+	assert.ErrorIs(t, err, notFound)
+}
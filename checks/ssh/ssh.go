@@ -0,0 +1,120 @@
+// Package ssh provides a health check for SSH servers (e.g., bastion hosts) that uses the
+// golang.org/x/crypto/ssh client library.
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/alexliesenfeld/health/internal/netutil"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		hostKeyCallback func() (ssh.HostKeyCallback, error)
+		user            string
+		signer          ssh.Signer
+	}
+)
+
+// WithHostKeyCallback sets the callback that verifies the host key of the server.
+func WithHostKeyCallback(callback ssh.HostKeyCallback) Option {
+	return func(cfg *config) {
+		cfg.hostKeyCallback = func() (ssh.HostKeyCallback, error) { return callback, nil }
+	}
+}
+
+// WithHostKey configures the check to fail if the server does not present the provided host key.
+func WithHostKey(key ssh.PublicKey) Option {
+	return WithHostKeyCallback(ssh.FixedHostKey(key))
+}
+
+// WithKnownHosts configures the check to verify the host key of the server using the provided
+// known_hosts files (e.g., "/home/user/.ssh/known_hosts"). The files are read for each check,
+// so that changes are picked up without restarting the application.
+func WithKnownHosts(files ...string) Option {
+	return func(cfg *config) {
+		cfg.hostKeyCallback = func() (ssh.HostKeyCallback, error) { return knownhosts.New(files...) }
+	}
+}
+
+// WithPublicKeyAuth configures the check to authenticate as 'user' using the provided key.
+// By default, the check does not authenticate and only verifies that the server completes the key
+// exchange and offers authentication.
+func WithPublicKeyAuth(user string, signer ssh.Signer) Option {
+	return func(cfg *config) {
+		cfg.user = user
+		cfg.signer = signer
+	}
+}
+
+// New creates a new SSH health check function that connects to the server at 'address'
+// (e.g., "bastion.example.com:22") and performs an SSH handshake. The check adheres to the deadline
+// of the context that is passed to the check function.
+// By default, any host key is accepted. Use WithHostKey, WithKnownHosts or WithHostKeyCallback to
+// detect servers that present an unexpected host key.
+func New(address string, options ...Option) func(ctx context.Context) error {
+	cfg := config{user: "health"}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		if err := check(ctx, &cfg, address); err != nil {
+			if ctx.Err() != nil {
+				// Report the cause instead of the resulting I/O timeout.
+				err = ctx.Err()
+			}
+			return fmt.Errorf("ssh server %s is not available: %w", address, err)
+		}
+		return nil
+	}
+}
+
+func check(ctx context.Context, cfg *config, address string) error {
+	hostKeyCallback := ssh.InsecureIgnoreHostKey() //nolint:gosec
+	if cfg.hostKeyCallback != nil {
+		callback, err := cfg.hostKeyCallback()
+		if err != nil {
+			return fmt.Errorf("cannot create host key callback: %w", err)
+		}
+		hostKeyCallback = callback
+	}
+
+	var auth []ssh.AuthMethod
+	if cfg.signer != nil {
+		auth = append(auth, ssh.PublicKeys(cfg.signer))
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Interrupt the handshake when the context is done.
+	defer netutil.InterruptOnDone(ctx, conn)()
+
+	clientConn, channels, requests, err := ssh.NewClientConn(conn, address, &ssh.ClientConfig{
+		User:            cfg.user,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+	})
+	if err != nil {
+		// Without credentials, authentication always fails once the key exchange has completed.
+		if cfg.signer == nil && strings.Contains(err.Error(), "unable to authenticate") {
+			return nil
+		}
+		return err
+	}
+
+	return ssh.NewClient(clientConn, channels, requests).Close()
+}
@@ -0,0 +1,101 @@
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func newSigner(t *testing.T) ssh.Signer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(key)
+	require.NoError(t, err)
+	return signer
+}
+
+// startServer starts an SSH server that accepts the provided client key for the user "health".
+func startServer(t *testing.T, hostKey ssh.Signer, clientKey ssh.PublicKey) string {
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if meta.User() == "health" && bytes.Equal(key.Marshal(), clientKey.Marshal()) {
+				return &ssh.Permissions{}, nil
+			}
+			return nil, errors.New("unknown public key")
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				serverConn, channels, requests, err := ssh.NewServerConn(conn, config)
+				if err != nil {
+					return
+				}
+				defer serverConn.Close()
+				go ssh.DiscardRequests(requests)
+				for channel := range channels {
+					_ = channel.Reject(ssh.Prohibited, "no channels allowed")
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestCheckWithPublicKeyAuth(t *testing.T) {
+	// Arrange
+	hostKey, clientKey := newSigner(t), newSigner(t)
+	address := startServer(t, hostKey, clientKey.PublicKey())
+
+	// Act
+	valid := New(address, WithHostKey(hostKey.PublicKey()), WithPublicKeyAuth("health", clientKey))(context.Background())
+	invalid := New(address, WithHostKey(hostKey.PublicKey()), WithPublicKeyAuth("health", newSigner(t)))(context.Background())
+
+	// Assert
+	assert.NoError(t, valid)
+	assert.ErrorContains(t, invalid, "unable to authenticate")
+}
+
+func TestCheckWithoutAuth(t *testing.T) {
+	// Arrange
+	hostKey := newSigner(t)
+	address := startServer(t, hostKey, newSigner(t).PublicKey())
+
+	// Act
+	err := New(address)(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestCheckFailsIfHostKeyDoesNotMatch(t *testing.T) {
+	// Arrange
+	hostKey := newSigner(t)
+	address := startServer(t, hostKey, newSigner(t).PublicKey())
+
+	// Act
+	err := New(address, WithHostKey(newSigner(t).PublicKey()))(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "host key mismatch")
+}
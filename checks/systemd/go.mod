@@ -0,0 +1,17 @@
+module github.com/alexliesenfeld/health/checks/systemd
+
+go 1.18
+
+require (
+	github.com/coreos/go-systemd/v22 v22.5.0
+	github.com/stretchr/testify v1.8.4
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/godbus/dbus/v5 v5.0.4 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/alexliesenfeld/health => ../..
@@ -0,0 +1,80 @@
+//go:build linux
+
+// Package systemd provides a health check for systemd units, which is useful for services that depend
+// on daemons of the host (e.g., chronyd or a local proxy). The check queries systemd over D-Bus and is
+// therefore only available on Linux.
+package systemd
+
+import (
+	"context"
+	"fmt"
+)
+
+type (
+	// Client is the subset of the systemd D-Bus API that is used by the check.
+	// It is implemented by *dbus.Conn of the package github.com/coreos/go-systemd/v22/dbus
+	// (see dbus.NewSystemConnectionContext).
+	Client interface {
+		GetUnitPropertiesContext(ctx context.Context, unit string) (map[string]interface{}, error)
+	}
+
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		subStates []string
+	}
+)
+
+// WithSubState configures the check to additionally require one of the provided sub states,
+// e.g., "running" for service units, which fails for services that have exited but are still
+// considered active (services with RemainAfterExit=yes).
+func WithSubState(states ...string) Option {
+	return func(cfg *config) {
+		cfg.subStates = append(cfg.subStates, states...)
+	}
+}
+
+// New creates a new systemd health check function that fails if 'unit' (e.g., "chronyd.service")
+// is not loaded or not active. The D-Bus call adheres to the deadline of the context that is passed
+// to the check function.
+func New(client Client, unit string, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		properties, err := client.GetUnitPropertiesContext(ctx, unit)
+		if err != nil {
+			return fmt.Errorf("cannot get properties of unit %s: %w", unit, err)
+		}
+
+		loadState, _ := properties["LoadState"].(string)
+		activeState, _ := properties["ActiveState"].(string)
+		subState, _ := properties["SubState"].(string)
+
+		if loadState != "loaded" {
+			return fmt.Errorf("unit %s is not loaded (load state: %s)", unit, loadState)
+		}
+
+		if activeState != "active" {
+			return fmt.Errorf("unit %s is not active (state: %s/%s)", unit, activeState, subState)
+		}
+
+		if len(cfg.subStates) > 0 && !contains(cfg.subStates, subState) {
+			return fmt.Errorf("unit %s has unexpected sub state %s", unit, subState)
+		}
+
+		return nil
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+//go:build linux
+
+package systemd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Client = (*dbus.Conn)(nil)
+
+type clientMock map[string]map[string]interface{}
+
+func (c clientMock) GetUnitPropertiesContext(_ context.Context, unit string) (map[string]interface{}, error) {
+	properties, ok := c[unit]
+	if !ok {
+		// systemd reports units that do not exist as not found instead of returning an error.
+		return map[string]interface{}{"LoadState": "not-found", "ActiveState": "inactive", "SubState": "dead"}, nil
+	}
+	return properties, nil
+}
+
+var client = clientMock{
+	"chronyd.service": {"LoadState": "loaded", "ActiveState": "active", "SubState": "running"},
+	"setup.service":   {"LoadState": "loaded", "ActiveState": "active", "SubState": "exited"},
+	"envoy.service":   {"LoadState": "loaded", "ActiveState": "failed", "SubState": "failed"},
+}
+
+func TestCheck(t *testing.T) {
+	// Act
+	running := New(client, "chronyd.service", WithSubState("running"))(context.Background())
+	exited := New(client, "setup.service")(context.Background())
+
+	// Assert
+	assert.NoError(t, running)
+	assert.NoError(t, exited)
+}
+
+func TestCheckFailsIfUnitIsNotRunning(t *testing.T) {
+	// Act
+	exited := New(client, "setup.service", WithSubState("running"))(context.Background())
+	failed := New(client, "envoy.service")(context.Background())
+	missing := New(client, "proxy.service")(context.Background())
+
+	// Assert
+	assert.EqualError(t, exited, "unit setup.service has unexpected sub state exited")
+	assert.EqualError(t, failed, "unit envoy.service is not active (state: failed/failed)")
+	assert.EqualError(t, missing, "unit proxy.service is not loaded (load state: not-found)")
+}
+
+func TestCheckFailsIfSystemdIsNotReachable(t *testing.T) {
+	// Arrange
+	err := errors.New("dial unix /run/systemd/private: connect: no such file or directory")
+
+	// Act
+	checkErr := New(failingClient{err}, "chronyd.service")(context.Background())
+
+	// Assert
+	assert.ErrorIs(t, checkErr, err)
+}
+
+type failingClient struct{ err error }
+
+func (c failingClient) GetUnitPropertiesContext(context.Context, string) (map[string]interface{}, error) {
+	return nil, c.err
+}
@@ -0,0 +1,112 @@
+// Package tcp provides a health check for services that are reachable over TCP.
+package tcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/alexliesenfeld/health/internal/netutil"
+)
+
+const maxResponseSize = 4096
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		send   []byte
+		expect []byte
+	}
+)
+
+// WithSend configures the check to send the provided data after the connection has been established.
+func WithSend(data []byte) Option {
+	return func(cfg *config) {
+		cfg.send = data
+	}
+}
+
+// WithExpect configures the check to read from the connection (after sending the data of WithSend, if any)
+// until the provided data has been received (e.g., the banner "SSH-2.0-" or "+PONG").
+// The check fails if the connection is closed, the context is done or 4 KiB have been read before
+// the expected data was received.
+func WithExpect(data []byte) Option {
+	return func(cfg *config) {
+		cfg.expect = data
+	}
+}
+
+// New creates a new TCP health check function that connects to 'address' (e.g., "localhost:6379").
+// Connecting and the optional handshake (see WithSend and WithExpect) adhere to the deadline
+// of the context that is passed to the check function.
+func New(address string, options ...Option) func(ctx context.Context) error {
+	cfg := config{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) error {
+		if err := check(ctx, &cfg, address); err != nil {
+			if ctx.Err() != nil && !errors.Is(err, ctx.Err()) {
+				// Report the cause instead of the resulting I/O timeout.
+				err = ctx.Err()
+			}
+			return fmt.Errorf("%s is not available: %w", address, err)
+		}
+		return nil
+	}
+}
+
+func check(ctx context.Context, cfg *config, address string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if len(cfg.send) == 0 && len(cfg.expect) == 0 {
+		return nil
+	}
+
+	// Interrupt pending reads and writes when the context is done. This also covers the deadline of the
+	// context and, unlike a deadline on the connection, guarantees that ctx.Err() reports the cause.
+	defer netutil.InterruptOnDone(ctx, conn)()
+
+	if len(cfg.send) > 0 {
+		if _, err := conn.Write(cfg.send); err != nil {
+			return err
+		}
+	}
+
+	if len(cfg.expect) > 0 {
+		return expect(ctx, conn, cfg.expect)
+	}
+
+	return nil
+}
+
+func expect(ctx context.Context, conn net.Conn, expected []byte) error {
+	received := make([]byte, 0, maxResponseSize)
+	buf := make([]byte, maxResponseSize)
+
+	for len(received) < maxResponseSize {
+		n, err := conn.Read(buf[:maxResponseSize-len(received)])
+		received = append(received, buf[:n]...)
+		if bytes.Contains(received, expected) {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				err = ctx.Err()
+			}
+			return fmt.Errorf("expected response %q, received %q: %w", expected, received, err)
+		}
+	}
+
+	return fmt.Errorf("expected response %q, received %q", expected, received)
+}
@@ -0,0 +1,81 @@
+package tcp
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer starts a server that sends a banner and then answers each "PING" line with "+PONG".
+func startServer(t *testing.T, banner string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = conn.Write([]byte(banner))
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					if scanner.Text() == "PING" {
+						_, _ = conn.Write([]byte("+PONG\r\n"))
+					}
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestCheckWithHandshake(t *testing.T) {
+	// Arrange
+	address := startServer(t, "")
+
+	// Act
+	err := New(address, WithSend([]byte("PING\r\n")), WithExpect([]byte("+PONG")))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestCheckWithBanner(t *testing.T) {
+	// Arrange
+	address := startServer(t, "SSH-2.0-OpenSSH_9.6\r\n")
+
+	// Act
+	matching := New(address, WithExpect([]byte("SSH-2.0-")))(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	mismatching := New(address, WithExpect([]byte("220 ")))(ctx)
+
+	// Assert
+	assert.NoError(t, matching)
+	assert.ErrorIs(t, mismatching, context.DeadlineExceeded)
+}
+
+func TestCheckFailsIfConnectionIsRefused(t *testing.T) {
+	// Arrange
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	// Act
+	err = New(address)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, address+" is not available")
+}
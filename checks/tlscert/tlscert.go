@@ -0,0 +1,142 @@
+// Package tlscert provides a health check that validates TLS certificates and detects certificates
+// that are about to expire.
+package tlscert
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+type (
+	// Option is a configuration option for New and NewFromCertificates.
+	Option func(cfg *config)
+
+	config struct {
+		rootCAs      *x509.CertPool
+		serverName   string
+		expiryWindow time.Duration
+	}
+)
+
+// ErrExpiresSoon is returned (wrapped) by the check if the certificates are valid, but the leaf certificate
+// expires within the expiry window (see WithExpiryWindow). Use errors.Is to distinguish it from invalid
+// certificates, e.g., to report the component as degraded instead of down.
+var ErrExpiresSoon = errors.New("certificate expires soon")
+
+// WithRootCAs sets the pool of root certificates that the certificate chain is verified against.
+// By default, the root certificates of the operating system are used.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(cfg *config) {
+		cfg.rootCAs = pool
+	}
+}
+
+// WithServerName sets the name that the leaf certificate must be valid for. New also sends the name
+// to the server (using SNI). By default, New uses the host of the address and NewFromCertificates
+// does not verify the name.
+func WithServerName(name string) Option {
+	return func(cfg *config) {
+		cfg.serverName = name
+	}
+}
+
+// WithExpiryWindow configures the check to fail if the leaf certificate expires within the provided
+// duration, so that expiring certificates are noticed before clients start to reject them.
+// Default is 7 days.
+func WithExpiryWindow(d time.Duration) Option {
+	return func(cfg *config) {
+		cfg.expiryWindow = d
+	}
+}
+
+// New creates a new TLS certificate health check function that connects to 'address' (e.g.,
+// "example.com:443"), performs a TLS handshake and validates the certificates that are presented
+// by the server. Connecting adheres to the deadline of the context that is passed to the check function.
+func New(address string, options ...Option) func(ctx context.Context) error {
+	cfg := newConfig(options)
+	if cfg.serverName == "" {
+		if host, _, err := net.SplitHostPort(address); err == nil {
+			cfg.serverName = host
+		}
+	}
+
+	return func(ctx context.Context) error {
+		dialer := tls.Dialer{Config: &tls.Config{
+			ServerName: cfg.serverName,
+			// The certificates are verified by the check itself, so that it can report meaningful errors.
+			InsecureSkipVerify: true, //nolint:gosec
+		}}
+
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return fmt.Errorf("cannot connect to %s: %w", address, err)
+		}
+		defer conn.Close()
+
+		return verify(&cfg, conn.(*tls.Conn).ConnectionState().PeerCertificates)
+	}
+}
+
+// NewFromCertificates creates a new TLS certificate health check function that validates the provided
+// certificates (e.g., the certificates that a server loads from disk). The first certificate is the
+// leaf certificate, the remaining certificates are used as intermediates.
+func NewFromCertificates(certs []*x509.Certificate, options ...Option) func(ctx context.Context) error {
+	cfg := newConfig(options)
+
+	return func(ctx context.Context) error {
+		return verify(&cfg, certs)
+	}
+}
+
+func newConfig(options []Option) config {
+	cfg := config{expiryWindow: 7 * 24 * time.Hour}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+func verify(cfg *config, certs []*x509.Certificate) error {
+	if len(certs) == 0 {
+		return fmt.Errorf("no certificates were presented")
+	}
+
+	leaf := certs[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := leaf.Verify(x509.VerifyOptions{
+		DNSName:       cfg.serverName,
+		Roots:         cfg.rootCAs,
+		Intermediates: intermediates,
+	})
+	if err != nil {
+		return fmt.Errorf("certificate %q is invalid: %w", name(leaf), err)
+	}
+
+	if remaining := time.Until(leaf.NotAfter); remaining < cfg.expiryWindow {
+		return fmt.Errorf("%w: certificate %q expires in %d days (at %s)",
+			ErrExpiresSoon, name(leaf), int(remaining.Hours()/24), leaf.NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// name returns a human readable name of the certificate for error messages.
+func name(cert *x509.Certificate) string {
+	switch {
+	case cert.Subject.CommonName != "":
+		return cert.Subject.CommonName
+	case len(cert.DNSNames) > 0:
+		return cert.DNSNames[0]
+	default:
+		return cert.SerialNumber.String()
+	}
+}
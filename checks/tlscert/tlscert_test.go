@@ -0,0 +1,68 @@
+package tlscert
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newServer(t *testing.T) (*httptest.Server, *x509.CertPool) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	return server, pool
+}
+
+func TestCheck(t *testing.T) {
+	// Arrange
+	server, pool := newServer(t)
+	address := strings.TrimPrefix(server.URL, "https://")
+
+	// Act
+	trusted := New(address, WithRootCAs(pool))(context.Background())
+	untrusted := New(address)(context.Background())
+	wrongName := New(address, WithRootCAs(pool), WithServerName("orders.internal"))(context.Background())
+
+	// Assert
+	assert.NoError(t, trusted)
+	assert.ErrorContains(t, untrusted, "certificate signed by unknown authority")
+	assert.ErrorContains(t, wrongName, "not orders.internal")
+}
+
+func TestCheckFailsIfCertificateExpiresWithinWindow(t *testing.T) {
+	// Arrange
+	server, pool := newServer(t)
+	cert := server.Certificate()
+	window := time.Until(cert.NotAfter) + 24*time.Hour
+
+	// Act
+	err := NewFromCertificates([]*x509.Certificate{cert}, WithRootCAs(pool), WithExpiryWindow(window))(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "expires in")
+}
+
+func TestCheckReturnsErrExpiresSoonOnlyForExpiringCertificates(t *testing.T) {
+	// Arrange
+	server, pool := newServer(t)
+	cert := server.Certificate()
+	window := time.Until(cert.NotAfter) + 24*time.Hour
+
+	// Act
+	expiring := NewFromCertificates([]*x509.Certificate{cert}, WithRootCAs(pool), WithExpiryWindow(window))(context.Background())
+	untrusted := NewFromCertificates([]*x509.Certificate{cert}, WithExpiryWindow(window))(context.Background())
+
+	// Assert
+	assert.ErrorIs(t, expiring, ErrExpiresSoon)
+	assert.Error(t, untrusted)
+	assert.NotErrorIs(t, untrusted, ErrExpiresSoon)
+}
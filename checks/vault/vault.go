@@ -0,0 +1,106 @@
+// Package vault provides a health check for HashiCorp Vault (https://www.vaultproject.io) that uses the
+// health API of a Vault server.
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type (
+	// Option is a configuration option for New.
+	Option func(cfg *config)
+
+	config struct {
+		client    *http.Client
+		standbyOK bool
+	}
+
+	healthResponse struct {
+		Initialized        bool   `json:"initialized"`
+		Sealed             bool   `json:"sealed"`
+		Standby            bool   `json:"standby"`
+		PerformanceStandby bool   `json:"performance_standby"`
+		Version            string `json:"version"`
+	}
+)
+
+// WithHTTPClient sets the http.Client that is used to call Vault. By default, http.DefaultClient is used.
+func WithHTTPClient(client *http.Client) Option {
+	return func(cfg *config) {
+		cfg.client = client
+	}
+}
+
+// WithStandbyOK sets whether a Vault server in standby mode (including performance standby mode)
+// is considered healthy. Standby servers forward requests to the active server, so this is usually
+// what clients want, but a check for a specific server of a cluster may want to fail instead.
+// Default is true.
+func WithStandbyOK(ok bool) Option {
+	return func(cfg *config) {
+		cfg.standbyOK = ok
+	}
+}
+
+// New creates a new Vault health check function that calls the health API (GET /v1/sys/health)
+// of the Vault server at 'url' (e.g., https://vault.example.com:8200). The check fails if the
+// server is not initialized or sealed.
+func New(url string, options ...Option) func(ctx context.Context) error {
+	cfg := config{client: http.DefaultClient, standbyOK: true}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	endpoint := strings.TrimSuffix(url, "/") + "/v1/sys/health"
+
+	return func(ctx context.Context) error {
+		health, err := getHealth(ctx, endpoint, &cfg)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case !health.Initialized:
+			return fmt.Errorf("vault is not initialized")
+		case health.Sealed:
+			return fmt.Errorf("vault is sealed")
+		case health.PerformanceStandby && !cfg.standbyOK:
+			return fmt.Errorf("vault is in performance standby mode")
+		case health.Standby && !cfg.standbyOK:
+			return fmt.Errorf("vault is in standby mode")
+		}
+
+		return nil
+	}
+}
+
+func getHealth(ctx context.Context, endpoint string, cfg *config) (*healthResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := cfg.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get vault health: %w", err)
+	}
+	defer res.Body.Close()
+
+	// The health API uses the status code to encode the state of the server (e.g., 503 if it is sealed),
+	// but the response body always contains the details.
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusTooManyRequests, 472, 473, http.StatusNotImplemented, http.StatusServiceUnavailable:
+	default:
+		return nil, fmt.Errorf("cannot get vault health: unexpected status code %d", res.StatusCode)
+	}
+
+	var health healthResponse
+	if err := json.NewDecoder(res.Body).Decode(&health); err != nil {
+		return nil, fmt.Errorf("cannot parse vault health: %w", err)
+	}
+
+	return &health, nil
+}
@@ -0,0 +1,58 @@
+package vault
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newServer(t *testing.T, statusCode int, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/sys/health", r.URL.Path)
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCheckWithStandby(t *testing.T) {
+	// Arrange
+	server := newServer(t, http.StatusTooManyRequests,
+		`{"initialized":true,"sealed":false,"standby":true,"performance_standby":false,"version":"1.15.2"}`)
+
+	// Act
+	standbyOK := New(server.URL)(context.Background())
+	standbyNotOK := New(server.URL, WithStandbyOK(false))(context.Background())
+
+	// Assert
+	assert.NoError(t, standbyOK)
+	assert.EqualError(t, standbyNotOK, "vault is in standby mode")
+}
+
+func TestCheckFailsIfSealed(t *testing.T) {
+	// Arrange
+	server := newServer(t, http.StatusServiceUnavailable,
+		`{"initialized":true,"sealed":true,"standby":true,"performance_standby":false,"version":"1.15.2"}`)
+
+	// Act
+	err := New(server.URL)(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "vault is sealed")
+}
+
+func TestCheckFailsIfNotInitialized(t *testing.T) {
+	// Arrange
+	server := newServer(t, http.StatusNotImplemented,
+		`{"initialized":false,"sealed":true,"standby":true,"performance_standby":false,"version":"1.15.2"}`)
+
+	// Act
+	err := New(server.URL)(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "vault is not initialized")
+}
@@ -0,0 +1,61 @@
+// Package zookeeper provides a health check for Apache ZooKeeper (https://zookeeper.apache.org) that uses
+// the "ruok" four letter word command.
+package zookeeper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/alexliesenfeld/health/internal/netutil"
+)
+
+// New creates a new ZooKeeper health check function that sends the "ruok" command to each of the
+// provided servers (e.g., "localhost:2181") and verifies that they respond with "imok". The check
+// fails if any of the servers is not available. Connecting to a server and waiting for the response
+// adhere to the deadline of the context that is passed to the check function.
+// Since ZooKeeper 3.5, the command must be allowed using the "4lw.commands.whitelist" server setting.
+func New(servers ...string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		for _, server := range servers {
+			if err := ruok(ctx, server); err != nil {
+				if ctx.Err() != nil {
+					// Report the cause instead of the resulting I/O timeout.
+					err = ctx.Err()
+				}
+				return fmt.Errorf("zookeeper server %s is not available: %w", server, err)
+			}
+		}
+		return nil
+	}
+}
+
+func ruok(ctx context.Context, server string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", server)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Abort the request as soon as the context is done (this includes reaching its deadline).
+	defer netutil.InterruptOnDone(ctx, conn)()
+
+	if _, err := conn.Write([]byte("ruok")); err != nil {
+		return err
+	}
+
+	// The server closes the connection after it has sent the response.
+	response, err := io.ReadAll(io.LimitReader(conn, 1024))
+	if err != nil {
+		return err
+	}
+
+	if reply := strings.TrimSpace(string(response)); reply != "imok" {
+		return fmt.Errorf("unexpected response to ruok command: %q", reply)
+	}
+
+	return nil
+}
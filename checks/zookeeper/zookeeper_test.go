@@ -0,0 +1,75 @@
+package zookeeper
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer starts a server that reads a four letter word command and responds with 'response'
+// after the provided delay.
+func startServer(t *testing.T, response string, delay time.Duration) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				command := make([]byte, 4)
+				if _, err := conn.Read(command); err != nil || string(command) != "ruok" {
+					return
+				}
+				time.Sleep(delay)
+				_, _ = conn.Write([]byte(response))
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestCheck(t *testing.T) {
+	// Arrange
+	servers := []string{startServer(t, "imok", 0), startServer(t, "imok", 0)}
+
+	// Act
+	err := New(servers...)(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestCheckFailsIfCommandIsNotAllowed(t *testing.T) {
+	// Arrange
+	server := startServer(t, "ruok is not executed because it is not in the whitelist.\n", 0)
+
+	// Act
+	err := New(server)(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "zookeeper server "+server+" is not available: "+
+		"unexpected response to ruok command: \"ruok is not executed because it is not in the whitelist.\"")
+}
+
+func TestCheckAdheresToContextDeadline(t *testing.T) {
+	// Arrange
+	server := startServer(t, "imok", 1*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := New(server)(ctx)
+
+	// Assert
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,27 @@
+// Package compat provides adapters that convert health checks written for other Go health check libraries
+// (InVisionApp/go-health, heptiolabs/healthcheck and hellofresh/health-go) into their counterparts of this library.
+// This allows migrating existing health check code step by step without having to rewrite all check
+// implementations at once.
+package compat
+
+import (
+	"context"
+
+	"github.com/alexliesenfeld/health"
+)
+
+// nonCritical is an interceptor that keeps a failing check from affecting the aggregated system
+// availability status. The check error is still reported, but the component status remains "up".
+// This mirrors the behaviour of non-fatal checks in other libraries (e.g., InVisionApp/go-health
+// checks that are not marked as fatal or hellofresh/health-go checks that have SkipOnErr enabled).
+func nonCritical() health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			result := next(ctx, name, state)
+			if result.Status == health.StatusDown {
+				result.Status = health.StatusUp
+			}
+			return result
+		}
+	}
+}
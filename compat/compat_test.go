@@ -0,0 +1,104 @@
+package compat
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	invisionapp "github.com/InVisionApp/go-health"
+	"github.com/alexliesenfeld/health"
+	hellofresh "github.com/hellofresh/health-go/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type checkable struct {
+	err error
+}
+
+func (c checkable) Status() (interface{}, error) {
+	return nil, c.err
+}
+
+func TestInVisionAppNonFatalCheckFailure(t *testing.T) {
+	// Arrange
+	var states []invisionapp.State
+	checker := health.NewChecker(
+		InVisionApp(&invisionapp.Config{
+			Name:    "check",
+			Checker: checkable{err: fmt.Errorf("check error")},
+			OnComplete: func(state *invisionapp.State) {
+				states = append(states, *state)
+			},
+		}),
+	)
+
+	// Act
+	res := checker.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, res.Status)
+	require.NotNil(t, res.Details["check"].Error)
+	assert.Equal(t, "check error", res.Details["check"].Error.Error())
+	require.Len(t, states, 1)
+	assert.Equal(t, "check", states[0].Name)
+	assert.Equal(t, "failed", states[0].Status)
+	assert.Equal(t, "check error", states[0].Err)
+	assert.False(t, states[0].Fatal)
+	assert.Equal(t, int64(1), states[0].ContiguousFailures)
+}
+
+func TestInVisionAppFatalCheckFailure(t *testing.T) {
+	// Arrange
+	checker := health.NewChecker(
+		InVisionApp(&invisionapp.Config{
+			Name:    "check",
+			Checker: checkable{err: fmt.Errorf("check error")},
+			Fatal:   true,
+		}),
+	)
+
+	// Act
+	res := checker.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, health.StatusDown, res.Status)
+}
+
+func TestHelloFreshSkipOnErrDoesNotAffectAggregatedStatus(t *testing.T) {
+	// Arrange
+	checker := health.NewChecker(
+		health.WithCheck(HelloFresh(hellofresh.Config{
+			Name:      "check",
+			Timeout:   1 * time.Second,
+			SkipOnErr: true,
+			Check: func(ctx context.Context) error {
+				return fmt.Errorf("check error")
+			},
+		})),
+	)
+
+	// Act
+	res := checker.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, res.Status)
+	require.NotNil(t, res.Details["check"].Error)
+	assert.Equal(t, "check error", res.Details["check"].Error.Error())
+}
+
+func TestHeptiolabsCheckFailure(t *testing.T) {
+	// Arrange
+	checker := health.NewChecker(
+		health.WithCheck(Heptiolabs("check", func() error {
+			return fmt.Errorf("check error")
+		})),
+	)
+
+	// Act
+	res := checker.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, health.StatusDown, res.Status)
+}
@@ -0,0 +1,24 @@
+package compat
+
+import (
+	"github.com/alexliesenfeld/health"
+	hellofresh "github.com/hellofresh/health-go/v4"
+)
+
+// HelloFresh converts a hellofresh/health-go check configuration into a health.Check.
+// The configuration is translated as follows:
+//   - Name, Timeout and Check are used as they are. The check context is passed through to the check function.
+//   - If SkipOnErr is true, check failures are reported but do not affect the aggregated system status.
+func HelloFresh(cfg hellofresh.Config) health.Check {
+	check := health.Check{
+		Name:    cfg.Name,
+		Timeout: cfg.Timeout,
+		Check:   cfg.Check,
+	}
+
+	if cfg.SkipOnErr {
+		check.Interceptors = []health.Interceptor{nonCritical()}
+	}
+
+	return check
+}
@@ -0,0 +1,25 @@
+package compat
+
+import (
+	"context"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/heptiolabs/healthcheck"
+)
+
+// Heptiolabs converts a heptiolabs/healthcheck check function into a health.Check with the provided name.
+// The resulting health.Check can be further configured (e.g., by setting a timeout or interceptors) before
+// it is passed to health.WithCheck or health.WithPeriodicCheck.
+//
+// Attention: heptiolabs/healthcheck checks do not support contexts. The check function will be abandoned
+// once the context is done, but it will keep running in the background until it returns. Prefer configuring
+// timeouts on the check itself (e.g., healthcheck.HTTPGetCheck accepts a timeout) instead of using
+// healthcheck.Timeout. Also, do not wrap checks in healthcheck.Async, but use health.WithPeriodicCheck instead.
+func Heptiolabs(name string, check healthcheck.Check) health.Check {
+	return health.Check{
+		Name: name,
+		Check: func(_ context.Context) error {
+			return check()
+		},
+	}
+}
@@ -0,0 +1,69 @@
+package compat
+
+import (
+	"context"
+
+	invisionapp "github.com/InVisionApp/go-health"
+	"github.com/alexliesenfeld/health"
+)
+
+// InVisionApp converts an InVisionApp/go-health check configuration into a health.CheckerOption.
+// The configuration is translated as follows:
+//   - Name and Checker are used as the check name and check function.
+//   - If Interval is greater than 0, the check is added as a periodic check (see health.WithPeriodicCheck),
+//     otherwise it is executed synchronously (see health.WithCheck).
+//   - If Fatal is false, check failures are reported but do not affect the aggregated system status.
+//   - OnComplete is called after every check execution with a state that was converted from health.CheckState.
+//
+// Attention: InVisionApp/go-health checkers do not support contexts. The context deadline will not
+// propagate to the checker, so timeouts need to be configured on the checker itself
+// (e.g., by using the Timeout field in checkers.HTTPConfig). The additional data returned by
+// ICheckable.Status is discarded.
+func InVisionApp(cfg *invisionapp.Config) health.CheckerOption {
+	check := health.Check{
+		Name: cfg.Name,
+		Check: func(_ context.Context) error {
+			_, err := cfg.Checker.Status()
+			return err
+		},
+	}
+
+	if !cfg.Fatal {
+		check.Interceptors = append(check.Interceptors, nonCritical())
+	}
+
+	if cfg.OnComplete != nil {
+		check.Interceptors = append(check.Interceptors, onCompleteInterceptor(cfg.Fatal, cfg.OnComplete))
+	}
+
+	if cfg.Interval > 0 {
+		return health.WithPeriodicCheck(cfg.Interval, 0, check)
+	}
+
+	return health.WithCheck(check)
+}
+
+func onCompleteInterceptor(fatal bool, onComplete func(state *invisionapp.State)) health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			result := next(ctx, name, state)
+
+			converted := invisionapp.State{
+				Name:               name,
+				Status:             "ok",
+				Fatal:              fatal,
+				CheckTime:          result.LastCheckedAt,
+				ContiguousFailures: int64(result.ContiguousFails),
+			}
+
+			if result.Result != nil {
+				converted.Status = "failed"
+				converted.Err = result.Result.Error()
+			}
+
+			onComplete(&converted)
+
+			return result
+		}
+	}
+}
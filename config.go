@@ -40,6 +40,11 @@ type (
 		// panics will be automatically converted into errors instead.
 		DisablePanicRecovery bool
 
+		// Labels holds arbitrary key/value pairs that describe the check (such as the owning team or the type
+		// of the checked component). Labels are available in CheckResult.Labels and can be used by middleware
+		// and interceptors (e.g., to decide which check results are visible to a client).
+		Labels map[string]string // Optional
+
 		updateInterval time.Duration
 		initialDelay   time.Duration
 	}
@@ -58,10 +63,11 @@ type (
 // adding the WithDisabledAutostart configuration option.
 func NewChecker(options ...CheckerOption) Checker {
 	cfg := checkerConfig{
-		cacheTTL:     1 * time.Second,
-		timeout:      10 * time.Second,
-		checks:       map[string]*Check{},
-		interceptors: []Interceptor{},
+		cacheTTL:       1 * time.Second,
+		timeout:        10 * time.Second,
+		checks:         map[string]*Check{},
+		interceptors:   []Interceptor{},
+		noChecksStatus: StatusUp,
 	}
 
 	for _, opt := range options {
@@ -79,6 +85,16 @@ func WithDisabledDetails() CheckerOption {
 	}
 }
 
+// WithNoChecksStatus sets the AvailabilityStatus that the Checker reports if no checks have been configured.
+// This is useful if a Checker without any checks indicates a configuration error (e.g., a readiness Checker
+// where no checks were wired) that should be reported as StatusDown or StatusUnknown.
+// Default value is StatusUp.
+func WithNoChecksStatus(status AvailabilityStatus) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.noChecksStatus = status
+	}
+}
+
 // WithTimeout defines a timeout duration for all checks. You can override
 // this timeout by using the timeout value in the Check configuration.
 // Default value is 10 seconds.
@@ -132,6 +148,41 @@ func WithResultWriter(writer ResultWriter) HandlerOption {
 	}
 }
 
+// WithCacheControlMaxAge configures the handler to send a "Cache-Control: public, max-age" HTTP response header
+// that matches the remaining time until the Checker will produce a fresh result (see WithCacheDuration and
+// WithPeriodicCheck). This allows clients that poll the endpoint frequently (such as fleets of pollers behind
+// a shared caching proxy) to reuse the previous response instead of sending a new request. Authentication
+// middleware of package middleware adds a "Vary: Authorization, X-Api-Key" response header, so that shared
+// caches do not serve responses of authenticated clients to other clients. Use WithPrivateCacheControl to
+// prevent shared caches from storing responses at all. If the result is not cached (anymore), or the Checker
+// was not created with NewChecker, responses will not be cacheable.
+// By default, caching of health check responses is always disabled.
+func WithCacheControlMaxAge() HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.maxAgeEnabled = true
+	}
+}
+
+// WithPrivateCacheControl marks cacheable responses (see WithCacheControlMaxAge) as private
+// ("Cache-Control: private, max-age"), so that only the client itself but no shared cache (such as a proxy)
+// may store them. This is useful if responses depend on client credentials in ways that cannot be expressed
+// using the "Vary" header (e.g., when using a custom authentication middleware).
+func WithPrivateCacheControl() HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.maxAgePrivate = true
+	}
+}
+
+// WithSelectorHeader sets the name of an HTTP request header that will be used by a handler created with
+// NewMultiHandler to select the Checker that processes the request. If the header is not present in a request,
+// the last segment of the request URL path will be used instead. This option has no effect on handlers
+// created with NewHandler.
+func WithSelectorHeader(headerName string) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.selectorHeader = headerName
+	}
+}
+
 // WithDisabledAutostart disables automatic startup of a Checker instance.
 func WithDisabledAutostart() CheckerOption {
 	return func(cfg *checkerConfig) {
@@ -220,3 +220,14 @@ func TestWithChecks(t *testing.T) {
 	assert.Len(t, ckr.cfg.checks, 1)
 	assert.Contains(t, ckr.cfg.checks, check.Name)
 }
+
+func TestWithNoChecksStatusConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithNoChecksStatus(StatusDown)(&cfg)
+
+	// Assert
+	assert.Equal(t, StatusDown, cfg.noChecksStatus)
+}
@@ -0,0 +1,17 @@
+package health
+
+import (
+	"context"
+	"expvar"
+)
+
+// PublishExpvar publishes the current CheckerResult of the provided Checker as an expvar variable with
+// the provided name. This makes the health state available at /debug/vars for services that already expose
+// expvar variables (see package expvar). The checker is executed each time the variable is read, so the
+// result is subject to the caching configuration of the checker (see WithCacheDuration).
+// Attention: As with expvar.Publish, this function panics if a variable with the same name is already published.
+func PublishExpvar(checker Checker, name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return checker.Check(context.Background())
+	}))
+}
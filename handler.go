@@ -1,9 +1,16 @@
 package health
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type (
@@ -12,6 +19,30 @@ type (
 		statusCodeDown int
 		middleware     []Middleware
 		resultWriter   ResultWriter
+		selectorHeader string
+		maxAgeEnabled  bool
+		maxAgePrivate  bool
+	}
+
+	responseStateKey struct{}
+
+	// responseState holds the functions that are registered with OnResponse and the response
+	// overrides that are set with OverrideResponse for a request.
+	responseState struct {
+		listeners  []func(statusCode int)
+		statusCode int
+		header     http.Header
+	}
+
+	// statusRecorder is an http.ResponseWriter that records the status code of the response.
+	statusRecorder struct {
+		http.ResponseWriter
+		statusCode int
+	}
+
+	// cacheExpiryProvider is implemented by Checkers that can tell until when their current result is cached.
+	cacheExpiryProvider interface {
+		cacheExpiry() time.Time
 	}
 
 	// Middleware is factory function that allows creating new instances of
@@ -21,6 +52,9 @@ type (
 	// invoke of the Checker.Check function. Each interceptor must therefore
 	// invoke the 'next' interceptor. If the 'next' MiddlewareFunc is not called,
 	// Checker.Check will never be executed.
+	// A handler (see NewHandler) creates a new chain for each request it processes, so a
+	// Middleware must keep state that is shared between requests outside of the returned
+	// MiddlewareFunc (e.g., in variables of the function that creates the Middleware).
 	Middleware func(next MiddlewareFunc) MiddlewareFunc
 
 	// MiddlewareFunc is a middleware for a health Handler (see NewHandler).
@@ -44,6 +78,22 @@ type (
 	// JSONResultWriter writes a CheckerResult in JSON format into an
 	// http.ResponseWriter. This ResultWriter is set by default.
 	JSONResultWriter struct{}
+
+	// TextResultWriter writes a CheckerResult in a human-readable plain text format into an
+	// http.ResponseWriter. Each line contains the name of a component followed by its status
+	// (and error message, if any). The first line contains the aggregated status.
+	TextResultWriter struct {
+		translate Translator
+	}
+
+	// Translator translates a message that is identified by a key (such as "status.up") into another
+	// language. The provided default message is the English message that is used if no translation
+	// is required. A Translator must return the default message for keys it does not know.
+	// The following keys are used by this library:
+	//   - "status": The label of the aggregated status line.
+	//   - "status.up", "status.down", "status.unknown": The labels for all availability statuses.
+	//   - "error.timeout": The error message of checks that timed out (see CheckTimeoutErr).
+	Translator func(key, defaultMsg string) string
 )
 
 // Write implements ResultWriter.Write.
@@ -63,23 +113,191 @@ func NewJSONResultWriter() *JSONResultWriter {
 	return &JSONResultWriter{}
 }
 
+// Write implements ResultWriter.Write.
+func (rw *TextResultWriter) Write(result *CheckerResult, statusCode int, w http.ResponseWriter, r *http.Request) error {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s: %s\n", rw.message("status", "status"), rw.translateStatus(result.Status)))
+
+	names := make([]string, 0, len(result.Details))
+	for name := range result.Details {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		checkResult := result.Details[name]
+		sb.WriteString(fmt.Sprintf("%s: %s", name, rw.translateStatus(checkResult.Status)))
+		if checkResult.Error != nil {
+			sb.WriteString(fmt.Sprintf(" (%s)", rw.translateError(checkResult.Error)))
+		}
+		sb.WriteString("\n")
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_, err := w.Write([]byte(sb.String()))
+	return err
+}
+
+// message returns the translation of the message identified by 'key' or the default message if no
+// Translator is configured (e.g., for the zero value of TextResultWriter).
+func (rw *TextResultWriter) message(key, defaultMsg string) string {
+	if rw.translate == nil {
+		return defaultMsg
+	}
+	return rw.translate(key, defaultMsg)
+}
+
+func (rw *TextResultWriter) translateStatus(status AvailabilityStatus) string {
+	return rw.message("status."+string(status), string(status))
+}
+
+func (rw *TextResultWriter) translateError(err error) string {
+	if errors.Is(err, CheckTimeoutErr) {
+		return rw.message("error.timeout", err.Error())
+	}
+	return err.Error()
+}
+
+// NewTextResultWriter creates a new instance of a TextResultWriter. The provided Translator will be used
+// to localize status labels and common error messages. If translator is nil, all messages will be written in English.
+func NewTextResultWriter(translator Translator) *TextResultWriter {
+	return &TextResultWriter{translate: translator}
+}
+
 // NewHandler creates a new health check http.Handler.
 func NewHandler(checker Checker, options ...HandlerOption) http.HandlerFunc {
 	cfg := createConfig(options)
 	return func(w http.ResponseWriter, r *http.Request) {
+		state := &responseState{}
+		r = r.WithContext(context.WithValue(r.Context(), responseStateKey{}, state))
+		recorder := &statusRecorder{ResponseWriter: w}
+		w = recorder
+
 		// Do the check (with configured middleware)
 		result := withMiddleware(cfg.middleware, func(r *http.Request) CheckerResult {
 			return checker.Check(r.Context())
 		})(r)
+		defer state.notify(recorder)
 
 		// Write HTTP response
-		disableResponseCache(w)
+		if cfg.maxAgeEnabled {
+			setResponseCacheMaxAge(w, checker, cfg.maxAgePrivate)
+		} else {
+			disableResponseCache(w)
+		}
 		statusCode := mapHTTPStatusCode(result.Status, cfg.statusCodeUp, cfg.statusCodeDown)
+		if state.statusCode != 0 {
+			statusCode = state.statusCode
+		}
+		for key, values := range state.header {
+			w.Header()[key] = values
+		}
 		//nolint:errcheck
 		cfg.resultWriter.Write(&result, statusCode, w, r)
 	}
 }
 
+// NewMultiHandler creates a new http.Handler that serves the results of several Checkers on one endpoint.
+// This is useful if your service exposes different probe flavors (such as liveness and readiness checks),
+// but you do not want to register a separate handler for each of them.
+// The Checker that processes a request is selected by the last segment of the request URL path
+// (e.g., a request to "/health/ready" will be processed by the Checker that was registered with key "ready").
+// If a selector header is configured (see WithSelectorHeader) and present in the request, its value will be
+// used to select the Checker instead. If no Checker can be found for a request, the handler responds with
+// HTTP status code 404 (Not Found). All provided options are applied to all Checkers.
+func NewMultiHandler(checkers map[string]Checker, options ...HandlerOption) http.HandlerFunc {
+	cfg := createConfig(options)
+
+	handlers := make(map[string]http.Handler, len(checkers))
+	for name, checker := range checkers {
+		handlers[name] = NewHandler(checker, options...)
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := path.Base(r.URL.Path)
+		if cfg.selectorHeader != "" {
+			if value := r.Header.Get(cfg.selectorHeader); value != "" {
+				name = value
+			}
+		}
+
+		handler, ok := handlers[name]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	}
+}
+
+// OnResponse registers a function that is called with the HTTP status code of the response after the handler
+// (see NewHandler) has written the result of request 'r'. This allows middleware to take the actual response
+// into account (e.g., for logging or metrics), although the response is written after all middleware has
+// returned. Listeners are called in the order they were registered. OnResponse returns false (and never calls
+// the function) if the request is not processed by a handler that was created with NewHandler.
+func OnResponse(r *http.Request, listener func(statusCode int)) bool {
+	state, ok := r.Context().Value(responseStateKey{}).(*responseState)
+	if !ok {
+		return false
+	}
+	state.listeners = append(state.listeners, listener)
+	return true
+}
+
+// OverrideResponse instructs the handler (see NewHandler) that processes request 'r' to respond with the provided
+// HTTP status code instead of the status code that is mapped from the aggregated availability status (see
+// WithStatusCodeUp and WithStatusCodeDown) and to add the provided headers to the response. This allows
+// middleware to reject requests (e.g., with status code 429 and a "Retry-After" header). A status code of 0
+// keeps the mapped status code. OverrideResponse returns false (and has no effect) if the request is not
+// processed by a handler that was created with NewHandler.
+func OverrideResponse(r *http.Request, statusCode int, header http.Header) bool {
+	state, ok := r.Context().Value(responseStateKey{}).(*responseState)
+	if !ok {
+		return false
+	}
+	if statusCode != 0 {
+		state.statusCode = statusCode
+	}
+	for key, values := range header {
+		if state.header == nil {
+			state.header = http.Header{}
+		}
+		state.header[key] = values
+	}
+	return true
+}
+
+func (s *responseState) notify(recorder *statusRecorder) {
+	statusCode := recorder.statusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	for _, listener := range s.listeners {
+		listener(statusCode)
+	}
+}
+
+func (r *statusRecorder) WriteHeader(statusCode int) {
+	if r.statusCode == 0 {
+		r.statusCode = statusCode
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.statusCode == 0 {
+		r.statusCode = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the original http.ResponseWriter (see http.ResponseController).
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 func disableResponseCache(w http.ResponseWriter) {
 	// Avoid caching: https://www.ibm.com/garage/method/practices/manage/health-check-apis/
 	w.Header().Set("Cache-Control", "no-cache")
@@ -87,6 +305,26 @@ func disableResponseCache(w http.ResponseWriter) {
 	w.Header().Set("Expires", "Thu, 01 Jan 1970 00:00:00 GMT")
 }
 
+func setResponseCacheMaxAge(w http.ResponseWriter, checker Checker, private bool) {
+	provider, ok := checker.(cacheExpiryProvider)
+	if !ok {
+		disableResponseCache(w)
+		return
+	}
+
+	maxAge := int(time.Until(provider.cacheExpiry()).Seconds())
+	if maxAge <= 0 {
+		disableResponseCache(w)
+		return
+	}
+
+	visibility := "public"
+	if private {
+		visibility = "private"
+	}
+	w.Header().Set("Cache-Control", visibility+", max-age="+strconv.Itoa(maxAge))
+}
+
 func mapHTTPStatusCode(status AvailabilityStatus, statusCodeUp int, statusCodeDown int) int {
 	if status == StatusDown || status == StatusUnknown {
 		return statusCodeDown
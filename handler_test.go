@@ -121,3 +121,204 @@ func TestWhenChecksEmptyThenHandlerResultContainNoChecksMap(t *testing.T) {
 	}
 
 }
+
+func doTestMultiHandler(t *testing.T, target string, header string, expectedStatusCode int) {
+	// Arrange
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	if header != "" {
+		r.Header.Set("X-Health-Probe", header)
+	}
+	w := httptest.NewRecorder()
+
+	handler := NewMultiHandler(map[string]Checker{
+		"live": NewChecker(),
+		"ready": NewChecker(WithCheck(Check{
+			Name: "check",
+			Check: func(ctx context.Context) error {
+				return fmt.Errorf("not ready")
+			},
+		})),
+	}, WithSelectorHeader("X-Health-Probe"))
+
+	// Act
+	handler.ServeHTTP(w, r)
+
+	// Assert
+	assert.Equal(t, expectedStatusCode, w.Result().StatusCode)
+}
+
+func TestMultiHandlerSelectsCheckerByPath(t *testing.T) {
+	doTestMultiHandler(t, "/health/live", "", http.StatusOK)
+	doTestMultiHandler(t, "/health/ready/", "", http.StatusServiceUnavailable)
+}
+
+func TestMultiHandlerSelectsCheckerByHeader(t *testing.T) {
+	doTestMultiHandler(t, "/health", "ready", http.StatusServiceUnavailable)
+}
+
+func TestMultiHandlerRespondsNotFoundForUnknownChecker(t *testing.T) {
+	doTestMultiHandler(t, "/health/startup", "", http.StatusNotFound)
+}
+
+func TestTextResultWriterTranslatesMessages(t *testing.T) {
+	// Arrange
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/health", nil)
+	result := CheckerResult{
+		Status: StatusDown,
+		Details: map[string]CheckResult{
+			"database": {Status: StatusUp},
+			"search":   {Status: StatusDown, Error: CheckTimeoutErr},
+		},
+	}
+	translations := map[string]string{
+		"status":        "Status",
+		"status.up":     "verfügbar",
+		"status.down":   "nicht verfügbar",
+		"error.timeout": "Zeitüberschreitung",
+	}
+	writer := NewTextResultWriter(func(key, defaultMsg string) string {
+		if msg, ok := translations[key]; ok {
+			return msg
+		}
+		return defaultMsg
+	})
+
+	// Act
+	err := writer.Write(&result, http.StatusServiceUnavailable, w, r)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "Status: nicht verfügbar\ndatabase: verfügbar\nsearch: nicht verfügbar (Zeitüberschreitung)\n", w.Body.String())
+}
+
+func TestTextResultWriterZeroValue(t *testing.T) {
+	// Arrange
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/health", nil)
+	result := CheckerResult{
+		Status:  StatusDown,
+		Details: map[string]CheckResult{"search": {Status: StatusDown, Error: CheckTimeoutErr}},
+	}
+
+	// Act
+	err := (&TextResultWriter{}).Write(&result, http.StatusServiceUnavailable, w, r)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "status: down\nsearch: down ("+CheckTimeoutErr.Error()+")\n", w.Body.String())
+}
+
+func TestHandlerWithCacheControlMaxAge(t *testing.T) {
+	// Arrange
+	r := httptest.NewRequest(http.MethodGet, "/health", nil)
+	w := httptest.NewRecorder()
+	checker := NewChecker(
+		WithCacheDuration(1*time.Minute),
+		WithCheck(Check{
+			Name: "check",
+			Check: func(ctx context.Context) error {
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	NewHandler(checker, WithCacheControlMaxAge()).ServeHTTP(w, r)
+
+	// Assert
+	assert.Contains(t, []string{"public, max-age=59", "public, max-age=60"}, w.Header().Get("Cache-Control"))
+}
+
+func TestHandlerWithPrivateCacheControl(t *testing.T) {
+	// Arrange
+	r := httptest.NewRequest(http.MethodGet, "/health", nil)
+	w := httptest.NewRecorder()
+	checker := NewChecker(
+		WithCacheDuration(1*time.Minute),
+		WithCheck(Check{
+			Name: "check",
+			Check: func(ctx context.Context) error {
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	NewHandler(checker, WithCacheControlMaxAge(), WithPrivateCacheControl()).ServeHTTP(w, r)
+
+	// Assert
+	assert.Contains(t, []string{"private, max-age=59", "private, max-age=60"}, w.Header().Get("Cache-Control"))
+}
+
+func TestHandlerWithCacheControlMaxAgeAndDisabledCache(t *testing.T) {
+	// Arrange
+	r := httptest.NewRequest(http.MethodGet, "/health", nil)
+	w := httptest.NewRecorder()
+	checker := NewChecker(
+		WithDisabledCache(),
+		WithCheck(Check{
+			Name: "check",
+			Check: func(ctx context.Context) error {
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	NewHandler(checker, WithCacheControlMaxAge()).ServeHTTP(w, r)
+
+	// Assert
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+}
+
+func TestOnResponseReportsStatusCode(t *testing.T) {
+	// Arrange
+	var statusCodes []int
+	middleware := func(next MiddlewareFunc) MiddlewareFunc {
+		return func(r *http.Request) CheckerResult {
+			assert.True(t, OnResponse(r, func(statusCode int) {
+				statusCodes = append(statusCodes, statusCode)
+			}))
+			result := next(r)
+			assert.Empty(t, statusCodes, "the response must not have been written yet")
+			return result
+		}
+	}
+	checker := NewChecker(WithDisabledAutostart(), WithCheck(Check{
+		Name: "check",
+		Check: func(ctx context.Context) error {
+			return fmt.Errorf("failed")
+		},
+	}))
+
+	// Act
+	NewHandler(checker, WithMiddleware(middleware), WithStatusCodeDown(http.StatusTooManyRequests)).
+		ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, []int{http.StatusTooManyRequests}, statusCodes)
+	assert.False(t, OnResponse(httptest.NewRequest(http.MethodGet, "/health", nil), func(int) {}))
+}
+
+func TestOverrideResponse(t *testing.T) {
+	// Arrange
+	middleware := func(next MiddlewareFunc) MiddlewareFunc {
+		return func(r *http.Request) CheckerResult {
+			assert.True(t, OverrideResponse(r, http.StatusTooManyRequests, http.Header{"Retry-After": {"3"}}))
+			return next(r)
+		}
+	}
+	w := httptest.NewRecorder()
+
+	// Act
+	NewHandler(NewChecker(WithDisabledAutostart()), WithMiddleware(middleware)).
+		ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "3", w.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"status":"up"}`, w.Body.String())
+	assert.False(t, OverrideResponse(httptest.NewRequest(http.MethodGet, "/health", nil), http.StatusTooManyRequests, nil))
+}
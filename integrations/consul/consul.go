@@ -0,0 +1,107 @@
+// Package consul provides an integration to register services with a health check in
+// Consul (https://www.consul.io).
+package consul
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/hashicorp/consul/api"
+)
+
+type (
+	// Option is a configuration option for Register.
+	Option func(cfg *config)
+
+	config struct {
+		interval                       time.Duration
+		timeout                        time.Duration
+		deregisterCriticalServiceAfter time.Duration
+	}
+
+	registeredChecker struct {
+		health.Checker
+		agent     *api.Agent
+		serviceID string
+		once      sync.Once
+	}
+)
+
+// WithCheckInterval sets the interval in which Consul calls the health check endpoint. Default is 10 seconds.
+func WithCheckInterval(interval time.Duration) Option {
+	return func(cfg *config) {
+		cfg.interval = interval
+	}
+}
+
+// WithCheckTimeout sets the timeout for calls of Consul to the health check endpoint. Default is 5 seconds.
+func WithCheckTimeout(timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithDeregisterCriticalServiceAfter configures Consul to deregister the service automatically if its health check
+// has been failing for longer than the provided duration (e.g., because the process crashed before it could
+// deregister itself). By default, services are not deregistered automatically.
+func WithDeregisterCriticalServiceAfter(duration time.Duration) Option {
+	return func(cfg *config) {
+		cfg.deregisterCriticalServiceAfter = duration
+	}
+}
+
+// Register registers the provided service at the local Consul agent along with an HTTP check that calls the
+// health check endpoint at 'checkURL' (i.e., the URL of the handler that was created using health.NewHandler).
+// Consul considers the service healthy as long as the endpoint responds with a 2xx status code.
+// Register returns a health.Checker that wraps the provided checker and deregisters the service from Consul when
+// health.Checker.Stop is called. Errors during deregistration are logged using the standard logger (see package log).
+func Register(
+	checker health.Checker,
+	client *api.Client,
+	service api.AgentServiceRegistration,
+	checkURL string,
+	options ...Option,
+) (health.Checker, error) {
+	cfg := config{interval: 10 * time.Second, timeout: 5 * time.Second}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	if service.ID == "" {
+		service.ID = service.Name
+	}
+
+	check := api.AgentServiceCheck{
+		Name:     "health",
+		HTTP:     checkURL,
+		Method:   "GET",
+		Interval: cfg.interval.String(),
+		Timeout:  cfg.timeout.String(),
+	}
+	if cfg.deregisterCriticalServiceAfter > 0 {
+		check.DeregisterCriticalServiceAfter = cfg.deregisterCriticalServiceAfter.String()
+	}
+	// The checks are copied, so that the backing array of the caller's slice is not modified.
+	checks := make(api.AgentServiceChecks, 0, len(service.Checks)+1)
+	service.Checks = append(append(checks, service.Checks...), &check)
+
+	agent := client.Agent()
+	if err := agent.ServiceRegister(&service); err != nil {
+		return nil, fmt.Errorf("cannot register service %q in consul: %w", service.ID, err)
+	}
+
+	return &registeredChecker{Checker: checker, agent: agent, serviceID: service.ID}, nil
+}
+
+// Stop stops the wrapped checker and deregisters the service from Consul.
+func (ck *registeredChecker) Stop() {
+	ck.once.Do(func() {
+		if err := ck.agent.ServiceDeregister(ck.serviceID); err != nil {
+			log.Printf("cannot deregister service %q from consul: %v", ck.serviceID, err)
+		}
+	})
+	ck.Checker.Stop()
+}
@@ -0,0 +1,86 @@
+package consul
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterAndDeregisterOnStop(t *testing.T) {
+	// Arrange
+	var (
+		mtx          sync.Mutex
+		registration api.AgentServiceRegistration
+		deregistered string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		switch {
+		case r.URL.Path == "/v1/agent/service/register":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&registration))
+		case r.URL.Path == "/v1/agent/service/deregister/orders-1":
+			deregistered = "orders-1"
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := api.NewClient(&api.Config{Address: server.URL})
+	require.NoError(t, err)
+
+	// Act
+	checker, err := Register(
+		health.NewChecker(health.WithDisabledAutostart()),
+		client,
+		api.AgentServiceRegistration{ID: "orders-1", Name: "orders", Port: 8080},
+		"http://localhost:8080/health",
+		WithCheckInterval(5*time.Second),
+	)
+	require.NoError(t, err)
+	checker.Start()
+	checker.Stop()
+
+	// Assert
+	mtx.Lock()
+	defer mtx.Unlock()
+	assert.Equal(t, "orders", registration.Name)
+	require.Len(t, registration.Checks, 1)
+	assert.Equal(t, "http://localhost:8080/health", registration.Checks[0].HTTP)
+	assert.Equal(t, "5s", registration.Checks[0].Interval)
+	assert.Equal(t, "orders-1", deregistered)
+}
+
+func TestRegisterDoesNotModifyChecksOfCaller(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client, err := api.NewClient(&api.Config{Address: server.URL})
+	require.NoError(t, err)
+
+	callerCheck := api.AgentServiceCheck{Name: "caller"}
+	checks := make(api.AgentServiceChecks, 1, 2)
+	checks[0] = &callerCheck
+
+	// Act
+	_, err = Register(
+		health.NewChecker(health.WithDisabledAutostart()),
+		client,
+		api.AgentServiceRegistration{ID: "orders-1", Name: "orders", Checks: checks},
+		"http://localhost:8080/health",
+	)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, checks[:2][1])
+}
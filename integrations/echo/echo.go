@@ -0,0 +1,38 @@
+// Package echo provides helpers to serve health checks with the Echo web framework (https://echo.labstack.com).
+package echo
+
+import (
+	"net/http"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/labstack/echo/v4"
+)
+
+// NewHandler creates a new echo.HandlerFunc that processes health check requests. It behaves exactly like
+// the http.Handler created by health.NewHandler, so all handler options (such as health.WithMiddleware or
+// health.WithResultWriter) are supported.
+func NewHandler(checker health.Checker, options ...health.HandlerOption) echo.HandlerFunc {
+	return echo.WrapHandler(health.NewHandler(checker, options...))
+}
+
+// Register registers a health check handler (see NewHandler) for HTTP GET requests on the provided path.
+func Register(e *echo.Echo, path string, checker health.Checker, options ...health.HandlerOption) *echo.Route {
+	return e.GET(path, NewHandler(checker, options...))
+}
+
+// Middleware creates an echo.MiddlewareFunc that responds to all HTTP GET and HEAD requests to the provided path
+// with the health check result (see NewHandler). All other requests are forwarded to the next handler.
+// This is useful if health check requests should bypass other middleware (such as authentication or
+// request logging), which can be achieved by registering this middleware with echo.Echo.Pre.
+func Middleware(path string, checker health.Checker, options ...health.HandlerOption) echo.MiddlewareFunc {
+	handler := NewHandler(checker, options...)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r := c.Request()
+			if r.URL.Path == path && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+				return handler(c)
+			}
+			return next(c)
+		}
+	}
+}
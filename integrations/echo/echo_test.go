@@ -0,0 +1,44 @@
+package echo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	// Arrange
+	e := echo.New()
+	Register(e, "/health", health.NewChecker())
+	w := httptest.NewRecorder()
+
+	// Act
+	e.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "{\"status\":\"up\"}", w.Body.String())
+}
+
+func TestMiddlewareForwardsOtherRequests(t *testing.T) {
+	// Arrange
+	e := echo.New()
+	e.Pre(Middleware("/health", health.NewChecker(health.WithNoChecksStatus(health.StatusDown))))
+	e.GET("/hello", func(c echo.Context) error {
+		return c.String(http.StatusOK, "hello")
+	})
+
+	// Act
+	healthResponse := httptest.NewRecorder()
+	e.ServeHTTP(healthResponse, httptest.NewRequest(http.MethodGet, "/health", nil))
+	helloResponse := httptest.NewRecorder()
+	e.ServeHTTP(helloResponse, httptest.NewRequest(http.MethodGet, "/hello", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, healthResponse.Code)
+	assert.Equal(t, "hello", helloResponse.Body.String())
+}
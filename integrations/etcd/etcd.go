@@ -0,0 +1,175 @@
+// Package etcd provides an integration to publish the health status of a service to etcd (https://etcd.io).
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/alexliesenfeld/health/internal/publish"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+type (
+	// Client is the subset of the etcd client API that is used by the Publisher.
+	// It is implemented by *clientv3.Client.
+	Client interface {
+		clientv3.KV
+		clientv3.Lease
+	}
+
+	// Publisher writes the aggregated health status of a service into an etcd key whenever the status changes.
+	// The key is attached to a lease that is kept alive as long as the Publisher is running, so that the key is
+	// removed automatically by etcd if the process terminates unexpectedly.
+	Publisher struct {
+		client  Client
+		key     string
+		ttl     time.Duration
+		timeout time.Duration
+		mtx     sync.Mutex
+		leaseID clientv3.LeaseID
+		cancel  context.CancelFunc
+		queue   *publish.Queue[health.CheckerState]
+	}
+
+	// Option is a configuration option for a Publisher.
+	Option func(p *Publisher)
+
+	// Status is the value that is written into the etcd key (encoded as JSON).
+	Status = publish.Status
+)
+
+// WithTTL sets the TTL of the lease that the key is attached to. If the process terminates without closing the
+// Publisher, etcd removes the key after the TTL has expired. Default is 10 seconds.
+func WithTTL(ttl time.Duration) Option {
+	return func(p *Publisher) {
+		p.ttl = ttl
+	}
+}
+
+// WithTimeout sets the timeout for requests to etcd. Default is 5 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(p *Publisher) {
+		p.timeout = timeout
+	}
+}
+
+// NewPublisher creates a new Publisher that writes the health status into the provided etcd key.
+func NewPublisher(client Client, key string, options ...Option) *Publisher {
+	p := Publisher{client: client, key: key, ttl: 10 * time.Second, timeout: 5 * time.Second}
+	for _, opt := range options {
+		opt(&p)
+	}
+	p.queue = publish.NewQueue(p.publishQueued)
+	return &p
+}
+
+// StatusListener returns a status listener that publishes the aggregated health status whenever it changes.
+// Use it with health.WithStatusListener. The listener does not wait for etcd: a background goroutine writes
+// the states in the order in which they were reported, skipping states that have already been superseded
+// by a newer one when it gets to them. Errors are logged using the standard logger (see package log).
+func (p *Publisher) StatusListener() func(ctx context.Context, state health.CheckerState) {
+	return func(_ context.Context, state health.CheckerState) {
+		p.queue.Push(state)
+	}
+}
+
+// publishQueued is called by the queue of the listener.
+func (p *Publisher) publishQueued(state health.CheckerState) {
+	if err := p.Publish(context.Background(), state); err != nil {
+		log.Printf("cannot publish health status to etcd: %v", err)
+	}
+}
+
+// Publish writes the provided state into the etcd key. The lease is created on the first call.
+func (p *Publisher) Publish(ctx context.Context, state health.CheckerState) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	value, err := json.Marshal(publish.NewStatus(state))
+	if err != nil {
+		return err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.cancel == nil {
+		if err := p.grantLease(ctx); err != nil {
+			return err
+		}
+	}
+
+	if _, err := p.client.Put(ctx, p.key, string(value), clientv3.WithLease(p.leaseID)); err != nil {
+		return fmt.Errorf("cannot write key %q: %w", p.key, err)
+	}
+
+	return nil
+}
+
+// Close stops keeping the lease alive and revokes it, which removes the key from etcd. States that are still
+// being published by the status listener are awaited first, so that they cannot recreate the key.
+func (p *Publisher) Close(ctx context.Context) error {
+	if err := p.queue.Wait(ctx); err != nil {
+		return err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.cancel == nil {
+		return nil
+	}
+
+	p.cancel()
+	p.cancel = nil
+
+	if _, err := p.client.Revoke(ctx, p.leaseID); err != nil {
+		return fmt.Errorf("cannot revoke lease: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Publisher) grantLease(ctx context.Context) error {
+	lease, err := p.client.Grant(ctx, int64(p.ttl.Seconds()))
+	if err != nil {
+		return fmt.Errorf("cannot grant lease: %w", err)
+	}
+
+	keepAliveCtx, cancel := context.WithCancel(context.Background())
+	responses, err := p.client.KeepAlive(keepAliveCtx, lease.ID)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("cannot keep lease alive: %w", err)
+	}
+
+	// The keep alive responses must be consumed, otherwise the client logs warnings about a full channel.
+	// The channel is closed if the lease expires or cannot be kept alive anymore (e.g., after etcd was
+	// unavailable for longer than the TTL). In that case, the lease is forgotten, so that the next call
+	// of Publish grants a new one instead of writing the key with a lease that does not exist anymore.
+	go func() {
+		for range responses {
+		}
+
+		p.mtx.Lock()
+		defer p.mtx.Unlock()
+
+		if p.cancel != nil && p.leaseID == lease.ID {
+			log.Printf("etcd lease %x of key %q is not kept alive anymore, a new lease is granted on the next publish",
+				lease.ID, p.key)
+			p.cancel()
+			p.cancel = nil
+			p.leaseID = clientv3.NoLease
+		}
+	}()
+
+	p.leaseID = lease.ID
+	p.cancel = cancel
+
+	return nil
+}
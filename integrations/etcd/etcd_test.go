@@ -0,0 +1,128 @@
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+type clientMock struct {
+	clientv3.KV
+	clientv3.Lease
+	values  map[string]string
+	puts    []string
+	revoked []clientv3.LeaseID
+	started chan struct{}
+	release chan struct{}
+	grants  int
+	expire  chan struct{}
+}
+
+func (c *clientMock) Grant(ctx context.Context, ttl int64) (*clientv3.LeaseGrantResponse, error) {
+	c.grants++
+	return &clientv3.LeaseGrantResponse{ID: clientv3.LeaseID(41 + c.grants), TTL: ttl}, nil
+}
+
+func (c *clientMock) KeepAlive(ctx context.Context, id clientv3.LeaseID) (<-chan *clientv3.LeaseKeepAliveResponse, error) {
+	ch := make(chan *clientv3.LeaseKeepAliveResponse)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-c.expire:
+		}
+		close(ch)
+	}()
+	return ch, nil
+}
+
+func (c *clientMock) Revoke(ctx context.Context, id clientv3.LeaseID) (*clientv3.LeaseRevokeResponse, error) {
+	c.revoked = append(c.revoked, id)
+	return &clientv3.LeaseRevokeResponse{}, nil
+}
+
+func (c *clientMock) Put(ctx context.Context, key, val string, opts ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	if c.release != nil {
+		c.started <- struct{}{}
+		<-c.release
+	}
+	c.values[key] = val
+	c.puts = append(c.puts, val)
+	return &clientv3.PutResponse{}, nil
+}
+
+func TestPublisherWritesStatusWithLease(t *testing.T) {
+	// Arrange
+	client := clientMock{values: map[string]string{}}
+	publisher := NewPublisher(&client, "/services/orders/instance-1")
+
+	// Act
+	publisher.StatusListener()(context.Background(), health.CheckerState{
+		Status:     health.StatusDown,
+		CheckState: map[string]health.CheckState{"database": {Status: health.StatusDown}},
+	})
+	require.NoError(t, publisher.Close(context.Background()))
+
+	// Assert
+	var status Status
+	require.NoError(t, json.Unmarshal([]byte(client.values["/services/orders/instance-1"]), &status))
+	assert.Equal(t, health.StatusDown, status.Status)
+	assert.Equal(t, map[string]health.AvailabilityStatus{"database": health.StatusDown}, status.Components)
+	assert.Equal(t, []clientv3.LeaseID{42}, client.revoked)
+}
+
+func TestStatusListenerDoesNotWaitForEtcd(t *testing.T) {
+	// Arrange
+	client := clientMock{
+		values:  map[string]string{},
+		started: make(chan struct{}, 10),
+		release: make(chan struct{}),
+	}
+	publisher := NewPublisher(&client, "/services/orders/instance-1")
+	listener := publisher.StatusListener()
+
+	// Act
+	listener(context.Background(), health.CheckerState{Status: health.StatusUp})
+	<-client.started
+	listener(context.Background(), health.CheckerState{Status: health.StatusDown})
+	listener(context.Background(), health.CheckerState{Status: health.StatusUnknown})
+	close(client.release)
+	require.NoError(t, publisher.Close(context.Background()))
+
+	// Assert
+	var statuses []health.AvailabilityStatus
+	for _, put := range client.puts {
+		var status Status
+		require.NoError(t, json.Unmarshal([]byte(put), &status))
+		statuses = append(statuses, status.Status)
+	}
+	assert.Equal(t, []health.AvailabilityStatus{health.StatusUp, health.StatusUnknown}, statuses)
+}
+
+func TestPublisherGrantsNewLeaseIfKeepAliveEnds(t *testing.T) {
+	// Arrange
+	client := clientMock{values: map[string]string{}, expire: make(chan struct{})}
+	publisher := NewPublisher(&client, "/services/orders/instance-1")
+	require.NoError(t, publisher.Publish(context.Background(), health.CheckerState{Status: health.StatusUp}))
+
+	// Act
+	close(client.expire)
+	require.Eventually(t, func() bool {
+		publisher.mtx.Lock()
+		defer publisher.mtx.Unlock()
+		return publisher.cancel == nil
+	}, 1*time.Second, 10*time.Millisecond)
+	client.expire = nil // Keep the second lease alive.
+	err := publisher.Publish(context.Background(), health.CheckerState{Status: health.StatusDown})
+	require.NoError(t, publisher.Close(context.Background()))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, client.grants)
+	assert.Equal(t, []clientv3.LeaseID{43}, client.revoked)
+}
@@ -0,0 +1,161 @@
+// Package eureka provides an integration to propagate the health status of a service to a
+// Eureka service registry (https://github.com/Netflix/eureka), as it is used in Spring Cloud environments.
+package eureka
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/alexliesenfeld/health/internal/publish"
+)
+
+type (
+	// Status is an instance status as it is known by Eureka.
+	Status string
+
+	// StatusUpdater updates the status of a registered instance in Eureka whenever the aggregated
+	// health status of the service changes. The instance must already be registered in Eureka
+	// (e.g., by a sidecar or by the Eureka client of the service itself).
+	StatusUpdater struct {
+		serverURL  string
+		app        string
+		instanceID string
+		client     *http.Client
+		timeout    time.Duration
+		mapping    map[health.AvailabilityStatus]Status
+		queue      *publish.Queue[Status]
+	}
+
+	// Option is a configuration option for a StatusUpdater.
+	Option func(u *StatusUpdater)
+)
+
+// Instance status values that are supported by Eureka.
+const (
+	StatusUp           Status = "UP"
+	StatusDown         Status = "DOWN"
+	StatusStarting     Status = "STARTING"
+	StatusOutOfService Status = "OUT_OF_SERVICE"
+	StatusUnknown      Status = "UNKNOWN"
+)
+
+// WithHTTPClient sets the HTTP client that is used to send requests to the Eureka server.
+// Default is http.DefaultClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(u *StatusUpdater) {
+		u.client = client
+	}
+}
+
+// WithTimeout sets the timeout for requests to the Eureka server. Default is 10 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(u *StatusUpdater) {
+		u.timeout = timeout
+	}
+}
+
+// WithStatusMapping overrides the Eureka status that is reported for an aggregated availability status.
+// By default, health.StatusUp is reported as StatusUp, health.StatusDown as StatusDown and
+// health.StatusUnknown as StatusStarting. Use this option to, for example, report health.StatusDown
+// as StatusOutOfService, so that Eureka clients stop routing traffic to the instance.
+func WithStatusMapping(status health.AvailabilityStatus, eurekaStatus Status) Option {
+	return func(u *StatusUpdater) {
+		u.mapping[status] = eurekaStatus
+	}
+}
+
+// NewStatusUpdater creates a new StatusUpdater for the instance 'instanceID' of application 'app'.
+// The 'serverURL' is the base URL of the Eureka REST API (e.g., http://localhost:8761/eureka).
+func NewStatusUpdater(serverURL, app, instanceID string, options ...Option) *StatusUpdater {
+	u := StatusUpdater{
+		serverURL:  strings.TrimSuffix(serverURL, "/"),
+		app:        app,
+		instanceID: instanceID,
+		client:     http.DefaultClient,
+		timeout:    10 * time.Second,
+		mapping: map[health.AvailabilityStatus]Status{
+			health.StatusUp:      StatusUp,
+			health.StatusDown:    StatusDown,
+			health.StatusUnknown: StatusStarting,
+		},
+	}
+	for _, opt := range options {
+		opt(&u)
+	}
+	u.queue = publish.NewQueue(u.sendStatus)
+	return &u
+}
+
+// StatusListener returns a status listener that updates the instance status in Eureka whenever the aggregated
+// health status changes. Use it with health.WithStatusListener. The listener returns immediately: updates are
+// sent one after another by a background goroutine, and if the status changes again while an update is in
+// progress, only the latest status is sent afterwards. Errors are logged using the standard logger
+// (see package log).
+func (u *StatusUpdater) StatusListener() func(ctx context.Context, state health.CheckerState) {
+	return func(_ context.Context, state health.CheckerState) {
+		status, ok := u.mapping[state.Status]
+		if !ok {
+			status = StatusUnknown
+		}
+
+		u.queue.Push(status)
+	}
+}
+
+// sendStatus is called by the queue of the listener.
+func (u *StatusUpdater) sendStatus(status Status) {
+	// The context of the listener ends with the health check, so the update uses its own context.
+	if err := u.SetStatus(context.Background(), status); err != nil {
+		log.Printf("cannot update instance status in eureka: %v", err)
+	}
+}
+
+// SetStatus sets the status of the instance in Eureka. Eureka keeps this status as an override until it
+// is changed again or removed (see ClearStatus).
+func (u *StatusUpdater) SetStatus(ctx context.Context, status Status) error {
+	return u.do(ctx, http.MethodPut, status)
+}
+
+// ClearStatus removes the status override from the instance in Eureka, so that Eureka uses the status
+// that is sent with the heartbeats of the instance again. Call it when the service shuts down and the
+// instance is deregistered, to not leave a stale override in Eureka. Status updates of the listener
+// (see StatusListener) that are still in progress are awaited, so that they cannot override the result.
+func (u *StatusUpdater) ClearStatus(ctx context.Context) error {
+	if err := u.queue.Wait(ctx); err != nil {
+		return err
+	}
+
+	return u.do(ctx, http.MethodDelete, StatusUp)
+}
+
+func (u *StatusUpdater) do(ctx context.Context, method string, status Status) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	endpoint := fmt.Sprintf("%s/apps/%s/%s/status?value=%s",
+		u.serverURL, url.PathEscape(u.app), url.PathEscape(u.instanceID), url.QueryEscape(string(status)))
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := u.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot update status of instance %q: %w", u.instanceID, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("cannot update status of instance %q: eureka responded with status code %d",
+			u.instanceID, res.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package eureka
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusListenerUpdatesInstanceStatus(t *testing.T) {
+	// Arrange
+	var (
+		mtx      sync.Mutex
+		requests []string
+	)
+	received := make(chan struct{}, 10)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		mtx.Unlock()
+		received <- struct{}{}
+		<-release
+	}))
+	defer server.Close()
+
+	updater := NewStatusUpdater(server.URL+"/eureka/", "ORDERS", "host:orders:8080",
+		WithStatusMapping(health.StatusDown, StatusOutOfService))
+	listener := updater.StatusListener()
+
+	// Act
+	listener(context.Background(), health.CheckerState{Status: health.StatusUnknown})
+	<-received // The listener must not wait for the blocked request.
+	listener(context.Background(), health.CheckerState{Status: health.StatusUp})
+	listener(context.Background(), health.CheckerState{Status: health.StatusDown})
+	close(release)
+	err := updater.ClearStatus(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"PUT /eureka/apps/ORDERS/host:orders:8080/status?value=STARTING",
+		"PUT /eureka/apps/ORDERS/host:orders:8080/status?value=OUT_OF_SERVICE",
+		"DELETE /eureka/apps/ORDERS/host:orders:8080/status?value=UP",
+	}, requests)
+}
+
+func TestSetStatusReturnsErrorOnUnexpectedStatusCode(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	// Act
+	err := NewStatusUpdater(server.URL, "ORDERS", "orders-1").SetStatus(context.Background(), StatusDown)
+
+	// Assert
+	assert.EqualError(t, err, "cannot update status of instance \"orders-1\": eureka responded with status code 404")
+}
@@ -0,0 +1,16 @@
+module github.com/alexliesenfeld/health/integrations/eureka
+
+go 1.18
+
+require (
+	github.com/alexliesenfeld/health v0.0.0
+	github.com/stretchr/testify v1.8.4
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/alexliesenfeld/health => ../..
@@ -0,0 +1,90 @@
+// Package kafka provides an integration to publish health status changes to Apache Kafka (https://kafka.apache.org).
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/alexliesenfeld/health/notify"
+	"github.com/segmentio/kafka-go"
+)
+
+type (
+	// MessageWriter writes messages to Kafka. It is implemented by *kafka.Writer.
+	MessageWriter interface {
+		WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	}
+
+	// Serializer converts an event into the value of a Kafka message.
+	Serializer func(event notify.Event) ([]byte, error)
+
+	// Publisher is a notify.Notifier that publishes status changes as messages to a Kafka topic. Use it with
+	// notify.SystemTransitions and notify.ComponentTransitions.
+	Publisher struct {
+		writer     MessageWriter
+		topic      string
+		key        string
+		serializer Serializer
+	}
+
+	// Option is a configuration option for a Publisher.
+	Option func(p *Publisher)
+)
+
+// WithTopic sets the topic that messages are published to. It must be left empty if the topic is configured in the
+// kafka.Writer (see kafka.Writer.Topic).
+func WithTopic(topic string) Option {
+	return func(p *Publisher) {
+		p.topic = topic
+	}
+}
+
+// WithKey sets the key of all published messages (e.g., the service or instance name), so that all events
+// of a service are published to the same partition and can be consumed in order.
+func WithKey(key string) Option {
+	return func(p *Publisher) {
+		p.key = key
+	}
+}
+
+// WithSerializer sets the Serializer that converts events into message values. Default is JSONSerializer.
+func WithSerializer(serializer Serializer) Option {
+	return func(p *Publisher) {
+		p.serializer = serializer
+	}
+}
+
+// JSONSerializer serializes events as JSON (see notify.Event.MarshalJSON).
+func JSONSerializer(event notify.Event) ([]byte, error) {
+	return json.Marshal(event)
+}
+
+// NewPublisher creates a new Publisher that writes messages using the provided MessageWriter
+// (usually a *kafka.Writer).
+func NewPublisher(writer MessageWriter, options ...Option) *Publisher {
+	p := Publisher{writer: writer, serializer: JSONSerializer}
+	for _, opt := range options {
+		opt(&p)
+	}
+	return &p
+}
+
+// Notify implements notify.Notifier.
+func (p *Publisher) Notify(ctx context.Context, event notify.Event) error {
+	value, err := p.serializer(event)
+	if err != nil {
+		return fmt.Errorf("cannot serialize event: %w", err)
+	}
+
+	msg := kafka.Message{Topic: p.topic, Value: value, Time: event.Timestamp}
+	if p.key != "" {
+		msg.Key = []byte(p.key)
+	}
+
+	if err := p.writer.WriteMessages(ctx, msg); err != nil {
+		return fmt.Errorf("cannot publish event to kafka: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/alexliesenfeld/health/notify"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type writerMock struct {
+	messages []kafka.Message
+}
+
+func (w *writerMock) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	w.messages = append(w.messages, msgs...)
+	return nil
+}
+
+func TestPublisherWritesEvent(t *testing.T) {
+	// Arrange
+	writer := writerMock{}
+	publisher := NewPublisher(&writer, WithTopic("health-events"), WithKey("orders/instance-1"))
+	event := notify.Event{
+		Component: "database",
+		From:      health.StatusUp,
+		To:        health.StatusDown,
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	// Act
+	err := publisher.Notify(context.Background(), event)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, writer.messages, 1)
+	assert.Equal(t, "health-events", writer.messages[0].Topic)
+	assert.Equal(t, "orders/instance-1", string(writer.messages[0].Key))
+	assert.JSONEq(t, `{"component":"database","from":"up","to":"down","timestamp":"2024-01-02T03:04:05Z"}`,
+		string(writer.messages[0].Value))
+}
@@ -0,0 +1,178 @@
+// Package kubernetes provides integrations for services that run in Kubernetes (https://kubernetes.io).
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/alexliesenfeld/health/notify"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+type (
+	// EventEmitter is a notify.Notifier that creates Kubernetes Events on the Pod that runs the service whenever
+	// the status of the system or a component changes. This way, `kubectl describe pod` shows why a readiness probe
+	// started failing. Use it with notify.SystemTransitions and notify.ComponentTransitions.
+	// The service account of the Pod requires permissions to get pods and to create events in its namespace.
+	EventEmitter struct {
+		client    kubernetes.Interface
+		namespace string
+		podName   string
+		component string
+		mtx       sync.Mutex
+		pod       *corev1.ObjectReference
+	}
+
+	// EventEmitterOption is a configuration option for an EventEmitter.
+	EventEmitterOption func(e *EventEmitter)
+)
+
+// namespaceFile is the file that contains the namespace of the Pod when running in-cluster.
+const namespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// WithPod sets the namespace and name of the Pod that events are created for. By default, they are read
+// from the environment variables POD_NAMESPACE and POD_NAME (which can be set using the Downward API).
+// If these are not set, the namespace of the service account and the hostname are used.
+func WithPod(namespace, name string) EventEmitterOption {
+	return func(e *EventEmitter) {
+		e.namespace = namespace
+		e.podName = name
+	}
+}
+
+// WithReportingComponent sets the name of the component that is reported as the source of events.
+// Default is "health".
+func WithReportingComponent(component string) EventEmitterOption {
+	return func(e *EventEmitter) {
+		e.component = component
+	}
+}
+
+// NewEventEmitter creates a new EventEmitter that uses the provided Kubernetes client.
+func NewEventEmitter(client kubernetes.Interface, options ...EventEmitterOption) (*EventEmitter, error) {
+	e := EventEmitter{
+		client:    client,
+		namespace: os.Getenv("POD_NAMESPACE"),
+		podName:   os.Getenv("POD_NAME"),
+		component: "health",
+	}
+
+	for _, opt := range options {
+		opt(&e)
+	}
+
+	if e.namespace == "" {
+		namespace, err := os.ReadFile(namespaceFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot determine pod namespace: %w", err)
+		}
+		e.namespace = strings.TrimSpace(string(namespace))
+	}
+
+	if e.podName == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("cannot determine pod name: %w", err)
+		}
+		e.podName = hostname
+	}
+
+	return &e, nil
+}
+
+// NewInClusterEventEmitter creates a new EventEmitter that uses the in-cluster configuration
+// (see rest.InClusterConfig) to connect to the Kubernetes API.
+func NewInClusterEventEmitter(options ...EventEmitterOption) (*EventEmitter, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEventEmitter(client, options...)
+}
+
+// Notify implements notify.Notifier.
+func (e *EventEmitter) Notify(ctx context.Context, event notify.Event) error {
+	pod, err := e.podReference(ctx)
+	if err != nil {
+		return err
+	}
+
+	msg, err := notify.DefaultMessage(event)
+	if err != nil {
+		return err
+	}
+
+	eventType := corev1.EventTypeNormal
+	if event.To != health.StatusUp {
+		eventType = corev1.EventTypeWarning
+	}
+
+	reason := "System"
+	if event.Component != "" {
+		reason = "Component"
+	}
+	if status := string(event.To); status != "" {
+		reason += strings.ToUpper(status[:1]) + status[1:]
+	}
+
+	now := metav1.NewTime(time.Now())
+	_, err = e.client.CoreV1().Events(e.namespace).Create(ctx, &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", e.podName, now.UnixNano()),
+			Namespace: e.namespace,
+		},
+		InvolvedObject: *pod,
+		Reason:         reason,
+		Message:        msg,
+		Type:           eventType,
+		Source:         corev1.EventSource{Component: e.component},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("cannot create kubernetes event: %w", err)
+	}
+
+	return nil
+}
+
+// podReference returns a reference to the Pod. The Pod is fetched on the first call to get its UID.
+func (e *EventEmitter) podReference(ctx context.Context) (*corev1.ObjectReference, error) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	if e.pod != nil {
+		return e.pod, nil
+	}
+
+	pod, err := e.client.CoreV1().Pods(e.namespace).Get(ctx, e.podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get pod %s/%s: %w", e.namespace, e.podName, err)
+	}
+
+	e.pod = &corev1.ObjectReference{
+		Kind:            "Pod",
+		APIVersion:      "v1",
+		Namespace:       pod.Namespace,
+		Name:            pod.Name,
+		UID:             pod.UID,
+		ResourceVersion: pod.ResourceVersion,
+	}
+
+	return e.pod, nil
+}
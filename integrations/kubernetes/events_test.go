@@ -0,0 +1,42 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/alexliesenfeld/health/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestEventEmitterCreatesPodEvent(t *testing.T) {
+	// Arrange
+	client := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders-0", Namespace: "shop", UID: "1234"},
+	})
+	emitter, err := NewEventEmitter(client, WithPod("shop", "orders-0"))
+	require.NoError(t, err)
+
+	// Act
+	err = emitter.Notify(context.Background(), notify.Event{
+		Component: "database",
+		From:      health.StatusUp,
+		To:        health.StatusDown,
+		Error:     fmt.Errorf("connection refused"),
+	})
+
+	// Assert
+	require.NoError(t, err)
+	events, err := client.CoreV1().Events("shop").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, events.Items, 1)
+	assert.Equal(t, corev1.EventTypeWarning, events.Items[0].Type)
+	assert.Equal(t, "ComponentDown", events.Items[0].Reason)
+	assert.Equal(t, "Component database changed status from up to down: connection refused", events.Items[0].Message)
+	assert.Equal(t, "1234", string(events.Items[0].InvolvedObject.UID))
+}
@@ -0,0 +1,75 @@
+// Package mqtt provides an integration to publish health status messages to an MQTT broker (https://mqtt.org).
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/alexliesenfeld/health/notify"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+type (
+	// Client publishes messages to an MQTT broker. It is implemented by mqtt.Client.
+	Client interface {
+		Publish(topic string, qos byte, retained bool, payload interface{}) mqtt.Token
+	}
+
+	// Publisher is a notify.Notifier that publishes status changes as retained JSON messages
+	// (see notify.Event.MarshalJSON) to a topic tree below a configurable root topic:
+	//   - <root>/status: The aggregated system status (see notify.SystemTransitions).
+	//   - <root>/components/<name>: The status of each component (see notify.ComponentTransitions).
+	//
+	// Because messages are retained, new subscribers immediately receive the latest status of each component.
+	Publisher struct {
+		client Client
+		root   string
+		qos    byte
+	}
+
+	// Option is a configuration option for a Publisher.
+	Option func(p *Publisher)
+)
+
+// WithQoS sets the MQTT quality of service level (0, 1 or 2) of published messages. Default is 1.
+func WithQoS(qos byte) Option {
+	return func(p *Publisher) {
+		p.qos = qos
+	}
+}
+
+// NewPublisher creates a new Publisher that publishes messages below the provided root topic
+// (e.g., "devices/sensor-42/health").
+func NewPublisher(client Client, root string, options ...Option) *Publisher {
+	p := Publisher{client: client, root: root, qos: 1}
+	for _, opt := range options {
+		opt(&p)
+	}
+	return &p
+}
+
+// Notify implements notify.Notifier.
+func (p *Publisher) Notify(ctx context.Context, event notify.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("cannot serialize event: %w", err)
+	}
+
+	topic := p.root + "/status"
+	if event.Component != "" {
+		topic = p.root + "/components/" + event.Component
+	}
+
+	token := p.client.Publish(topic, p.qos, true, payload)
+
+	select {
+	case <-token.Done():
+		if err := token.Error(); err != nil {
+			return fmt.Errorf("cannot publish event to topic %q: %w", topic, err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,43 @@
+package mqtt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/alexliesenfeld/health/notify"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type message struct {
+	topic    string
+	qos      byte
+	retained bool
+}
+
+type clientMock struct {
+	messages []message
+}
+
+func (c *clientMock) Publish(topic string, qos byte, retained bool, payload interface{}) mqtt.Token {
+	c.messages = append(c.messages, message{topic: topic, qos: qos, retained: retained})
+	return &mqtt.DummyToken{}
+}
+
+func TestPublisherPublishesRetainedMessagesPerComponent(t *testing.T) {
+	// Arrange
+	client := clientMock{}
+	publisher := NewPublisher(&client, "devices/sensor-42/health")
+
+	// Act
+	require.NoError(t, publisher.Notify(context.Background(), notify.Event{To: health.StatusDown}))
+	require.NoError(t, publisher.Notify(context.Background(), notify.Event{Component: "modem", To: health.StatusDown}))
+
+	// Assert
+	assert.Equal(t, []message{
+		{topic: "devices/sensor-42/health/status", qos: 1, retained: true},
+		{topic: "devices/sensor-42/health/components/modem", qos: 1, retained: true},
+	}, client.messages)
+}
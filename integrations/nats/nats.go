@@ -0,0 +1,109 @@
+// Package nats provides an integration to publish health status changes and heartbeats to NATS (https://nats.io).
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/alexliesenfeld/health/notify"
+	"github.com/nats-io/nats.go"
+)
+
+type (
+	// Conn publishes messages to NATS. It is implemented by *nats.Conn.
+	Conn interface {
+		Publish(subject string, data []byte) error
+	}
+
+	// Publisher is a notify.Notifier that publishes status changes as JSON messages (see notify.Event.MarshalJSON)
+	// to a NATS subject. Use it with notify.SystemTransitions and notify.ComponentTransitions.
+	// Optionally, it publishes the full health check result periodically (see Publisher.StartHeartbeat).
+	Publisher struct {
+		conn             Conn
+		subject          string
+		heartbeatSubject string
+	}
+
+	// Option is a configuration option for a Publisher.
+	Option func(p *Publisher)
+)
+
+var _ Conn = (*nats.Conn)(nil)
+
+// WithHeartbeatSubject sets the subject that heartbeats are published to (see Publisher.StartHeartbeat).
+// Default is the status change subject with suffix ".heartbeat".
+func WithHeartbeatSubject(subject string) Option {
+	return func(p *Publisher) {
+		p.heartbeatSubject = subject
+	}
+}
+
+// NewPublisher creates a new Publisher that publishes status changes to the provided subject.
+func NewPublisher(conn Conn, subject string, options ...Option) *Publisher {
+	p := Publisher{conn: conn, subject: subject, heartbeatSubject: subject + ".heartbeat"}
+	for _, opt := range options {
+		opt(&p)
+	}
+	return &p
+}
+
+// Notify implements notify.Notifier.
+func (p *Publisher) Notify(ctx context.Context, event notify.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("cannot serialize event: %w", err)
+	}
+
+	if err := p.conn.Publish(p.subject, data); err != nil {
+		return fmt.Errorf("cannot publish event to nats: %w", err)
+	}
+
+	return nil
+}
+
+// StartHeartbeat starts publishing the health.CheckerResult of the provided health.Checker (encoded as JSON)
+// in the provided interval, so that subscribers can track the health of a fleet without scraping HTTP endpoints.
+// The first heartbeat is published immediately. Errors are logged using the standard logger (see package log).
+// The returned function stops publishing heartbeats.
+func (p *Publisher) StartHeartbeat(checker health.Checker, interval time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := p.publishHeartbeat(ctx, checker); err != nil {
+				log.Printf("cannot publish health heartbeat to nats: %v", err)
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+func (p *Publisher) publishHeartbeat(ctx context.Context, checker health.Checker) error {
+	data, err := json.Marshal(checker.Check(ctx))
+	if err != nil {
+		return err
+	}
+	return p.conn.Publish(p.heartbeatSubject, data)
+}
@@ -0,0 +1,66 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/alexliesenfeld/health/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type connMock struct {
+	mtx      sync.Mutex
+	messages map[string][][]byte
+}
+
+func (c *connMock) Publish(subject string, data []byte) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.messages[subject] = append(c.messages[subject], data)
+	return nil
+}
+
+func (c *connMock) get(subject string) [][]byte {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.messages[subject]
+}
+
+func TestPublisherPublishesEvent(t *testing.T) {
+	// Arrange
+	conn := connMock{messages: map[string][][]byte{}}
+	publisher := NewPublisher(&conn, "health.orders")
+
+	// Act
+	err := publisher.Notify(context.Background(), notify.Event{
+		From:      health.StatusUp,
+		To:        health.StatusDown,
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, conn.get("health.orders"), 1)
+	assert.JSONEq(t, `{"from":"up","to":"down","timestamp":"2024-01-02T03:04:05Z"}`, string(conn.get("health.orders")[0]))
+}
+
+func TestPublisherPublishesHeartbeat(t *testing.T) {
+	// Arrange
+	conn := connMock{messages: map[string][][]byte{}}
+	publisher := NewPublisher(&conn, "health.orders")
+
+	// Act
+	stop := publisher.StartHeartbeat(health.NewChecker(health.WithDisabledAutostart()), 1*time.Hour)
+	stop()
+
+	// Assert
+	require.Len(t, conn.get("health.orders.heartbeat"), 1)
+	var result health.CheckerResult
+	require.NoError(t, json.Unmarshal(conn.get("health.orders.heartbeat")[0], &result))
+	assert.Equal(t, health.StatusUp, result.Status)
+}
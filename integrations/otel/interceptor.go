@@ -0,0 +1,33 @@
+package otel
+
+import (
+	"context"
+
+	"github.com/alexliesenfeld/health"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceInterceptor creates a health.Interceptor that wraps every check function execution in a span that is
+// named after the checked component, using the provided trace.Tracer. The resulting availability status is added
+// as span attribute "health.status" and check errors are recorded on the span. Spans of synchronous checks become
+// children of the span that is contained in the request context (see TraceMiddleware), whereas spans of periodic
+// checks are root spans.
+func TraceInterceptor(tracer trace.Tracer) health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			ctx, span := tracer.Start(ctx, name, trace.WithAttributes(componentKey.String(name)))
+			defer span.End()
+
+			result := next(ctx, name, state)
+
+			span.SetAttributes(statusKey.String(string(result.Status)))
+			if result.Result != nil {
+				span.RecordError(result.Result)
+				span.SetStatus(codes.Error, result.Result.Error())
+			}
+
+			return result
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package otel
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// MetricsInterceptor creates a health.Interceptor that records metrics about check function executions
+// using the provided metric.Meter. The following instruments are used, each recorded with the name of the checked
+// component as attribute "health.component":
+//   - health.component.status: An observable gauge of the current availability status of each component
+//     (1 means "up", 0 means "down" and -1 means "unknown").
+//   - health.component.consecutive_failures: An observable gauge of the number of contiguous failed executions
+//     of each component.
+//   - health.component.failures: A counter of failed check function executions.
+//   - health.component.duration: A histogram of check function execution durations in seconds.
+func MetricsInterceptor(meter metric.Meter) (health.Interceptor, error) {
+	var (
+		mtx    sync.Mutex
+		states = map[string]health.CheckState{}
+	)
+
+	_, err := meter.Int64ObservableGauge("health.component.status",
+		metric.WithDescription("Availability status of a component (1 = up, 0 = down, -1 = unknown)."),
+		metric.WithInt64Callback(func(ctx context.Context, observer metric.Int64Observer) error {
+			mtx.Lock()
+			defer mtx.Unlock()
+			for name, state := range states {
+				observer.Observe(statusValue(state.Status), metric.WithAttributes(componentKey.String(name)))
+			}
+			return nil
+		}))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = meter.Int64ObservableGauge("health.component.consecutive_failures",
+		metric.WithDescription("Number of contiguous failed check executions of a component."),
+		metric.WithUnit("{failure}"),
+		metric.WithInt64Callback(func(ctx context.Context, observer metric.Int64Observer) error {
+			mtx.Lock()
+			defer mtx.Unlock()
+			for name, state := range states {
+				observer.Observe(int64(state.ContiguousFails), metric.WithAttributes(componentKey.String(name)))
+			}
+			return nil
+		}))
+	if err != nil {
+		return nil, err
+	}
+
+	failures, err := meter.Int64Counter("health.component.failures",
+		metric.WithDescription("Number of failed check executions of a component."),
+		metric.WithUnit("{failure}"))
+	if err != nil {
+		return nil, err
+	}
+
+	durations, err := meter.Float64Histogram("health.component.duration",
+		metric.WithDescription("Duration of check executions of a component."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			now := time.Now()
+			result := next(ctx, name, state)
+
+			attributes := metric.WithAttributes(componentKey.String(name))
+			durations.Record(ctx, time.Since(now).Seconds(), attributes)
+			if result.Result != nil {
+				failures.Add(ctx, 1, attributes)
+			}
+
+			mtx.Lock()
+			states[name] = result
+			mtx.Unlock()
+
+			return result
+		}
+	}, nil
+}
@@ -0,0 +1,57 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMetricsInterceptorRecordsComponentState(t *testing.T) {
+	// Arrange
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	interceptor, err := MetricsInterceptor(provider.Meter("health"))
+	require.NoError(t, err)
+
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithInterceptors(interceptor),
+		health.WithCheck(health.Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				return fmt.Errorf("connection refused")
+			},
+		}),
+	)
+
+	// Act
+	checker.Check(context.Background())
+	checker.Check(context.Background())
+
+	// Assert
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &data))
+	require.Len(t, data.ScopeMetrics, 1)
+
+	values := map[string]int64{}
+	for _, m := range data.ScopeMetrics[0].Metrics {
+		switch d := m.Data.(type) {
+		case metricdata.Gauge[int64]:
+			values[m.Name] = d.DataPoints[0].Value
+		case metricdata.Sum[int64]:
+			values[m.Name] = d.DataPoints[0].Value
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"health.component.status":               0,
+		"health.component.consecutive_failures": 2,
+		"health.component.failures":             2,
+	}, values)
+}
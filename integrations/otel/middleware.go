@@ -0,0 +1,51 @@
+package otel
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// MetricsMiddleware creates a health.Middleware that records metrics about processed health check requests
+// using the provided metric.Meter. The following instruments are used, each recorded with the aggregated
+// availability status of the result as attribute "health.status" and the HTTP status code of the response
+// as attribute "http.response.status_code":
+//   - health.http.requests: A counter of processed health check requests.
+//   - health.http.request.duration: A histogram of the request processing durations in seconds.
+//
+// The status code is only known if the middleware is used with a handler created by health.NewHandler
+// (see health.OnResponse). Otherwise, attribute "http.response.status_code" is omitted.
+func MetricsMiddleware(meter metric.Meter) (health.Middleware, error) {
+	requests, err := meter.Int64Counter("health.http.requests",
+		metric.WithDescription("Number of processed health check requests."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+
+	durations, err := meter.Float64Histogram("health.http.request.duration",
+		metric.WithDescription("Duration of processing health check requests."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next health.MiddlewareFunc) health.MiddlewareFunc {
+		return func(r *http.Request) health.CheckerResult {
+			now := time.Now()
+			result := next(r)
+			record := func(attrs ...attribute.KeyValue) {
+				attributes := metric.WithAttributes(append([]attribute.KeyValue{statusKey.String(string(result.Status))}, attrs...)...)
+				requests.Add(r.Context(), 1, attributes)
+				durations.Record(r.Context(), time.Since(now).Seconds(), attributes)
+			}
+			if !health.OnResponse(r, func(statusCode int) { record(statusCodeKey.Int(statusCode)) }) {
+				record()
+			}
+			return result
+		}
+	}, nil
+}
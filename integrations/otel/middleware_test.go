@@ -0,0 +1,70 @@
+package otel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMetricsMiddlewareRecordsRequests(t *testing.T) {
+	// Arrange
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	middleware, err := MetricsMiddleware(provider.Meter("health"))
+	require.NoError(t, err)
+
+	handler := health.NewHandler(health.NewChecker(), health.WithMiddleware(middleware))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &data))
+	require.Len(t, data.ScopeMetrics, 1)
+
+	names := make([]string, 0)
+	for _, m := range data.ScopeMetrics[0].Metrics {
+		names = append(names, m.Name)
+	}
+	assert.ElementsMatch(t, []string{"health.http.requests", "health.http.request.duration"}, names)
+}
+
+func TestMetricsMiddlewareRecordsStatusCode(t *testing.T) {
+	// Arrange
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	middleware, err := MetricsMiddleware(provider.Meter("health"))
+	require.NoError(t, err)
+
+	handler := health.NewHandler(health.NewChecker(), health.WithMiddleware(middleware),
+		health.WithStatusCodeUp(http.StatusNoContent))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &data))
+	require.Len(t, data.ScopeMetrics, 1)
+
+	for _, m := range data.ScopeMetrics[0].Metrics {
+		if m.Name != "health.http.requests" {
+			continue
+		}
+		points := m.Data.(metricdata.Sum[int64]).DataPoints
+		require.Len(t, points, 1)
+		statusCode, ok := points[0].Attributes.Value(statusCodeKey)
+		require.True(t, ok)
+		assert.Equal(t, int64(http.StatusNoContent), statusCode.AsInt64())
+		status, _ := points[0].Attributes.Value(statusKey)
+		assert.Equal(t, "up", status.AsString())
+	}
+}
@@ -0,0 +1,27 @@
+// Package otel provides integrations to observe health checks with OpenTelemetry (https://opentelemetry.io).
+package otel
+
+import (
+	"github.com/alexliesenfeld/health"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// statusKey is the attribute key for availability statuses.
+	statusKey = attribute.Key("health.status")
+	// statusCodeKey is the attribute key for HTTP response status codes.
+	statusCodeKey = attribute.Key("http.response.status_code")
+)
+
+// statusValue converts an availability status into a gauge value: 1 means "up", 0 means "down"
+// and -1 means "unknown".
+func statusValue(status health.AvailabilityStatus) int64 {
+	switch status {
+	case health.StatusUp:
+		return 1
+	case health.StatusDown:
+		return 0
+	default:
+		return -1
+	}
+}
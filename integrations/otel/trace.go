@@ -0,0 +1,49 @@
+package otel
+
+import (
+	"net/http"
+
+	"github.com/alexliesenfeld/health"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	componentKey = attribute.Key("health.component")
+	errorKey     = attribute.Key("health.error")
+)
+
+// TraceMiddleware creates a health.Middleware that starts a span for every processed health check request
+// using the provided trace.Tracer. The aggregated availability status is added as span attribute "health.status"
+// and the status of each component is recorded as a span event. If the aggregated status is not "up", the span
+// status is set to codes.Error. The span context is propagated to the health.Checker via the request context, so
+// that spans created by interceptors or check functions become children of the request span.
+func TraceMiddleware(tracer trace.Tracer) health.Middleware {
+	return func(next health.MiddlewareFunc) health.MiddlewareFunc {
+		return func(r *http.Request) health.CheckerResult {
+			ctx, span := tracer.Start(r.Context(), "health.request", trace.WithSpanKind(trace.SpanKindServer))
+			defer span.End()
+
+			result := next(r.WithContext(ctx))
+
+			span.SetAttributes(statusKey.String(string(result.Status)))
+			for name, checkResult := range result.Details {
+				attributes := []attribute.KeyValue{
+					componentKey.String(name),
+					statusKey.String(string(checkResult.Status)),
+				}
+				if checkResult.Error != nil {
+					attributes = append(attributes, errorKey.String(checkResult.Error.Error()))
+				}
+				span.AddEvent("health.component", trace.WithAttributes(attributes...))
+			}
+
+			if result.Status != health.StatusUp {
+				span.SetStatus(codes.Error, "system is "+string(result.Status))
+			}
+
+			return result
+		}
+	}
+}
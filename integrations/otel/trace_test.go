@@ -0,0 +1,72 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceMiddlewarePropagatesSpanContext(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	var checkSpanContext trace.SpanContext
+	checker := health.NewChecker(health.WithDisabledAutostart(), health.WithCheck(health.Check{
+		Name: "check",
+		Check: func(ctx context.Context) error {
+			checkSpanContext = trace.SpanContextFromContext(ctx)
+			return fmt.Errorf("check error")
+		},
+	}))
+	handler := health.NewHandler(checker, health.WithMiddleware(TraceMiddleware(provider.Tracer("health"))))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, spans[0].SpanContext().TraceID(), checkSpanContext.TraceID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, "health.component", spans[0].Events()[0].Name)
+}
+
+func TestTraceInterceptorCreatesChildSpans(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("health")
+
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(TraceInterceptor(tracer)),
+		health.WithCheck(health.Check{
+			Name: "check",
+			Check: func(ctx context.Context) error {
+				return fmt.Errorf("check error")
+			},
+		}),
+	)
+	handler := health.NewHandler(checker, health.WithMiddleware(TraceMiddleware(tracer)))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "check", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+}
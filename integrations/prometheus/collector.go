@@ -0,0 +1,95 @@
+package prometheus
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type (
+	// Collector is a prometheus.Collector that exposes the state of a health.Checker. The state is
+	// retrieved by calling health.Checker.Check each time metrics are collected, so results are subject to
+	// the caching configuration of the checker (see health.WithCacheDuration).
+	Collector struct {
+		checker        health.Checker
+		statusDesc     *prometheus.Desc
+		checkDesc      *prometheus.Desc
+		lastCheckDesc  *prometheus.Desc
+		failuresDesc   *prometheus.Desc
+		mtx            sync.Mutex
+		componentState map[string]*componentState
+	}
+
+	componentState struct {
+		lastCheckedAt time.Time
+		failures      float64
+	}
+)
+
+// NewCollector creates a new Collector for the provided health.Checker. The following metrics are exposed
+// (status values are 1 for "up", 0 for "down" and -1 for "unknown"):
+//   - health_status: The aggregated availability status of the system.
+//   - health_component_status: The availability status of each component, labeled by check name ("check").
+//   - health_component_last_checked_timestamp_seconds: The time of when each component was last checked.
+//   - health_component_observed_failures_total: The number of failed check executions of each component that
+//     were observed by the collector. Because the collector only sees the latest result of a check at the time of
+//     collection, failures between two collections may be missed. Use Interceptor for exact execution metrics.
+//
+// Component metrics are only available if the checker does not hide details (see health.WithDisabledDetails).
+func NewCollector(checker health.Checker) *Collector {
+	return &Collector{
+		checker: checker,
+		statusDesc: prometheus.NewDesc("health_status",
+			"Aggregated availability status of the system (1 = up, 0 = down, -1 = unknown).", nil, nil),
+		checkDesc: prometheus.NewDesc("health_component_status",
+			"Availability status of a component (1 = up, 0 = down, -1 = unknown).", []string{"check"}, nil),
+		lastCheckDesc: prometheus.NewDesc("health_component_last_checked_timestamp_seconds",
+			"Time of when a component was last checked.", []string{"check"}, nil),
+		failuresDesc: prometheus.NewDesc("health_component_observed_failures_total",
+			"Number of observed failed check executions of a component.", []string{"check"}, nil),
+		componentState: map[string]*componentState{},
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.statusDesc
+	ch <- c.checkDesc
+	ch <- c.lastCheckDesc
+	ch <- c.failuresDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	result := c.checker.Check(context.Background())
+
+	ch <- prometheus.MustNewConstMetric(c.statusDesc, prometheus.GaugeValue, statusValue(result.Status))
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for name, check := range result.Details {
+		state, ok := c.componentState[name]
+		if !ok {
+			state = &componentState{}
+			c.componentState[name] = state
+		}
+
+		if check.Timestamp != state.lastCheckedAt {
+			state.lastCheckedAt = check.Timestamp
+			if check.Error != nil {
+				state.failures++
+			}
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.checkDesc, prometheus.GaugeValue, statusValue(check.Status), name)
+		ch <- prometheus.MustNewConstMetric(c.failuresDesc, prometheus.CounterValue, state.failures, name)
+		if !check.Timestamp.IsZero() {
+			ch <- prometheus.MustNewConstMetric(c.lastCheckDesc, prometheus.GaugeValue,
+				float64(check.Timestamp.UnixNano())/1e9, name)
+		}
+	}
+}
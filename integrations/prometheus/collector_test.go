@@ -0,0 +1,46 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectorExposesCheckerState(t *testing.T) {
+	// Arrange
+	registry := prometheus.NewRegistry()
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				return fmt.Errorf("connection refused")
+			},
+		}),
+	)
+	require.NoError(t, registry.Register(NewCollector(checker)))
+
+	// Act
+	err := testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP health_component_observed_failures_total Number of observed failed check executions of a component.
+# TYPE health_component_observed_failures_total counter
+health_component_observed_failures_total{check="database"} 1
+# HELP health_component_status Availability status of a component (1 = up, 0 = down, -1 = unknown).
+# TYPE health_component_status gauge
+health_component_status{check="database"} 0
+# HELP health_status Aggregated availability status of the system (1 = up, 0 = down, -1 = unknown).
+# TYPE health_status gauge
+health_status 0
+`), "health_status", "health_component_status", "health_component_observed_failures_total")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 4, testutil.CollectAndCount(registry))
+}
@@ -0,0 +1,71 @@
+package prometheus
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Interceptor creates a health.Interceptor that records metrics about check function executions and registers
+// them with the provided prometheus.Registerer. The following metrics are recorded, each labeled by check name
+// ("check"):
+//   - health_check_executions_total: The number of check function executions.
+//   - health_check_failures_total: The number of check function executions that returned an error.
+//   - health_check_duration_seconds: A histogram of check function execution durations.
+//   - health_check_status: The availability status of the check after its last execution
+//     (1 means "up", 0 means "down" and -1 means "unknown").
+//
+// If the metrics have already been registered (e.g., by an interceptor of another health.Checker),
+// the existing metrics will be reused.
+func Interceptor(registerer prometheus.Registerer) (health.Interceptor, error) {
+	executions, err := register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "health_check_executions_total",
+		Help: "Number of check function executions.",
+	}, []string{"check"}))
+	if err != nil {
+		return nil, err
+	}
+
+	failures, err := register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "health_check_failures_total",
+		Help: "Number of check function executions that returned an error.",
+	}, []string{"check"}))
+	if err != nil {
+		return nil, err
+	}
+
+	durations, err := register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "health_check_duration_seconds",
+		Help:    "Duration of check function executions.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"check"}))
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := register(registerer, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "health_check_status",
+		Help: "Availability status of the check (1 = up, 0 = down, -1 = unknown).",
+	}, []string{"check"}))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			now := time.Now()
+			result := next(ctx, name, state)
+
+			executions.WithLabelValues(name).Inc()
+			if result.Result != nil {
+				failures.WithLabelValues(name).Inc()
+			}
+			durations.WithLabelValues(name).Observe(time.Since(now).Seconds())
+			statuses.WithLabelValues(name).Set(statusValue(result.Status))
+
+			return result
+		}
+	}, nil
+}
@@ -0,0 +1,43 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterceptorRecordsCheckExecutions(t *testing.T) {
+	// Arrange
+	registry := prometheus.NewRegistry()
+	interceptor, err := Interceptor(registry)
+	require.NoError(t, err)
+
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(interceptor),
+		health.WithCheck(health.Check{
+			Name: "check",
+			Check: func(ctx context.Context) error {
+				return fmt.Errorf("check error")
+			},
+		}),
+	)
+
+	// Act
+	checker.Check(context.Background())
+
+	// Assert
+	failures, err := register(registry, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "health_check_failures_total",
+		Help: "Number of check function executions that returned an error.",
+	}, []string{"check"}))
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, testutil.ToFloat64(failures.WithLabelValues("check")))
+	assert.Equal(t, 4, testutil.CollectAndCount(registry))
+}
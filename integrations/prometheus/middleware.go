@@ -0,0 +1,56 @@
+package prometheus
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Middleware creates a health.Middleware that records metrics about processed health check requests
+// and registers them with the provided prometheus.Registerer. The following metrics are recorded, each
+// labeled by the aggregated availability status of the result ("status") and the HTTP status code of
+// the response ("code"):
+//   - health_http_requests_total: The number of processed health check requests.
+//   - health_http_request_duration_seconds: A histogram of the request processing durations.
+//
+// The status code is only known if the middleware is used with a handler created by health.NewHandler
+// (see health.OnResponse). Otherwise, label "code" is empty.
+//
+// If the metrics have already been registered (e.g., by a middleware of another handler), the existing
+// metrics will be reused.
+func Middleware(registerer prometheus.Registerer) (health.Middleware, error) {
+	requests, err := register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "health_http_requests_total",
+		Help: "Number of processed health check requests, labeled by aggregated availability status and HTTP status code.",
+	}, []string{"status", "code"}))
+	if err != nil {
+		return nil, err
+	}
+
+	durations, err := register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "health_http_request_duration_seconds",
+		Help:    "Duration of processing health check requests, labeled by aggregated availability status and HTTP status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"status", "code"}))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next health.MiddlewareFunc) health.MiddlewareFunc {
+		return func(r *http.Request) health.CheckerResult {
+			now := time.Now()
+			result := next(r)
+			record := func(code string) {
+				requests.WithLabelValues(string(result.Status), code).Inc()
+				durations.WithLabelValues(string(result.Status), code).Observe(time.Since(now).Seconds())
+			}
+			if !health.OnResponse(r, func(statusCode int) { record(strconv.Itoa(statusCode)) }) {
+				record("")
+			}
+			return result
+		}
+	}, nil
+}
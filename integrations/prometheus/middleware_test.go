@@ -0,0 +1,59 @@
+package prometheus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddlewareRecordsRequests(t *testing.T) {
+	// Arrange
+	registry := prometheus.NewRegistry()
+	first, err := Middleware(registry)
+	require.NoError(t, err)
+	second, err := Middleware(registry)
+	require.NoError(t, err)
+
+	handler := health.NewHandler(health.NewChecker(), health.WithMiddleware(first, second))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	assert.Equal(t, 1, testutil.CollectAndCount(registry, "health_http_requests_total"))
+	for _, family := range families {
+		if family.GetName() == "health_http_requests_total" {
+			assert.Equal(t, 2.0, family.GetMetric()[0].GetCounter().GetValue())
+		}
+	}
+}
+
+func TestMiddlewareRecordsStatusCode(t *testing.T) {
+	// Arrange
+	registry := prometheus.NewRegistry()
+	middleware, err := Middleware(registry)
+	require.NoError(t, err)
+
+	handler := health.NewHandler(health.NewChecker(), health.WithMiddleware(middleware),
+		health.WithStatusCodeUp(http.StatusNoContent))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	expected := `
+		# HELP health_http_requests_total Number of processed health check requests, labeled by aggregated availability status and HTTP status code.
+		# TYPE health_http_requests_total counter
+		health_http_requests_total{code="204",status="up"} 1
+	`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "health_http_requests_total"))
+}
@@ -0,0 +1,38 @@
+// Package prometheus provides integrations to observe health checks with Prometheus (https://prometheus.io).
+package prometheus
+
+import (
+	"errors"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// register registers the provided collector. If an equal collector has already been registered before,
+// the existing collector will be returned instead. This allows creating several instances of the same
+// integration (e.g., a middleware for multiple handlers) that share their metrics.
+func register[T prometheus.Collector](registerer prometheus.Registerer, collector T) (T, error) {
+	if err := registerer.Register(collector); err != nil {
+		var alreadyRegisteredErr prometheus.AlreadyRegisteredError
+		if errors.As(err, &alreadyRegisteredErr) {
+			if existing, ok := alreadyRegisteredErr.ExistingCollector.(T); ok {
+				return existing, nil
+			}
+		}
+		return collector, err
+	}
+	return collector, nil
+}
+
+// statusValue converts an availability status into a gauge value: 1 means "up", 0 means "down"
+// and -1 means "unknown".
+func statusValue(status health.AvailabilityStatus) float64 {
+	switch status {
+	case health.StatusUp:
+		return 1
+	case health.StatusDown:
+		return 0
+	default:
+		return -1
+	}
+}
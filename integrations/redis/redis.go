@@ -0,0 +1,119 @@
+// Package redis provides an integration to publish the health state of a service to Redis (https://redis.io).
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/alexliesenfeld/health/notify"
+	"github.com/redis/go-redis/v9"
+)
+
+type (
+	// Client is the subset of the Redis client API that is used by the Publisher.
+	// It is implemented by redis.UniversalClient (e.g., *redis.Client or *redis.ClusterClient).
+	Client interface {
+		Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+		Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd
+	}
+
+	// Publisher stores the health check result of a service in a Redis key and publishes status changes on a
+	// Redis channel. This allows building simple cross-service dashboards that are backed by Redis.
+	Publisher struct {
+		client  Client
+		key     string
+		channel string
+		ttl     time.Duration
+	}
+
+	// Option is a configuration option for a Publisher.
+	Option func(p *Publisher)
+)
+
+var _ Client = (redis.UniversalClient)(nil)
+
+// WithTTL sets the expiration time of the key that holds the health check result. If the service stops
+// refreshing the key (see Publisher.Start), e.g. because it crashed, Redis removes the key after this duration.
+// Default is 30 seconds.
+func WithTTL(ttl time.Duration) Option {
+	return func(p *Publisher) {
+		p.ttl = ttl
+	}
+}
+
+// NewPublisher creates a new Publisher that stores the health check result under the provided key and publishes
+// status changes on the provided channel.
+func NewPublisher(client Client, key, channel string, options ...Option) *Publisher {
+	p := Publisher{client: client, key: key, channel: channel, ttl: 30 * time.Second}
+	for _, opt := range options {
+		opt(&p)
+	}
+	return &p
+}
+
+// Notify implements notify.Notifier. It publishes the event as a JSON message (see notify.Event.MarshalJSON)
+// on the channel. Use it with notify.SystemTransitions and notify.ComponentTransitions.
+func (p *Publisher) Notify(ctx context.Context, event notify.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("cannot serialize event: %w", err)
+	}
+
+	if err := p.client.Publish(ctx, p.channel, data).Err(); err != nil {
+		return fmt.Errorf("cannot publish event on channel %q: %w", p.channel, err)
+	}
+
+	return nil
+}
+
+// Store writes the provided result (encoded as JSON) into the key, using the configured TTL.
+func (p *Publisher) Store(ctx context.Context, result health.CheckerResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("cannot serialize health check result: %w", err)
+	}
+
+	if err := p.client.Set(ctx, p.key, data, p.ttl).Err(); err != nil {
+		return fmt.Errorf("cannot write key %q: %w", p.key, err)
+	}
+
+	return nil
+}
+
+// Start starts storing the result of the provided health.Checker in the provided interval (see Publisher.Store).
+// The interval should be shorter than the TTL (see WithTTL). The first result is stored immediately.
+// Errors are logged using the standard logger (see package log). The returned function stops storing results.
+func (p *Publisher) Start(checker health.Checker, interval time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := p.Store(ctx, checker.Check(ctx)); err != nil && ctx.Err() == nil {
+				log.Printf("cannot store health check result in redis: %v", err)
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
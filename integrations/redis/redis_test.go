@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/alexliesenfeld/health/notify"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type clientMock struct {
+	values    map[string]interface{}
+	ttls      map[string]time.Duration
+	published map[string][]interface{}
+}
+
+func (c *clientMock) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	c.values[key] = value
+	c.ttls[key] = expiration
+	return redis.NewStatusResult("OK", nil)
+}
+
+func (c *clientMock) Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd {
+	c.published[channel] = append(c.published[channel], message)
+	return redis.NewIntResult(1, nil)
+}
+
+func newClientMock() *clientMock {
+	return &clientMock{values: map[string]interface{}{}, ttls: map[string]time.Duration{}, published: map[string][]interface{}{}}
+}
+
+func TestPublisherStoresResultWithTTL(t *testing.T) {
+	// Arrange
+	client := newClientMock()
+	publisher := NewPublisher(client, "health:orders", "health-events", WithTTL(1*time.Minute))
+
+	// Act
+	stop := publisher.Start(health.NewChecker(health.WithDisabledAutostart()), 1*time.Hour)
+	stop()
+
+	// Assert
+	var result health.CheckerResult
+	require.NoError(t, json.Unmarshal(client.values["health:orders"].([]byte), &result))
+	assert.Equal(t, health.StatusUp, result.Status)
+	assert.Equal(t, 1*time.Minute, client.ttls["health:orders"])
+}
+
+func TestPublisherPublishesEvents(t *testing.T) {
+	// Arrange
+	client := newClientMock()
+	publisher := NewPublisher(client, "health:orders", "health-events")
+
+	// Act
+	err := publisher.Notify(context.Background(), notify.Event{
+		Component: "database",
+		From:      health.StatusUp,
+		To:        health.StatusDown,
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, client.published["health-events"], 1)
+	assert.JSONEq(t, `{"component":"database","from":"up","to":"down","timestamp":"2024-01-02T03:04:05Z"}`,
+		string(client.published["health-events"][0].([]byte)))
+}
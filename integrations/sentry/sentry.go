@@ -0,0 +1,126 @@
+// Package sentry provides an integration to report unavailable components to Sentry (https://sentry.io),
+// so that outages of dependencies appear alongside application errors.
+package sentry
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/getsentry/sentry-go"
+)
+
+type (
+	// Hub is the subset of the Sentry SDK API that is used by the Reporter.
+	// It is implemented by *sentry.Hub (e.g., sentry.CurrentHub()).
+	Hub interface {
+		CaptureEvent(event *sentry.Event) *sentry.EventID
+	}
+
+	// Reporter captures an event in Sentry whenever a component transitions to health.StatusDown.
+	// Each event contains the name of the check, the error that was returned by the check function
+	// and the most recent status transitions of the check as breadcrumbs.
+	Reporter struct {
+		hub            Hub
+		maxBreadcrumbs int
+		mtx            sync.Mutex
+		breadcrumbs    map[string][]*sentry.Breadcrumb
+	}
+
+	// Option is a configuration option for a Reporter.
+	Option func(r *Reporter)
+)
+
+// WithMaxBreadcrumbs sets the maximum number of status transitions per check that are attached to
+// an event as breadcrumbs. Default is 10. A negative value is treated as zero.
+func WithMaxBreadcrumbs(n int) Option {
+	return func(r *Reporter) {
+		if n < 0 {
+			n = 0
+		}
+		r.maxBreadcrumbs = n
+	}
+}
+
+// NewReporter creates a new Reporter that captures events using the provided Hub.
+func NewReporter(hub Hub, options ...Option) *Reporter {
+	r := Reporter{hub: hub, maxBreadcrumbs: 10, breadcrumbs: map[string][]*sentry.Breadcrumb{}}
+	for _, opt := range options {
+		opt(&r)
+	}
+	return &r
+}
+
+// Interceptor creates a health.Interceptor that records status transitions of checks and captures
+// an event in Sentry whenever a check transitions to health.StatusDown. Use it with
+// health.WithInterceptors (for all checks) or health.Check.Interceptors (for individual checks).
+func (r *Reporter) Interceptor() health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			result := next(ctx, name, state)
+			if result.Status == state.Status {
+				return result
+			}
+
+			breadcrumbs := r.record(name, state.Status, result)
+			if result.Status == health.StatusDown {
+				r.hub.CaptureEvent(r.event(name, result, breadcrumbs))
+			}
+
+			return result
+		}
+	}
+}
+
+func (r *Reporter) record(name string, from health.AvailabilityStatus, state health.CheckState) []*sentry.Breadcrumb {
+	breadcrumb := sentry.Breadcrumb{
+		Type:      "default",
+		Category:  "health",
+		Message:   fmt.Sprintf("health check %q changed from %s to %s", name, from, state.Status),
+		Data:      map[string]interface{}{"check": name, "from": string(from), "to": string(state.Status)},
+		Level:     sentry.LevelInfo,
+		Timestamp: time.Now().UTC(),
+	}
+	if state.Result != nil {
+		breadcrumb.Data["error"] = state.Result.Error()
+	}
+	if state.Status == health.StatusDown {
+		breadcrumb.Level = sentry.LevelError
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	breadcrumbs := append(r.breadcrumbs[name], &breadcrumb)
+	if len(breadcrumbs) > r.maxBreadcrumbs {
+		breadcrumbs = breadcrumbs[len(breadcrumbs)-r.maxBreadcrumbs:]
+	}
+	r.breadcrumbs[name] = breadcrumbs
+
+	return append([]*sentry.Breadcrumb(nil), breadcrumbs...)
+}
+
+func (r *Reporter) event(name string, state health.CheckState, breadcrumbs []*sentry.Breadcrumb) *sentry.Event {
+	event := sentry.NewEvent()
+	event.Level = sentry.LevelError
+	event.Message = fmt.Sprintf("health check %q is down", name)
+	event.Tags["health.check"] = name
+	event.Fingerprint = []string{"health", name}
+	event.Breadcrumbs = breadcrumbs
+	event.Extra["contiguous_fails"] = state.ContiguousFails
+	if !state.LastSuccessAt.IsZero() {
+		event.Extra["last_success_at"] = state.LastSuccessAt
+	}
+
+	if state.Result != nil {
+		event.Exception = []sentry.Exception{{
+			Type:  reflect.TypeOf(state.Result).String(),
+			Value: state.Result.Error(),
+		}}
+	}
+
+	return event
+}
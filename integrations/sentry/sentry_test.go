@@ -0,0 +1,75 @@
+package sentry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Hub = (*sentry.Hub)(nil)
+
+type hubMock struct {
+	events []*sentry.Event
+}
+
+func (h *hubMock) CaptureEvent(event *sentry.Event) *sentry.EventID {
+	h.events = append(h.events, event)
+	return &event.EventID
+}
+
+func TestInterceptorCapturesEventOnDownTransition(t *testing.T) {
+	// Arrange
+	hub := hubMock{}
+	reporter := NewReporter(&hub, WithMaxBreadcrumbs(2))
+	checkErr := errors.New("connection refused")
+	results := []health.CheckState{
+		{Status: health.StatusUp},
+		{Status: health.StatusDown, Result: checkErr},
+		{Status: health.StatusDown, Result: checkErr},
+		{Status: health.StatusUp},
+		{Status: health.StatusDown, Result: checkErr, ContiguousFails: 1},
+	}
+	interceptor := reporter.Interceptor()
+
+	// Act
+	state := health.CheckState{Status: health.StatusUnknown}
+	for _, result := range results {
+		result := result
+		state = interceptor(func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			return result
+		})(context.Background(), "database", state)
+	}
+
+	// Assert
+	require.Len(t, hub.events, 2)
+	event := hub.events[1]
+	assert.Equal(t, sentry.LevelError, event.Level)
+	assert.Equal(t, "health check \"database\" is down", event.Message)
+	assert.Equal(t, "database", event.Tags["health.check"])
+	assert.Equal(t, []string{"health", "database"}, event.Fingerprint)
+	assert.Equal(t, []sentry.Exception{{Type: "*errors.errorString", Value: "connection refused"}}, event.Exception)
+	require.Len(t, event.Breadcrumbs, 2)
+	assert.Equal(t, "health check \"database\" changed from down to up", event.Breadcrumbs[0].Message)
+	assert.Equal(t, "health check \"database\" changed from up to down", event.Breadcrumbs[1].Message)
+}
+
+func TestInterceptorWithNegativeMaxBreadcrumbs(t *testing.T) {
+	// Arrange
+	hub := hubMock{}
+	reporter := NewReporter(&hub, WithMaxBreadcrumbs(-1))
+	interceptor := reporter.Interceptor()
+
+	// Act
+	interceptor(func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+		return health.CheckState{Status: health.StatusDown, Result: errors.New("connection refused")}
+	})(context.Background(), "database", health.CheckState{Status: health.StatusUp})
+
+	// Assert
+	require.Len(t, hub.events, 1)
+	assert.Empty(t, hub.events[0].Breadcrumbs)
+}
@@ -0,0 +1,93 @@
+// Package snmp provides an integration to send SNMP traps (https://datatracker.ietf.org/doc/html/rfc3416)
+// when the health status of a service changes.
+package snmp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/alexliesenfeld/health/notify"
+	"github.com/gosnmp/gosnmp"
+)
+
+type (
+	// TrapSender sends SNMP traps. It is implemented by *gosnmp.GoSNMP, which must be configured with
+	// the target address and either SNMP version 2c (community) or version 3 (security parameters)
+	// and be connected (see gosnmp.GoSNMP.Connect).
+	TrapSender interface {
+		SendTrap(trap gosnmp.SnmpTrap) (*gosnmp.SnmpPacket, error)
+	}
+
+	// Notifier is a notify.Notifier that sends an SNMPv2 trap whenever the status of the system or a component
+	// changes. Use it with notify.SystemTransitions and notify.ComponentTransitions.
+	// All object identifiers are located below a configurable enterprise OID (<enterprise>):
+	//   - <enterprise>.0.1: Notification that is sent when a component is down.
+	//   - <enterprise>.0.2: Notification that is sent when a component is up.
+	//   - <enterprise>.0.3: Notification that is sent when the status of a component is unknown.
+	//   - <enterprise>.1.1: The name of the component (OCTET STRING, empty for the aggregated system status).
+	//   - <enterprise>.1.2: The previous status (OCTET STRING).
+	//   - <enterprise>.1.3: The current status (OCTET STRING).
+	//   - <enterprise>.1.4: The check error message (OCTET STRING, empty if successful).
+	Notifier struct {
+		sender     TrapSender
+		enterprise string
+		startedAt  time.Time
+	}
+)
+
+// sysUpTimeOID and snmpTrapOID are the object identifiers of the mandatory variable bindings of SNMPv2 traps.
+const (
+	sysUpTimeOID = ".1.3.6.1.2.1.1.3.0"
+	snmpTrapOID  = ".1.3.6.1.6.3.1.1.4.1.0"
+)
+
+var _ TrapSender = (*gosnmp.GoSNMP)(nil)
+
+// NewNotifier creates a new Notifier that sends traps using the provided TrapSender (usually a *gosnmp.GoSNMP).
+// Argument 'enterpriseOID' is the object identifier of your organization or product (e.g., ".1.3.6.1.4.1.99999.1").
+func NewNotifier(sender TrapSender, enterpriseOID string) *Notifier {
+	return &Notifier{
+		sender:     sender,
+		enterprise: "." + strings.Trim(enterpriseOID, "."),
+		startedAt:  time.Now(),
+	}
+}
+
+// Notify implements notify.Notifier.
+func (n *Notifier) Notify(_ context.Context, event notify.Event) error {
+	errMsg := ""
+	if event.Error != nil {
+		errMsg = event.Error.Error()
+	}
+
+	trap := gosnmp.SnmpTrap{
+		Variables: []gosnmp.SnmpPDU{
+			{Name: sysUpTimeOID, Type: gosnmp.TimeTicks, Value: uint32(time.Since(n.startedAt) / (10 * time.Millisecond))},
+			{Name: snmpTrapOID, Type: gosnmp.ObjectIdentifier, Value: n.enterprise + ".0." + notificationID(event.To)},
+			{Name: n.enterprise + ".1.1", Type: gosnmp.OctetString, Value: event.Component},
+			{Name: n.enterprise + ".1.2", Type: gosnmp.OctetString, Value: string(event.From)},
+			{Name: n.enterprise + ".1.3", Type: gosnmp.OctetString, Value: string(event.To)},
+			{Name: n.enterprise + ".1.4", Type: gosnmp.OctetString, Value: errMsg},
+		},
+	}
+
+	if _, err := n.sender.SendTrap(trap); err != nil {
+		return fmt.Errorf("cannot send snmp trap: %w", err)
+	}
+
+	return nil
+}
+
+func notificationID(status health.AvailabilityStatus) string {
+	switch status {
+	case health.StatusDown:
+		return "1"
+	case health.StatusUp:
+		return "2"
+	default:
+		return "3"
+	}
+}
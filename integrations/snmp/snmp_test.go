@@ -0,0 +1,72 @@
+package snmp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/alexliesenfeld/health/notify"
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifierSendsTrap(t *testing.T) {
+	// Arrange
+	traps := make(chan *gosnmp.SnmpPacket, 1)
+	listener := gosnmp.NewTrapListener()
+	listener.Params = gosnmp.Default
+	listener.OnNewTrap = func(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+		traps <- packet
+	}
+	defer listener.Close()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := conn.LocalAddr().(*net.UDPAddr)
+	require.NoError(t, conn.Close())
+
+	go func() {
+		_ = listener.Listen(address.String())
+	}()
+	<-listener.Listening()
+
+	sender := &gosnmp.GoSNMP{
+		Target:    address.IP.String(),
+		Port:      uint16(address.Port),
+		Community: "public",
+		Version:   gosnmp.Version2c,
+		Timeout:   1 * time.Second,
+	}
+	require.NoError(t, sender.Connect())
+	defer sender.Conn.Close()
+
+	notifier := NewNotifier(sender, "1.3.6.1.4.1.99999.1")
+
+	// Act
+	err = notifier.Notify(context.Background(), notify.Event{
+		Component: "database",
+		From:      health.StatusUp,
+		To:        health.StatusDown,
+		Error:     fmt.Errorf("timeout"),
+	})
+
+	// Assert
+	require.NoError(t, err)
+	select {
+	case packet := <-traps:
+		values := map[string]interface{}{}
+		for _, v := range packet.Variables {
+			values[v.Name] = v.Value
+		}
+		assert.Equal(t, ".1.3.6.1.4.1.99999.1.0.1", values[snmpTrapOID])
+		assert.Equal(t, []byte("database"), values[".1.3.6.1.4.1.99999.1.1.1"])
+		assert.Equal(t, []byte("down"), values[".1.3.6.1.4.1.99999.1.1.3"])
+		assert.Equal(t, []byte("timeout"), values[".1.3.6.1.4.1.99999.1.1.4"])
+	case <-time.After(2 * time.Second):
+		assert.Fail(t, "no trap received")
+	}
+}
@@ -0,0 +1,144 @@
+// Package statsd provides an integration to report health check results to StatsD (https://github.com/statsd/statsd)
+// or DogStatsD (https://docs.datadoghq.com/developers/dogstatsd/).
+package statsd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/alexliesenfeld/health"
+)
+
+type (
+	// Reporter sends health check metrics and events to a StatsD or DogStatsD server via UDP.
+	Reporter struct {
+		conn      net.Conn
+		prefix    string
+		tags      []string
+		dogStatsD bool
+	}
+
+	// Option is a configuration option for a Reporter.
+	Option func(r *Reporter)
+)
+
+// WithPrefix sets a prefix that is prepended to all metric names (e.g., "myservice.").
+// Default is "health.".
+func WithPrefix(prefix string) Option {
+	return func(r *Reporter) {
+		r.prefix = prefix
+	}
+}
+
+// WithTags sets tags (e.g., "env:prod") that are added to all metrics and events. Tags are only supported
+// by DogStatsD (see WithDogStatsD) and ignored otherwise.
+func WithTags(tags ...string) Option {
+	return func(r *Reporter) {
+		r.tags = tags
+	}
+}
+
+// WithDogStatsD enables DogStatsD protocol extensions: check names are sent as tag "check" instead of being
+// part of the metric name, and status transitions are sent as DogStatsD events.
+func WithDogStatsD() Option {
+	return func(r *Reporter) {
+		r.dogStatsD = true
+	}
+}
+
+// New creates a new Reporter that sends metrics to the StatsD server at the provided address (e.g., "127.0.0.1:8125").
+func New(address string, options ...Option) (*Reporter, error) {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to statsd server: %w", err)
+	}
+
+	r := Reporter{conn: conn, prefix: "health."}
+	for _, opt := range options {
+		opt(&r)
+	}
+
+	return &r, nil
+}
+
+// Close closes the connection to the StatsD server.
+func (r *Reporter) Close() error {
+	return r.conn.Close()
+}
+
+// Interceptor returns a health.Interceptor that reports each check function execution. The following metrics
+// are sent on every execution (with the check name as tag "check" for DogStatsD, or as part of the metric name
+// for StatsD, e.g. "health.check.database.status"):
+//   - <prefix>check.status: A gauge of the availability status (1 means "up", 0 means "down" and -1 means "unknown").
+//   - <prefix>check.transitions: A counter that is incremented on every status transition.
+//
+// With DogStatsD, status transitions are additionally sent as events. Metrics are sent on a best effort basis:
+// errors are ignored, so that an unavailable StatsD server does not affect health checks.
+func (r *Reporter) Interceptor() health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			result := next(ctx, name, state)
+
+			r.send(r.metric(name, "status", fmt.Sprintf("%d|g", statusValue(result.Status))))
+
+			if state.Status != result.Status {
+				r.send(r.metric(name, "transitions", "1|c"))
+				if r.dogStatsD {
+					r.send(r.event(name, state.Status, result))
+				}
+			}
+
+			return result
+		}
+	}
+}
+
+func (r *Reporter) metric(check, name, value string) string {
+	if !r.dogStatsD {
+		return fmt.Sprintf("%scheck.%s.%s:%s", r.prefix, sanitize(check), name, value)
+	}
+	return fmt.Sprintf("%scheck.%s:%s%s", r.prefix, name, value, r.tagSuffix(check))
+}
+
+func (r *Reporter) event(check string, from health.AvailabilityStatus, state health.CheckState) string {
+	title := fmt.Sprintf("Health check %s changed status to %s", check, state.Status)
+	text := fmt.Sprintf("Status changed from %s to %s", from, state.Status)
+	if state.Result != nil {
+		text += ": " + state.Result.Error()
+	}
+	text = strings.ReplaceAll(text, "\n", "\\n")
+
+	alertType := "success"
+	if state.Status != health.StatusUp {
+		alertType = "error"
+	}
+
+	return fmt.Sprintf("_e{%d,%d}:%s|%s|t:%s%s", len(title), len(text), title, text, alertType, r.tagSuffix(check))
+}
+
+func (r *Reporter) tagSuffix(check string) string {
+	tags := append([]string{"check:" + sanitize(check)}, r.tags...)
+	return "|#" + strings.Join(tags, ",")
+}
+
+func (r *Reporter) send(msg string) {
+	_, _ = r.conn.Write([]byte(msg))
+}
+
+// sanitize replaces characters that have a special meaning in the StatsD protocol.
+func sanitize(name string) string {
+	return strings.NewReplacer(":", "_", "|", "_", "@", "_", "#", "_", ",", "_", " ", "_", "\n", "_").Replace(name)
+}
+
+func statusValue(status health.AvailabilityStatus) int {
+	switch status {
+	case health.StatusUp:
+		return 1
+	case health.StatusDown:
+		return 0
+	default:
+		return -1
+	}
+}
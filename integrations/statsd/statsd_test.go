@@ -0,0 +1,64 @@
+package statsd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doTestReporter(t *testing.T, options []Option, expectedPackets []string) {
+	// Arrange
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer server.Close()
+
+	reporter, err := New(server.LocalAddr().String(), options...)
+	require.NoError(t, err)
+	defer reporter.Close()
+
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(reporter.Interceptor()),
+		health.WithCheck(health.Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				return fmt.Errorf("connection refused")
+			},
+		}),
+	)
+
+	// Act
+	checker.Check(context.Background())
+
+	// Assert
+	packets := make([]string, 0)
+	buf := make([]byte, 1024)
+	for range expectedPackets {
+		require.NoError(t, server.SetReadDeadline(time.Now().Add(1*time.Second)))
+		n, _, err := server.ReadFrom(buf)
+		require.NoError(t, err)
+		packets = append(packets, string(buf[:n]))
+	}
+	assert.Equal(t, expectedPackets, packets)
+}
+
+func TestReporterStatsD(t *testing.T) {
+	doTestReporter(t, []Option{WithPrefix("svc.")}, []string{
+		"svc.check.database.status:0|g",
+		"svc.check.database.transitions:1|c",
+	})
+}
+
+func TestReporterDogStatsD(t *testing.T) {
+	doTestReporter(t, []Option{WithDogStatsD(), WithTags("env:test")}, []string{
+		"health.check.status:0|g|#check:database,env:test",
+		"health.check.transitions:1|c|#check:database,env:test",
+		"_e{44,55}:Health check database changed status to down|Status changed from unknown to down: connection refused|t:error|#check:database,env:test",
+	})
+}
@@ -0,0 +1,131 @@
+// Package zookeeper provides an integration to announce the availability of a service in
+// ZooKeeper (https://zookeeper.apache.org) by using an ephemeral znode.
+package zookeeper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/alexliesenfeld/health/internal/publish"
+	"github.com/go-zookeeper/zk"
+)
+
+type (
+	// Conn is the subset of the ZooKeeper client API that is used by the Publisher.
+	// It is implemented by *zk.Conn.
+	Conn interface {
+		Create(path string, data []byte, flags int32, acl []zk.ACL) (string, error)
+		Set(path string, data []byte, version int32) (*zk.Stat, error)
+		Delete(path string, version int32) error
+	}
+
+	// Publisher maintains an ephemeral znode that contains the health status of a service as long as the
+	// aggregated status is health.StatusUp. The znode is removed as soon as the status changes to any other
+	// status or when the Publisher is closed. Because the znode is ephemeral, ZooKeeper also removes it
+	// when the session of the client ends (e.g., because the process terminated unexpectedly).
+	Publisher struct {
+		conn  Conn
+		path  string
+		acl   []zk.ACL
+		mtx   sync.Mutex
+		queue *publish.Queue[health.CheckerState]
+	}
+
+	// Option is a configuration option for a Publisher.
+	Option func(p *Publisher)
+
+	// Status is the value that is written into the znode (encoded as JSON).
+	Status = publish.Status
+)
+
+// WithACL sets the ACL of the znode. Default is zk.WorldACL(zk.PermAll).
+func WithACL(acl []zk.ACL) Option {
+	return func(p *Publisher) {
+		p.acl = acl
+	}
+}
+
+// NewPublisher creates a new Publisher that maintains an ephemeral znode at the provided path.
+// The parent znode must already exist.
+func NewPublisher(conn Conn, path string, options ...Option) *Publisher {
+	p := Publisher{conn: conn, path: path, acl: zk.WorldACL(zk.PermAll)}
+	for _, opt := range options {
+		opt(&p)
+	}
+	p.queue = publish.NewQueue(p.publishQueued)
+	return &p
+}
+
+// StatusListener returns a status listener that creates, updates or removes the znode whenever the
+// aggregated health status changes. Use it with health.WithStatusListener. The listener only hands the
+// state over to a background goroutine that applies the states one after another; states that are
+// replaced by a newer one before the goroutine gets to them are dropped. Errors are logged using the
+// standard logger (see package log).
+// Attention: If the ZooKeeper session expires, ZooKeeper removes the znode and it is only created again
+// with the next status change.
+func (p *Publisher) StatusListener() func(ctx context.Context, state health.CheckerState) {
+	return func(_ context.Context, state health.CheckerState) {
+		p.queue.Push(state)
+	}
+}
+
+// publishQueued is called by the queue of the listener.
+func (p *Publisher) publishQueued(state health.CheckerState) {
+	if err := p.Publish(state); err != nil {
+		log.Printf("cannot publish health status to zookeeper: %v", err)
+	}
+}
+
+// wait blocks until all states that were reported to the status listener have been applied.
+func (p *Publisher) wait() {
+	_ = p.queue.Wait(context.Background())
+}
+
+// Publish writes the provided state into the znode if the aggregated status is health.StatusUp and
+// removes the znode otherwise.
+func (p *Publisher) Publish(state health.CheckerState) error {
+	if state.Status != health.StatusUp {
+		return p.remove()
+	}
+
+	data, err := json.Marshal(publish.NewStatus(state))
+	if err != nil {
+		return err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	_, err = p.conn.Create(p.path, data, zk.FlagEphemeral, p.acl)
+	if errors.Is(err, zk.ErrNodeExists) {
+		_, err = p.conn.Set(p.path, data, -1)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot write znode %q: %w", p.path, err)
+	}
+
+	return nil
+}
+
+// Close removes the znode. It is not an error if the znode does not exist. States that are still being
+// applied by the status listener are awaited first, so that they cannot recreate the znode.
+func (p *Publisher) Close() error {
+	p.wait()
+	return p.remove()
+}
+
+func (p *Publisher) remove() error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if err := p.conn.Delete(p.path, -1); err != nil && !errors.Is(err, zk.ErrNoNode) {
+		return fmt.Errorf("cannot remove znode %q: %w", p.path, err)
+	}
+
+	return nil
+}
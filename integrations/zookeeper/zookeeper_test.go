@@ -0,0 +1,115 @@
+package zookeeper
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/go-zookeeper/zk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Conn = (*zk.Conn)(nil)
+
+type connMock struct {
+	nodes   map[string][]byte
+	flags   map[string]int32
+	created int
+	started chan struct{}
+	release chan struct{}
+}
+
+func (c *connMock) Create(path string, data []byte, flags int32, acl []zk.ACL) (string, error) {
+	if c.release != nil {
+		c.started <- struct{}{}
+		<-c.release
+	}
+	c.created++
+	if _, ok := c.nodes[path]; ok {
+		return "", zk.ErrNodeExists
+	}
+	c.nodes[path] = data
+	c.flags[path] = flags
+	return path, nil
+}
+
+func (c *connMock) Set(path string, data []byte, version int32) (*zk.Stat, error) {
+	if _, ok := c.nodes[path]; !ok {
+		return nil, zk.ErrNoNode
+	}
+	c.nodes[path] = data
+	return &zk.Stat{}, nil
+}
+
+func (c *connMock) Delete(path string, version int32) error {
+	if _, ok := c.nodes[path]; !ok {
+		return zk.ErrNoNode
+	}
+	delete(c.nodes, path)
+	return nil
+}
+
+func TestPublisherMaintainsEphemeralNodeWhileUp(t *testing.T) {
+	// Arrange
+	conn := connMock{nodes: map[string][]byte{}, flags: map[string]int32{}}
+	publisher := NewPublisher(&conn, "/services/orders/instance-1")
+	listener := publisher.StatusListener()
+
+	// Act
+	listener(context.Background(), health.CheckerState{Status: health.StatusUp})
+	listener(context.Background(), health.CheckerState{
+		Status:     health.StatusUp,
+		CheckState: map[string]health.CheckState{"database": {Status: health.StatusUp}},
+	})
+	publisher.wait()
+
+	// Assert
+	require.Contains(t, conn.nodes, "/services/orders/instance-1")
+	assert.Equal(t, int32(zk.FlagEphemeral), conn.flags["/services/orders/instance-1"])
+
+	var status Status
+	require.NoError(t, json.Unmarshal(conn.nodes["/services/orders/instance-1"], &status))
+	assert.Equal(t, health.StatusUp, status.Status)
+	assert.Equal(t, map[string]health.AvailabilityStatus{"database": health.StatusUp}, status.Components)
+}
+
+func TestPublisherRemovesNodeWhenDown(t *testing.T) {
+	// Arrange
+	conn := connMock{nodes: map[string][]byte{}, flags: map[string]int32{}}
+	publisher := NewPublisher(&conn, "/services/orders/instance-1")
+
+	// Act
+	require.NoError(t, publisher.Publish(health.CheckerState{Status: health.StatusUp}))
+	require.NoError(t, publisher.Publish(health.CheckerState{Status: health.StatusDown}))
+	err := publisher.Close()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, conn.nodes)
+}
+
+func TestStatusListenerDoesNotWaitForZooKeeper(t *testing.T) {
+	// Arrange
+	conn := connMock{
+		nodes:   map[string][]byte{},
+		flags:   map[string]int32{},
+		started: make(chan struct{}, 10),
+		release: make(chan struct{}),
+	}
+	publisher := NewPublisher(&conn, "/services/orders/instance-1")
+	listener := publisher.StatusListener()
+
+	// Act
+	listener(context.Background(), health.CheckerState{Status: health.StatusUp})
+	<-conn.started
+	listener(context.Background(), health.CheckerState{Status: health.StatusUp})
+	listener(context.Background(), health.CheckerState{Status: health.StatusDown})
+	close(conn.release)
+	publisher.wait()
+
+	// Assert
+	assert.Equal(t, 1, conn.created)
+	assert.Empty(t, conn.nodes)
+}
@@ -0,0 +1,23 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+)
+
+// Cache is an interceptor that only executes the check function if the previous result is older than the
+// provided TTL and otherwise returns the previous check state unchanged. In contrast to health.WithCacheDuration,
+// this allows configuring the cache duration per check (when used with health.Check.Interceptors).
+// It also works for synchronous checks of checkers that use health.WithDisabledCache.
+func Cache(ttl time.Duration) health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			if !state.LastCheckedAt.IsZero() && time.Since(state.LastCheckedAt) < ttl {
+				return state
+			}
+			return next(ctx, name, state)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheSkipsExecutionWithinTTL(t *testing.T) {
+	// Arrange
+	calls := 0
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithCheck(health.Check{
+			Name:         "cached",
+			Interceptors: []health.Interceptor{Cache(1 * time.Minute)},
+			Check: func(ctx context.Context) error {
+				calls++
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	checker.Check(context.Background())
+	res := checker.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, health.StatusUp, res.Status)
+}
@@ -0,0 +1,57 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alexliesenfeld/health"
+)
+
+// ErrorClass maps errors that are returned by check functions to an availability status.
+type ErrorClass struct {
+	// Match returns true, if the error belongs to this class (see ErrorIs and ErrorAs).
+	Match func(err error) bool
+	// Status is the availability status of the check if the error belongs to this class.
+	Status health.AvailabilityStatus
+}
+
+// ErrorIs creates a matcher for ErrorClass.Match that matches errors using errors.Is.
+func ErrorIs(target error) func(err error) bool {
+	return func(err error) bool {
+		return errors.Is(err, target)
+	}
+}
+
+// ErrorAs creates a matcher for ErrorClass.Match that matches errors using errors.As
+// (e.g., ErrorAs[*net.DNSError]()).
+func ErrorAs[T error]() func(err error) bool {
+	return func(err error) bool {
+		var target T
+		return errors.As(err, &target)
+	}
+}
+
+// ClassifyErrors is an interceptor that rewrites the availability status of a failed check based on the
+// returned error. The first class that matches the error determines the status. If no class matches, the status
+// is left unchanged. This allows treating errors differently without modifying check functions, e.g., to
+// report a check as StatusDown immediately on authentication errors (independent of
+// health.Check.MaxContiguousFails), or to tolerate temporary DNS failures by reporting StatusUp.
+func ClassifyErrors(classes ...ErrorClass) health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			result := next(ctx, name, state)
+			if result.Result == nil {
+				return result
+			}
+
+			for _, class := range classes {
+				if class.Match(result.Result) {
+					result.Status = class.Status
+					break
+				}
+			}
+
+			return result
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+var errUnauthorized = errors.New("unauthorized")
+
+func doTestClassifyErrors(t *testing.T, err error, expectedStatus health.AvailabilityStatus) {
+	// Arrange
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(ClassifyErrors(
+			ErrorClass{Match: ErrorAs[*net.DNSError](), Status: health.StatusUp},
+			ErrorClass{Match: ErrorIs(errUnauthorized), Status: health.StatusDown},
+		)),
+		health.WithCheck(health.Check{
+			Name:               "check",
+			MaxContiguousFails: 3,
+			Check: func(ctx context.Context) error {
+				return err
+			},
+		}),
+	)
+
+	// Act
+	res := checker.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, expectedStatus, res.Status)
+}
+
+func TestClassifyErrorsMatchesErrorType(t *testing.T) {
+	doTestClassifyErrors(t, fmt.Errorf("lookup: %w", &net.DNSError{IsTimeout: true}), health.StatusUp)
+}
+
+func TestClassifyErrorsMatchesErrorValue(t *testing.T) {
+	doTestClassifyErrors(t, fmt.Errorf("login: %w", errUnauthorized), health.StatusDown)
+}
+
+func TestClassifyErrorsLeavesUnmatchedErrorsUnchanged(t *testing.T) {
+	doTestClassifyErrors(t, fmt.Errorf("other"), health.StatusUp)
+}
@@ -0,0 +1,46 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+)
+
+type budgetKey struct{}
+
+// AttemptTimeout is an interceptor that enforces a per-attempt timeout that is smaller than the check timeout
+// (see health.Check.Timeout). The check function receives a context that is cancelled after the provided timeout
+// or when the check deadline is reached, whichever comes first. The deadline of the check is stored in the
+// context, so that check functions can find out how much of the overall budget remains (see RemainingBudget).
+// Add AttemptTimeout after Retry (e.g., health.WithInterceptors(Retry(3, nil), AttemptTimeout(time.Second)))
+// to give each attempt its own timeout within the overall check deadline.
+func AttemptTimeout(timeout time.Duration) health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			if deadline, ok := ctx.Deadline(); ok {
+				ctx = context.WithValue(ctx, budgetKey{}, deadline)
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			return next(ctx, name, state)
+		}
+	}
+}
+
+// RemainingBudget returns the time that remains until the deadline of the check (see health.Check.Timeout).
+// If the check function is not executed with AttemptTimeout, the deadline of the provided context is used.
+// The second return value is false if there is no deadline.
+func RemainingBudget(ctx context.Context) (time.Duration, bool) {
+	if deadline, ok := ctx.Value(budgetKey{}).(time.Time); ok {
+		return time.Until(deadline), true
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,41 @@
+package interceptors
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttemptTimeoutWithRetry(t *testing.T) {
+	// Arrange
+	var calls, budget int64
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(Retry(3, nil), AttemptTimeout(20*time.Millisecond)),
+		health.WithCheck(health.Check{
+			Name:    "check",
+			Timeout: 1 * time.Second,
+			Check: func(ctx context.Context) error {
+				remaining, _ := RemainingBudget(ctx)
+				atomic.StoreInt64(&budget, int64(remaining))
+				if atomic.AddInt64(&calls, 1) < 3 {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	res := checker.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, health.StatusUp, res.Status)
+	assert.Equal(t, int64(3), atomic.LoadInt64(&calls))
+	assert.Greater(t, time.Duration(atomic.LoadInt64(&budget)), 500*time.Millisecond)
+}
@@ -0,0 +1,57 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+)
+
+// CheckError is an error that is returned by WrapErrors. It adds machine-readable metadata to the error
+// that was returned by a check function.
+type CheckError struct {
+	// Check is the name of the check.
+	Check string
+	// Attempt is the number of the attempt that failed (see Retry). It is 1 if the check is not retried.
+	Attempt int
+	// Elapsed is the duration of the failed check function execution.
+	Elapsed time.Duration
+	// Err is the error that was returned by the check function.
+	Err error
+}
+
+// Error returns the error message in the form "[check=<name> attempt=<n> elapsed=<duration>] <error>",
+// so that the metadata can be parsed from logs and JSON responses.
+func (e *CheckError) Error() string {
+	return fmt.Sprintf("[check=%s attempt=%d elapsed=%s] %v", e.Check, e.Attempt, e.Elapsed, e.Err)
+}
+
+// Unwrap returns the error that was returned by the check function.
+func (e *CheckError) Unwrap() error {
+	return e.Err
+}
+
+// WrapErrors is an interceptor that wraps errors of failed checks into a CheckError, so that all failures contain
+// the same, parseable metadata (the check name, the attempt number and the elapsed time). The original error can
+// still be inspected using errors.Is and errors.As. If checks are retried, add WrapErrors after Retry
+// (e.g., health.WithInterceptors(Retry(3, nil), WrapErrors())), so that the attempt number is available.
+func WrapErrors() health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			now := time.Now()
+			result := next(ctx, name, state)
+
+			if result.Result != nil {
+				result.Result = &CheckError{
+					Check:   name,
+					Attempt: AttemptFromContext(ctx),
+					Elapsed: time.Since(now),
+					Err:     result.Result,
+				}
+			}
+
+			return result
+		}
+	}
+}
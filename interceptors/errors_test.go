@@ -0,0 +1,38 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapErrorsAddsCheckMetadata(t *testing.T) {
+	// Arrange
+	checkErr := fmt.Errorf("connection refused")
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(Retry(2, nil), WrapErrors()),
+		health.WithCheck(health.Check{
+			Name: "db",
+			Check: func(ctx context.Context) error {
+				return checkErr
+			},
+		}),
+	)
+
+	// Act
+	res := checker.Check(context.Background())
+
+	// Assert
+	var target *CheckError
+	require.True(t, errors.As(res.Details["db"].Error, &target))
+	assert.Equal(t, "db", target.Check)
+	assert.Equal(t, 2, target.Attempt)
+	assert.ErrorIs(t, res.Details["db"].Error, checkErr)
+	assert.Regexp(t, `^\[check=db attempt=2 elapsed=.+\] connection refused$`, res.Details["db"].Error.Error())
+}
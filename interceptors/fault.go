@@ -0,0 +1,115 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+)
+
+type (
+	// FaultConfig configures the faults that are injected by a FaultInjection.
+	FaultConfig struct {
+		// FailureRate is the fraction of check executions (between 0 and 1) that will fail with Error.
+		FailureRate float64
+		// Error is the error that is returned by failing check executions. Default is ErrInjectedFault.
+		Error error
+		// LatencyRate is the fraction of check executions (between 0 and 1) that will be delayed by Latency.
+		LatencyRate float64
+		// Latency is the artificial delay that is added to delayed check executions. Delays respect the check
+		// timeout (see health.Check.Timeout), so a Latency above the timeout results in a timeout error.
+		Latency time.Duration
+		// PanicRate is the fraction of check executions (between 0 and 1) that will panic.
+		PanicRate float64
+	}
+
+	// FaultInjection injects faults (errors, latency and panics) into check function executions.
+	// Faults are injected into the check function itself, so the results are still subject to panic recovery,
+	// timeouts and status evaluation (see health.Check.MaxContiguousFails and health.Check.MaxTimeInError).
+	// This allows verifying the configuration of checks and status listeners under failure.
+	// Fault injection can be enabled and disabled at runtime.
+	// Attention: FaultInjection is meant for resilience testing and should not be enabled in production.
+	FaultInjection struct {
+		mtx     sync.RWMutex
+		config  FaultConfig
+		enabled bool
+	}
+)
+
+// ErrInjectedFault is the default error that is returned by check executions that fail due to FaultInjection.
+var ErrInjectedFault = errors.New("injected fault")
+
+// NewFaultInjection creates a new FaultInjection with the provided configuration. Fault injection is enabled
+// initially.
+func NewFaultInjection(config FaultConfig) *FaultInjection {
+	return &FaultInjection{config: config, enabled: true}
+}
+
+// Enable enables fault injection.
+func (f *FaultInjection) Enable() {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.enabled = true
+}
+
+// Disable disables fault injection. Check functions will be executed without any injected faults.
+func (f *FaultInjection) Disable() {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.enabled = false
+}
+
+// IsEnabled returns true, if fault injection is currently enabled.
+func (f *FaultInjection) IsEnabled() bool {
+	f.mtx.RLock()
+	defer f.mtx.RUnlock()
+	return f.enabled
+}
+
+// SetConfig replaces the fault configuration at runtime.
+func (f *FaultInjection) SetConfig(config FaultConfig) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.config = config
+}
+
+// Interceptor returns an interceptor that injects faults into check function executions.
+func (f *FaultInjection) Interceptor() health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			f.mtx.RLock()
+			config, enabled := f.config, f.enabled
+			f.mtx.RUnlock()
+
+			if !enabled {
+				return next(ctx, name, state)
+			}
+
+			ctx = health.WrapCheckFunc(ctx, func(check func(ctx context.Context) error) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if config.LatencyRate > 0 && rand.Float64() < config.LatencyRate && !sleep(ctx, config.Latency) {
+						return ctx.Err()
+					}
+
+					if config.PanicRate > 0 && rand.Float64() < config.PanicRate {
+						panic("injected panic in check " + name)
+					}
+
+					if config.FailureRate > 0 && rand.Float64() < config.FailureRate {
+						if config.Error != nil {
+							return config.Error
+						}
+						return ErrInjectedFault
+					}
+
+					return check(ctx)
+				}
+			})
+
+			return next(ctx, name, state)
+		}
+	}
+}
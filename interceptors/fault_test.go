@@ -0,0 +1,53 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doTestFaultInjection(t *testing.T, config FaultConfig, enabled bool, expectedStatus health.AvailabilityStatus, expectedErr string) {
+	// Arrange
+	faults := NewFaultInjection(config)
+	if !enabled {
+		faults.Disable()
+	}
+
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(faults.Interceptor()),
+		health.WithCheck(health.Check{
+			Name: "check",
+			Check: func(ctx context.Context) error {
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	res := checker.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, expectedStatus, res.Status)
+	if expectedErr == "" {
+		assert.Nil(t, res.Details["check"].Error)
+	} else {
+		require.NotNil(t, res.Details["check"].Error)
+		assert.Equal(t, expectedErr, res.Details["check"].Error.Error())
+	}
+}
+
+func TestFaultInjectionFailure(t *testing.T) {
+	doTestFaultInjection(t, FaultConfig{FailureRate: 1}, true, health.StatusDown, ErrInjectedFault.Error())
+}
+
+func TestFaultInjectionPanicIsRecovered(t *testing.T) {
+	doTestFaultInjection(t, FaultConfig{PanicRate: 1}, true, health.StatusDown, "injected panic in check check")
+}
+
+func TestFaultInjectionDisabled(t *testing.T) {
+	doTestFaultInjection(t, FaultConfig{FailureRate: 1}, false, health.StatusUp, "")
+}
@@ -8,6 +8,7 @@ import (
 )
 
 // BasicLogger is a basic logger that is mostly used to showcase this library.
+// For structured logging in production, consider using Slog instead.
 func BasicLogger() health.Interceptor {
 	return func(next health.InterceptorFunc) health.InterceptorFunc {
 		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
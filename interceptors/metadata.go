@@ -0,0 +1,45 @@
+package interceptors
+
+import (
+	"context"
+
+	"github.com/alexliesenfeld/health"
+)
+
+type (
+	checkNameKey struct{}
+	attemptKey   struct{}
+)
+
+// EnrichContext is an interceptor that stores the name of the check in the context that is passed to the check
+// function (see CheckNameFromContext). Together with the labels of the check (see health.Check.Labels and
+// CheckLabelsFromContext), this allows code that is called by check functions (such as HTTP clients or
+// database drivers) to tag its own telemetry.
+func EnrichContext() health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			return next(context.WithValue(ctx, checkNameKey{}, name), name, state)
+		}
+	}
+}
+
+// CheckNameFromContext returns the name of the check that was stored in the context by EnrichContext.
+func CheckNameFromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(checkNameKey{}).(string)
+	return name, ok
+}
+
+// CheckLabelsFromContext returns the labels of the check (see health.Check.Labels). It is a shorthand for
+// health.CheckLabels and does not require EnrichContext.
+func CheckLabelsFromContext(ctx context.Context) map[string]string {
+	return health.CheckLabels(ctx)
+}
+
+// AttemptFromContext returns the number of the current attempt that was stored in the context by Retry.
+// If the check is not retried, 1 is returned.
+func AttemptFromContext(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptKey{}).(int); ok {
+		return attempt
+	}
+	return 1
+}
@@ -0,0 +1,44 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnrichContextProvidesCheckMetadata(t *testing.T) {
+	// Arrange
+	var (
+		name     string
+		labels   map[string]string
+		attempts []int
+	)
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(
+			EnrichContext(),
+			Retry(2, nil),
+		),
+		health.WithCheck(health.Check{
+			Name:   "check",
+			Labels: map[string]string{"team": "payments"},
+			Check: func(ctx context.Context) error {
+				name, _ = CheckNameFromContext(ctx)
+				labels = CheckLabelsFromContext(ctx)
+				attempts = append(attempts, AttemptFromContext(ctx))
+				return fmt.Errorf("check error")
+			},
+		}),
+	)
+
+	// Act
+	checker.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, "check", name)
+	assert.Equal(t, map[string]string{"team": "payments"}, labels)
+	assert.Equal(t, []int{1, 2}, attempts)
+}
@@ -0,0 +1,77 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+)
+
+// Backoff calculates the duration to wait before the next attempt of a check function execution.
+// Argument 'attempt' is the number of the attempt that has just failed (starting at 1).
+type Backoff func(attempt uint) time.Duration
+
+// ConstantBackoff creates a Backoff that always waits for the provided duration.
+func ConstantBackoff(duration time.Duration) Backoff {
+	return func(attempt uint) time.Duration {
+		return duration
+	}
+}
+
+// ExponentialBackoff creates a Backoff that doubles the wait duration after each failed attempt,
+// starting at 'initial' and never exceeding 'maxDuration'.
+func ExponentialBackoff(initial, maxDuration time.Duration) Backoff {
+	return func(attempt uint) time.Duration {
+		duration := initial
+		for i := uint(1); i < attempt && duration < maxDuration; i++ {
+			duration *= 2
+		}
+		if duration > maxDuration {
+			return maxDuration
+		}
+		return duration
+	}
+}
+
+// Retry is an interceptor that re-invokes the check function up to 'attempts' times in total until it succeeds.
+// Between attempts, it waits for the duration calculated by 'backoff' (may be nil for no wait time).
+// All attempts share the check's deadline (see Check.Timeout): no new attempt is started once the context
+// is done, in which case the result of the last attempt is returned. Each attempt is evaluated against the
+// same previous check state, so failed attempts that are followed by a successful one do not count as
+// contiguous fails (see Check.MaxContiguousFails).
+//
+// Panics are converted into errors before they reach this interceptor and are therefore retried like any other
+// error, unless Check.DisablePanicRecovery is set, in which case the panic is not intercepted.
+// The number of the current attempt is stored in the context (see AttemptFromContext).
+func Retry(attempts uint, backoff Backoff) health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			result := next(context.WithValue(ctx, attemptKey{}, 1), name, state)
+
+			for attempt := uint(1); attempt < attempts && result.Result != nil; attempt++ {
+				if backoff != nil && !sleep(ctx, backoff(attempt)) {
+					break
+				}
+				if ctx.Err() != nil {
+					break
+				}
+				result = next(context.WithValue(ctx, attemptKey{}, int(attempt)+1), name, state)
+			}
+
+			return result
+		}
+	}
+}
+
+// sleep waits for the provided duration. It returns false if the context is done before the duration has passed.
+func sleep(ctx context.Context, duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,69 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetrySucceedsAfterFailedAttempts(t *testing.T) {
+	// Arrange
+	calls := 0
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(Retry(3, ConstantBackoff(time.Millisecond))),
+		health.WithCheck(health.Check{
+			Name: "check",
+			Check: func(ctx context.Context) error {
+				calls++
+				if calls < 3 {
+					return fmt.Errorf("check error")
+				}
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	res := checker.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, health.StatusUp, res.Status)
+}
+
+func TestRetryStopsWhenDeadlineExceeded(t *testing.T) {
+	// Arrange
+	calls := 0
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(Retry(10, ConstantBackoff(50*time.Millisecond))),
+		health.WithCheck(health.Check{
+			Name:    "check",
+			Timeout: 75 * time.Millisecond,
+			Check: func(ctx context.Context) error {
+				calls++
+				return fmt.Errorf("check error")
+			},
+		}),
+	)
+
+	// Act
+	res := checker.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, health.StatusDown, res.Status)
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(10*time.Millisecond, 50*time.Millisecond)
+	assert.Equal(t, 10*time.Millisecond, backoff(1))
+	assert.Equal(t, 20*time.Millisecond, backoff(2))
+	assert.Equal(t, 40*time.Millisecond, backoff(3))
+	assert.Equal(t, 50*time.Millisecond, backoff(4))
+}
@@ -0,0 +1,35 @@
+package interceptors
+
+import (
+	"context"
+	"sync"
+
+	"github.com/alexliesenfeld/health"
+)
+
+// Sample is an interceptor that only executes the check function on every n-th invocation and otherwise
+// returns the previous check state unchanged. The first invocation of a check is always executed.
+// This is useful for expensive synchronous checks that cannot easily be converted into periodic checks.
+// Invocations are counted per check, so the same interceptor can be used for multiple checks
+// (e.g., with health.WithInterceptors).
+func Sample(n uint) health.Interceptor {
+	var (
+		mtx    sync.Mutex
+		counts = map[string]uint{}
+	)
+
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			mtx.Lock()
+			count := counts[name]
+			counts[name] = count + 1
+			mtx.Unlock()
+
+			if n > 1 && count%n != 0 && !state.LastCheckedAt.IsZero() {
+				return state
+			}
+
+			return next(ctx, name, state)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampleExecutesEveryNthInvocation(t *testing.T) {
+	// Arrange
+	calls := 0
+	interceptor := Sample(3)(func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+		calls++
+		state.LastCheckedAt = time.Now()
+		return state
+	})
+
+	// Act
+	state := health.CheckState{}
+	for i := 0; i < 7; i++ {
+		state = interceptor(context.Background(), "check", state)
+	}
+
+	// Assert
+	assert.Equal(t, 3, calls)
+}
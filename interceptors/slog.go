@@ -0,0 +1,42 @@
+//go:build go1.21
+
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+)
+
+// Slog is an interceptor that logs each check function execution and every status transition of a component
+// to the provided slog.Logger at the provided level. Log records contain the attributes "check" (the check name),
+// "status" (the resulting availability status), "duration" (the execution duration) and "error" (the check error,
+// if any). Status transition records additionally contain the attribute "previous_status".
+func Slog(logger *slog.Logger, level slog.Level) health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			now := time.Now()
+			result := next(ctx, name, state)
+
+			attrs := []slog.Attr{
+				slog.String("check", name),
+				slog.String("status", string(result.Status)),
+				slog.Duration("duration", time.Since(now)),
+			}
+			if result.Result != nil {
+				attrs = append(attrs, slog.String("error", result.Result.Error()))
+			}
+
+			logger.LogAttrs(ctx, level, "executed health check function", attrs...)
+
+			if state.Status != result.Status {
+				attrs = append(attrs, slog.String("previous_status", string(state.Status)))
+				logger.LogAttrs(ctx, level, "health check status changed", attrs...)
+			}
+
+			return result
+		}
+	}
+}
@@ -0,0 +1,46 @@
+//go:build go1.21
+
+package interceptors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlogLogsExecutionAndTransition(t *testing.T) {
+	// Arrange
+	buf := bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	interceptor := Slog(logger, slog.LevelInfo)(func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+		state.Status = health.StatusDown
+		state.Result = fmt.Errorf("check error")
+		return state
+	})
+
+	// Act
+	interceptor(context.Background(), "check", health.CheckState{Status: health.StatusUp})
+
+	// Assert
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var execution, transition map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &execution))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &transition))
+
+	assert.Equal(t, "check", execution["check"])
+	assert.Equal(t, "down", execution["status"])
+	assert.Equal(t, "check error", execution["error"])
+	assert.Equal(t, "up", transition["previous_status"])
+	assert.Equal(t, "down", transition["status"])
+}
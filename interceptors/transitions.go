@@ -0,0 +1,107 @@
+package interceptors
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+)
+
+type (
+	// Transition describes a change of the availability status of a check.
+	Transition struct {
+		// Check is the name of the check.
+		Check string
+		// From is the availability status before the transition.
+		From health.AvailabilityStatus
+		// To is the availability status after the transition.
+		To health.AvailabilityStatus
+		// Timestamp is the time of when the transition was detected.
+		Timestamp time.Time
+		// Error is the error that was returned by the check function (nil if successful).
+		Error error
+	}
+
+	// TransitionSink receives status transitions that are recorded by RecordTransitions
+	// (e.g., to store them in memory, a file or a database).
+	TransitionSink interface {
+		Record(ctx context.Context, transition Transition)
+	}
+
+	// TransitionSinkFunc is an adapter that allows using an ordinary function as a TransitionSink.
+	TransitionSinkFunc func(ctx context.Context, transition Transition)
+
+	// TransitionRing is a TransitionSink that keeps the most recent transitions in memory.
+	TransitionRing struct {
+		mtx         sync.Mutex
+		transitions []Transition
+		next        int
+		full        bool
+	}
+)
+
+// Record calls f(ctx, transition).
+func (f TransitionSinkFunc) Record(ctx context.Context, transition Transition) {
+	f(ctx, transition)
+}
+
+// NewTransitionRing creates a new TransitionRing that keeps up to 'size' of the most recent transitions.
+// A negative size is treated as zero.
+func NewTransitionRing(size int) *TransitionRing {
+	if size < 0 {
+		size = 0
+	}
+	return &TransitionRing{transitions: make([]Transition, size)}
+}
+
+// Record adds a transition to the ring. If the ring is full, the oldest transition is overwritten.
+func (r *TransitionRing) Record(_ context.Context, transition Transition) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if len(r.transitions) == 0 {
+		return
+	}
+
+	r.transitions[r.next] = transition
+	r.next = (r.next + 1) % len(r.transitions)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// Transitions returns the recorded transitions, ordered from oldest to newest.
+func (r *TransitionRing) Transitions() []Transition {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if !r.full {
+		return append([]Transition(nil), r.transitions[:r.next]...)
+	}
+
+	return append(append([]Transition(nil), r.transitions[r.next:]...), r.transitions[:r.next]...)
+}
+
+// RecordTransitions is an interceptor that detects availability status changes of checks and records them in
+// the provided TransitionSink. In contrast to health.Check.StatusListener, this works for all checks of a
+// checker (when used with health.WithInterceptors) and reports the status before the transition as well.
+func RecordTransitions(sink TransitionSink) health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			result := next(ctx, name, state)
+
+			if state.Status != result.Status {
+				sink.Record(ctx, Transition{
+					Check:     name,
+					From:      state.Status,
+					To:        result.Status,
+					Timestamp: time.Now().UTC(),
+					Error:     result.Result,
+				})
+			}
+
+			return result
+		}
+	}
+}
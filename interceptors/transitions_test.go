@@ -0,0 +1,70 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordTransitionsRecordsStatusChanges(t *testing.T) {
+	// Arrange
+	var err error
+	ring := NewTransitionRing(10)
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithInterceptors(RecordTransitions(ring)),
+		health.WithCheck(health.Check{
+			Name: "check",
+			Check: func(ctx context.Context) error {
+				return err
+			},
+		}),
+	)
+
+	// Act
+	checker.Check(context.Background())
+	checker.Check(context.Background())
+	err = fmt.Errorf("check error")
+	checker.Check(context.Background())
+
+	// Assert
+	transitions := ring.Transitions()
+	require.Len(t, transitions, 2)
+	assert.Equal(t, health.StatusUnknown, transitions[0].From)
+	assert.Equal(t, health.StatusUp, transitions[0].To)
+	assert.Equal(t, health.StatusUp, transitions[1].From)
+	assert.Equal(t, health.StatusDown, transitions[1].To)
+	assert.Equal(t, err, transitions[1].Error)
+}
+
+func TestTransitionRingKeepsMostRecentTransitions(t *testing.T) {
+	// Arrange
+	ring := NewTransitionRing(2)
+
+	// Act
+	for _, name := range []string{"a", "b", "c"} {
+		ring.Record(context.Background(), Transition{Check: name})
+	}
+
+	// Assert
+	transitions := ring.Transitions()
+	require.Len(t, transitions, 2)
+	assert.Equal(t, "b", transitions[0].Check)
+	assert.Equal(t, "c", transitions[1].Check)
+}
+
+func TestTransitionRingWithNegativeSize(t *testing.T) {
+	// Arrange
+	ring := NewTransitionRing(-1)
+
+	// Act
+	ring.Record(context.Background(), Transition{Check: "a"})
+
+	// Assert
+	assert.Empty(t, ring.Transitions())
+}
@@ -0,0 +1,48 @@
+// Package netutil provides helpers for health checks that communicate over network connections.
+package netutil
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+)
+
+// Deadliner is implemented by connections that support deadlines (such as net.Conn and net.PacketConn).
+type Deadliner interface {
+	SetDeadline(t time.Time) error
+}
+
+// AfterDone calls f in its own goroutine as soon as ctx is done. The returned stop function must be called
+// once f is not required anymore, so that the goroutine terminates. f may still be called if ctx is done
+// while stop is being called, so f must tolerate being called after the caller has finished.
+func AfterDone(ctx context.Context, f func()) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			f()
+		case <-done:
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// InterruptOnDone interrupts pending reads and writes on conn as soon as ctx is done, by setting the deadline
+// of conn to the current time (see AfterDone). Unlike a deadline on the connection, this also covers
+// cancellation and allows callers to report ctx.Err() as the cause of the resulting I/O timeout.
+func InterruptOnDone(ctx context.Context, conn Deadliner) (stop func()) {
+	return AfterDone(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+}
+
+// WithServerName returns a copy of tlsConfig that verifies the certificate of the server against host,
+// unless tlsConfig already specifies a server name.
+func WithServerName(tlsConfig *tls.Config, host string) *tls.Config {
+	tlsConfig = tlsConfig.Clone()
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = host
+	}
+	return tlsConfig
+}
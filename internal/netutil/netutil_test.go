@@ -0,0 +1,44 @@
+package netutil
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterruptOnDone(t *testing.T) {
+	// Arrange
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Act
+	stop := InterruptOnDone(ctx, client)
+	defer stop()
+	time.AfterFunc(10*time.Millisecond, cancel)
+	_, err := client.Read(make([]byte, 1))
+
+	// Assert
+	assert.True(t, errors.Is(err, os.ErrDeadlineExceeded))
+}
+
+func TestWithServerName(t *testing.T) {
+	// Arrange
+	original := &tls.Config{}
+
+	// Act
+	withHost := WithServerName(original, "example.com")
+	withExplicitName := WithServerName(&tls.Config{ServerName: "other.example.com"}, "example.com")
+
+	// Assert
+	assert.Equal(t, "example.com", withHost.ServerName)
+	assert.Empty(t, original.ServerName)
+	assert.Equal(t, "other.example.com", withExplicitName.ServerName)
+}
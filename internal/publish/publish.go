@@ -0,0 +1,99 @@
+// Package publish provides building blocks for integrations that publish the health status of a service
+// to external systems (such as service registries).
+package publish
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+)
+
+type (
+	// Status is the health status of a service as it is written into external systems (encoded as JSON).
+	Status struct {
+		// Status is the aggregated availability status of the service.
+		Status health.AvailabilityStatus `json:"status"`
+		// Components holds the availability status of each component.
+		Components map[string]health.AvailabilityStatus `json:"components,omitempty"`
+		// Timestamp is the time of when the status has changed.
+		Timestamp time.Time `json:"timestamp"`
+	}
+
+	// Queue sends values one after another in a background goroutine, so that status listeners do not block
+	// the health.Checker. If a new value is pushed while a value is being sent, only the latest value is
+	// sent afterwards, because intermediate values are outdated anyway.
+	Queue[T any] struct {
+		send    func(value T)
+		mtx     sync.Mutex
+		pending *T
+		done    chan struct{}
+	}
+)
+
+// NewStatus creates a Status from the provided state.
+func NewStatus(state health.CheckerState) Status {
+	status := Status{Status: state.Status, Timestamp: time.Now().UTC()}
+	if len(state.CheckState) > 0 {
+		status.Components = make(map[string]health.AvailabilityStatus, len(state.CheckState))
+		for name, checkState := range state.CheckState {
+			status.Components[name] = checkState.Status
+		}
+	}
+	return status
+}
+
+// NewQueue creates a new Queue that sends values using the provided function.
+func NewQueue[T any](send func(value T)) *Queue[T] {
+	return &Queue[T]{send: send}
+}
+
+// Push schedules the value to be sent and returns immediately.
+func (q *Queue[T]) Push(value T) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	q.pending = &value
+	if q.done == nil {
+		q.done = make(chan struct{})
+		go q.sendPending(q.done)
+	}
+}
+
+// Wait blocks until all pushed values have been sent or the context is done.
+func (q *Queue[T]) Wait(ctx context.Context) error {
+	q.mtx.Lock()
+	done := q.done
+	q.mtx.Unlock()
+
+	if done == nil {
+		return nil
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// sendPending sends the pending value until no newer value has been pushed in the meantime.
+func (q *Queue[T]) sendPending(done chan struct{}) {
+	defer close(done)
+
+	for {
+		q.mtx.Lock()
+		value := q.pending
+		q.pending = nil
+		if value == nil {
+			q.done = nil
+			q.mtx.Unlock()
+			return
+		}
+		q.mtx.Unlock()
+
+		q.send(*value)
+	}
+}
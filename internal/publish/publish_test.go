@@ -0,0 +1,42 @@
+package publish
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueueSendsOnlyLatestPendingValue(t *testing.T) {
+	// Arrange
+	var (
+		mtx     sync.Mutex
+		sent    []int
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	queue := NewQueue(func(value int) {
+		if value == 1 {
+			close(started)
+			<-release
+		}
+		mtx.Lock()
+		defer mtx.Unlock()
+		sent = append(sent, value)
+	})
+
+	// Act
+	queue.Push(1)
+	<-started
+	queue.Push(2)
+	queue.Push(3)
+	close(release)
+	err := queue.Wait(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	mtx.Lock()
+	defer mtx.Unlock()
+	assert.Equal(t, []int{1, 3}, sent)
+}
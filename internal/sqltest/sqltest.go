@@ -0,0 +1,96 @@
+// Package sqltest provides a database/sql driver for tests that answers statements with predefined results.
+package sqltest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+)
+
+type (
+	// Result is the predefined result of a statement.
+	Result struct {
+		// Columns are the column names of the result.
+		Columns []string
+		// Rows are the rows of the result.
+		Rows [][]driver.Value
+		// Err is returned instead of a result, if set.
+		Err error
+	}
+
+	// Config configures the behaviour of a database that is opened with Open.
+	Config struct {
+		// PingErr is returned for all pings.
+		PingErr error
+		// Results holds the predefined results by statement. Statements that are not contained
+		// fail with an error.
+		Results map[string]Result
+	}
+
+	connector struct{ cfg *Config }
+	conn      struct{ cfg *Config }
+	stmt      struct {
+		cfg   *Config
+		query string
+	}
+	rows struct {
+		result Result
+		next   int
+	}
+)
+
+// Open opens a database that answers statements according to the provided configuration.
+func Open(cfg Config) *sql.DB {
+	return sql.OpenDB(&connector{cfg: &cfg})
+}
+
+func (c *connector) Connect(context.Context) (driver.Conn, error) { return &conn{cfg: c.cfg}, nil }
+func (c *connector) Driver() driver.Driver                        { return c }
+func (c *connector) Open(string) (driver.Conn, error)             { return &conn{cfg: c.cfg}, nil }
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{cfg: c.cfg, query: query}, nil
+}
+func (c *conn) Close() error               { return nil }
+func (c *conn) Begin() (driver.Tx, error)  { return nil, fmt.Errorf("transactions are not supported") }
+func (c *conn) Ping(context.Context) error { return c.cfg.PingErr }
+
+func (s *stmt) Close() error  { return nil }
+func (s *stmt) NumInput() int { return -1 }
+
+func (s *stmt) Exec([]driver.Value) (driver.Result, error) {
+	if _, err := s.result(); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (s *stmt) Query([]driver.Value) (driver.Rows, error) {
+	result, err := s.result()
+	if err != nil {
+		return nil, err
+	}
+	return &rows{result: result}, nil
+}
+
+func (s *stmt) result() (Result, error) {
+	result, ok := s.cfg.Results[s.query]
+	if !ok {
+		return Result{}, fmt.Errorf("unexpected statement: %s", s.query)
+	}
+	return result, result.Err
+}
+
+func (r *rows) Columns() []string { return r.result.Columns }
+func (r *rows) Close() error      { return nil }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.next == len(r.result.Rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.Rows[r.next])
+	r.next++
+	return nil
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"github.com/alexliesenfeld/health"
+	"net/http"
+)
+
+// DetailLevel defines how much information about a health check result is exposed to a client.
+type DetailLevel int
+
+const (
+	// DetailLevelNone only exposes the aggregated availability status.
+	DetailLevelNone DetailLevel = iota
+	// DetailLevelSummary exposes the aggregated availability status and the status of each component,
+	// but no error messages or additional information (see health.CheckerResult.Info).
+	DetailLevelSummary
+	// DetailLevelFull exposes all available information.
+	DetailLevelFull
+)
+
+// APIKeyHeader is the name of the HTTP request header that the APIKey middleware reads the API key from.
+const APIKeyHeader = "X-Api-Key"
+
+// APIKey is a middleware that reduces the check details that are exposed in the HTTP response based on an API key
+// that is sent in the request header "X-Api-Key" (see APIKeyHeader). The argument 'keys' maps each accepted API key
+// to the DetailLevel that will be granted to clients presenting it. Requests that do not contain a known API key
+// will only receive the aggregated availability status (see DetailLevelNone).
+//
+// This is useful to provide tiered access to health information, such as full details for internal tooling
+// but only a summary for external uptime monitors.
+func APIKey(keys map[string]DetailLevel) health.Middleware {
+	return func(next health.MiddlewareFunc) health.MiddlewareFunc {
+		return func(r *http.Request) health.CheckerResult {
+			varyOnCredentials(r)
+			level := apiKeyDetailLevel(keys, r.Header.Get(APIKeyHeader))
+			result := next(r)
+			reduceDetails(&result, level)
+			return result
+		}
+	}
+}
+
+func apiKeyDetailLevel(keys map[string]DetailLevel, requestKey string) DetailLevel {
+	level := DetailLevelNone
+	if requestKey == "" {
+		return level
+	}
+
+	// All keys are compared in constant time to not reveal valid keys through response timing.
+	for key, keyLevel := range keys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(requestKey)) == 1 {
+			level = keyLevel
+		}
+	}
+
+	return level
+}
+
+func reduceDetails(result *health.CheckerResult, level DetailLevel) {
+	switch level {
+	case DetailLevelFull:
+		return
+	case DetailLevelSummary:
+		result.Info = nil
+		result.Details = withoutErrors(result.Details)
+	default:
+		result.Info = nil
+		result.Details = nil
+	}
+}
+
+func withoutErrors(details map[string]health.CheckResult) map[string]health.CheckResult {
+	if details == nil {
+		return nil
+	}
+
+	// We create a new map to not modify a map that might be shared with the health.Checker.
+	target := make(map[string]health.CheckResult, len(details))
+	for name, checkResult := range details {
+		checkResult.Error = nil
+		target[name] = checkResult
+	}
+
+	return target
+}
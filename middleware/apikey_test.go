@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func doTestAPIKey(t *testing.T, key string, expectDetails, expectErrors bool) {
+	// Arrange
+	r := httptest.NewRequest(http.MethodGet, "/health", nil)
+	r.Header.Set(APIKeyHeader, key)
+
+	handler := APIKey(map[string]DetailLevel{
+		"internal": DetailLevelFull,
+		"external": DetailLevelSummary,
+	})(func(r *http.Request) health.CheckerResult {
+		return health.CheckerResult{
+			Status:  health.StatusDown,
+			Details: map[string]health.CheckResult{"check": {Status: health.StatusDown, Error: fmt.Errorf("error")}},
+		}
+	})
+
+	// Act
+	result := handler(r)
+
+	// Assert
+	assert.Equal(t, health.StatusDown, result.Status)
+	assert.Equal(t, expectDetails, result.Details != nil)
+	assert.Equal(t, expectErrors, result.Details["check"].Error != nil)
+}
+
+func TestAPIKeyFullDetails(t *testing.T) {
+	doTestAPIKey(t, "internal", true, true)
+}
+
+func TestAPIKeySummary(t *testing.T) {
+	doTestAPIKey(t, "external", true, false)
+}
+
+func TestAPIKeyUnknownKey(t *testing.T) {
+	doTestAPIKey(t, "unknown", false, false)
+}
+
+func TestAuthMiddlewareVariesOnCredentials(t *testing.T) {
+	// Arrange
+	checker := health.NewChecker(health.WithCacheDuration(time.Minute), health.WithCheck(health.Check{
+		Name: "check",
+		Check: func(ctx context.Context) error {
+			return nil
+		},
+	}))
+	middlewares := map[string]health.Middleware{
+		"APIKey":    APIKey(map[string]DetailLevel{"key": DetailLevelFull}),
+		"BasicAuth": BasicAuth("user", "password"),
+	}
+
+	for name, middleware := range middlewares {
+		handler := health.NewHandler(checker, health.WithCacheControlMaxAge(), health.WithMiddleware(middleware))
+		response := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		// Assert
+		assert.Equal(t, "Authorization, X-Api-Key", response.Header().Get("Vary"), name)
+		assert.Contains(t, response.Header().Get("Cache-Control"), "public, max-age=", name)
+	}
+}
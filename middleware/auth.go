@@ -37,6 +37,7 @@ func BasicAuth(username, password string) health.Middleware {
 func CustomAuth(authFunc func(r *http.Request) bool) health.Middleware {
 	return func(next health.MiddlewareFunc) health.MiddlewareFunc {
 		return func(r *http.Request) health.CheckerResult {
+			varyOnCredentials(r)
 			authSuccess := authFunc(r)
 			result := next(r)
 			if !authSuccess {
@@ -46,3 +47,10 @@ func CustomAuth(authFunc func(r *http.Request) bool) health.Middleware {
 		}
 	}
 }
+
+// varyOnCredentials adds a "Vary" header for the request headers that the authentication middleware of this
+// package reads credentials from to the response, so that shared caches (see health.WithCacheControlMaxAge)
+// do not serve a response that contains details to clients with other credentials.
+func varyOnCredentials(r *http.Request) {
+	health.OverrideResponse(r, 0, http.Header{"Vary": {"Authorization, " + APIKeyHeader}})
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"github.com/alexliesenfeld/health"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// ChaosConfig configures the Chaos middleware.
+type ChaosConfig struct {
+	// Latency is an artificial delay that is added to each request.
+	Latency time.Duration
+	// DownRate is the fraction of requests (between 0 and 1) that will be answered with StatusDown
+	// (and hence the HTTP status code that is configured with health.WithStatusCodeDown).
+	DownRate float64
+	// ForceDown answers all requests with StatusDown.
+	ForceDown bool
+	// TriggerHeader is the name of an HTTP request header. If set, failures are only injected into requests
+	// that contain this header. The header value can be used to control the injected failure per request:
+	// the value "down" forces a StatusDown result and a duration (such as "2s") adds the given latency.
+	// For all other values, the remaining configuration is applied.
+	TriggerHeader string
+}
+
+// Chaos is a middleware that injects failures (such as latency or "down" results) into health check requests.
+// This allows testing how load balancers and orchestrators react to slow or unhealthy responses.
+// Attention: This middleware is meant for resilience testing and should not be used in production unless
+// it is restricted to explicitly triggered requests (see ChaosConfig.TriggerHeader).
+func Chaos(config ChaosConfig) health.Middleware {
+	return func(next health.MiddlewareFunc) health.MiddlewareFunc {
+		return func(r *http.Request) health.CheckerResult {
+			latency, forceDown, downRate := config.Latency, config.ForceDown, config.DownRate
+
+			if config.TriggerHeader != "" {
+				values, triggered := r.Header[http.CanonicalHeaderKey(config.TriggerHeader)]
+				if !triggered {
+					return next(r)
+				}
+
+				if len(values) > 0 {
+					if values[0] == "down" {
+						forceDown = true
+					} else if d, err := time.ParseDuration(values[0]); err == nil {
+						latency = d
+					}
+				}
+			}
+
+			if latency > 0 {
+				select {
+				case <-time.After(latency):
+				case <-r.Context().Done():
+				}
+			}
+
+			result := next(r)
+			if forceDown || (downRate > 0 && rand.Float64() < downRate) {
+				result.Status = health.StatusDown
+			}
+
+			return result
+		}
+	}
+}
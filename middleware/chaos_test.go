@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func doChaosRequest(config ChaosConfig, header http.Header) *httptest.ResponseRecorder {
+	checker := health.NewChecker(health.WithDisabledAutostart())
+	handler := health.NewHandler(checker, health.WithMiddleware(Chaos(config)))
+
+	r := httptest.NewRequest(http.MethodGet, "/health", nil)
+	for key, values := range header {
+		r.Header[key] = values
+	}
+
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, r)
+	return response
+}
+
+func TestChaosInjectsDownStatus(t *testing.T) {
+	// Act
+	never := doChaosRequest(ChaosConfig{DownRate: 0}, nil)
+	always := doChaosRequest(ChaosConfig{DownRate: 1}, nil)
+	forced := doChaosRequest(ChaosConfig{ForceDown: true}, nil)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, never.Code)
+	assert.Equal(t, http.StatusServiceUnavailable, always.Code)
+	assert.Contains(t, always.Body.String(), `"status":"down"`)
+	assert.Equal(t, http.StatusServiceUnavailable, forced.Code)
+}
+
+func TestChaosInjectsLatency(t *testing.T) {
+	// Arrange
+	start := time.Now()
+
+	// Act
+	response := doChaosRequest(ChaosConfig{Latency: 50 * time.Millisecond}, nil)
+
+	// Assert
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.Equal(t, http.StatusOK, response.Code)
+}
+
+func TestChaosLatencyAdheresToRequestContext(t *testing.T) {
+	// Arrange
+	handler := Chaos(ChaosConfig{Latency: time.Hour})(func(r *http.Request) health.CheckerResult {
+		return health.CheckerResult{Status: health.StatusUp}
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	result := handler(httptest.NewRequest(http.MethodGet, "/health", nil).WithContext(ctx))
+
+	// Assert
+	assert.Equal(t, health.StatusUp, result.Status)
+}
+
+func TestChaosWithTriggerHeader(t *testing.T) {
+	// Arrange
+	config := ChaosConfig{DownRate: 1, TriggerHeader: "X-Chaos"}
+
+	// Act
+	untriggered := doChaosRequest(config, nil)
+	triggeredDown := doChaosRequest(ChaosConfig{TriggerHeader: "X-Chaos"}, http.Header{"X-Chaos": {"down"}})
+	start := time.Now()
+	triggeredLatency := doChaosRequest(ChaosConfig{TriggerHeader: "X-Chaos"}, http.Header{"X-Chaos": {"50ms"}})
+	latency := time.Since(start)
+	triggered := doChaosRequest(config, http.Header{"X-Chaos": {"yes"}})
+
+	// Assert
+	assert.Equal(t, http.StatusOK, untriggered.Code)
+	assert.Equal(t, http.StatusServiceUnavailable, triggeredDown.Code)
+	assert.Equal(t, http.StatusOK, triggeredLatency.Code)
+	assert.GreaterOrEqual(t, latency, 50*time.Millisecond)
+	assert.Equal(t, http.StatusServiceUnavailable, triggered.Code)
+}
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"fmt"
+	"github.com/alexliesenfeld/health"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// AllowCIDR is a middleware that removes check details and additional information (see health.CheckerResult.Info)
+// from the HTTP response, unless the request originates from one of the provided networks (passed in CIDR
+// notation, such as "10.0.0.0/8"). The client address is taken from the request's remote address. If your service
+// runs behind a reverse proxy or load balancer, use AllowCIDRBehindProxies instead.
+//
+// This is useful to hide internals from internet-facing probes while giving full visibility to
+// clients in trusted networks. This function panics if a network cannot be parsed.
+func AllowCIDR(cidrs ...string) health.Middleware {
+	return AllowCIDRBehindProxies(nil, cidrs...)
+}
+
+// AllowCIDRBehindProxies works like AllowCIDR, but honors the "X-Forwarded-For" request header if the request
+// was sent by one of the trusted proxies (passed in CIDR notation in argument 'trustedProxies'). The client address
+// is determined by walking the "X-Forwarded-For" header from right to left and taking the first address that does
+// not belong to a trusted proxy. This function panics if a network cannot be parsed.
+func AllowCIDRBehindProxies(trustedProxies []string, cidrs ...string) health.Middleware {
+	proxyNetworks := mustParseCIDRs(trustedProxies)
+	allowedNetworks := mustParseCIDRs(cidrs)
+
+	return func(next health.MiddlewareFunc) health.MiddlewareFunc {
+		return func(r *http.Request) health.CheckerResult {
+			ip := clientIP(r, proxyNetworks)
+			result := next(r)
+			if ip == nil || !containsIP(allowedNetworks, ip) {
+				reduceDetails(&result, DetailLevelNone)
+			}
+			return result
+		}
+	}
+}
+
+func clientIP(r *http.Request, trustedProxies []*net.IPNet) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !containsIP(trustedProxies, ip) {
+		return ip
+	}
+
+	forwardedFor := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for idx := len(forwardedFor) - 1; idx >= 0; idx-- {
+		forwardedIP := net.ParseIP(strings.TrimSpace(forwardedFor[idx]))
+		if forwardedIP == nil {
+			// An unparseable entry cannot be trusted, so we stop at the last known address.
+			break
+		}
+
+		ip = forwardedIP
+		if !containsIP(trustedProxies, ip) {
+			break
+		}
+	}
+
+	return ip
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func mustParseCIDRs(cidrs []string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(fmt.Sprintf("cannot parse network %q: %v", cidr, err))
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func doTestAllowCIDR(t *testing.T, remoteAddr, forwardedFor string, expectDetails bool) {
+	// Arrange
+	r := httptest.NewRequest(http.MethodGet, "/health", nil)
+	r.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		r.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+
+	handler := AllowCIDRBehindProxies([]string{"192.168.0.0/16"}, "10.0.0.0/8")(func(r *http.Request) health.CheckerResult {
+		return health.CheckerResult{
+			Status:  health.StatusUp,
+			Info:    map[string]interface{}{"version": "1.0.0"},
+			Details: map[string]health.CheckResult{"check": {Status: health.StatusUp}},
+		}
+	})
+
+	// Act
+	result := handler(r)
+
+	// Assert
+	assert.Equal(t, expectDetails, result.Details != nil)
+	assert.Equal(t, expectDetails, result.Info != nil)
+}
+
+func TestAllowCIDRTrustedNetwork(t *testing.T) {
+	doTestAllowCIDR(t, "10.1.2.3:1234", "", true)
+}
+
+func TestAllowCIDRUntrustedNetwork(t *testing.T) {
+	doTestAllowCIDR(t, "8.8.8.8:1234", "", false)
+}
+
+func TestAllowCIDRForwardedByTrustedProxy(t *testing.T) {
+	doTestAllowCIDR(t, "192.168.1.1:1234", "8.8.8.8, 10.1.2.3", true)
+}
+
+func TestAllowCIDRForwardedByUntrustedProxy(t *testing.T) {
+	doTestAllowCIDR(t, "8.8.8.8:1234", "10.1.2.3", false)
+}
+
+func TestAllowCIDRSpoofedForwardedFor(t *testing.T) {
+	doTestAllowCIDR(t, "192.168.1.1:1234", "10.1.2.3, 8.8.8.8", false)
+}
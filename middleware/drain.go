@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"encoding/json"
+	"github.com/alexliesenfeld/health"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+)
+
+type (
+	// Drain controls the drain mode of a service. While drain mode is enabled, the middleware returned by
+	// Drain.Middleware reports the system as StatusDown, so that load balancers and orchestrators remove the
+	// instance from rotation (e.g., before the service is shut down). Drain mode can be enabled
+	// programmatically (see Drain.Enable), by a signal (see Drain.EnableOnSignal), by a marker file
+	// (see WithDrainMarkerFile) or by an admin endpoint (see Drain.Handler).
+	Drain struct {
+		enabled    int32
+		markerFile string
+	}
+
+	// DrainOption is a configuration option for a Drain.
+	DrainOption func(d *Drain)
+)
+
+// WithDrainMarkerFile configures a file path that enables drain mode while a file exists at this path.
+// This allows enabling drain mode from outside the process (e.g., from a deployment script).
+func WithDrainMarkerFile(path string) DrainOption {
+	return func(d *Drain) {
+		d.markerFile = path
+	}
+}
+
+// NewDrain creates a new Drain. Drain mode is disabled initially.
+func NewDrain(options ...DrainOption) *Drain {
+	d := Drain{}
+	for _, opt := range options {
+		opt(&d)
+	}
+	return &d
+}
+
+// Enable enables drain mode.
+func (d *Drain) Enable() {
+	atomic.StoreInt32(&d.enabled, 1)
+}
+
+// Disable disables drain mode. Drain mode will still be enabled if a marker file is present
+// (see WithDrainMarkerFile).
+func (d *Drain) Disable() {
+	atomic.StoreInt32(&d.enabled, 0)
+}
+
+// IsEnabled returns true, if drain mode is currently enabled.
+func (d *Drain) IsEnabled() bool {
+	if atomic.LoadInt32(&d.enabled) == 1 {
+		return true
+	}
+
+	if d.markerFile != "" {
+		if _, err := os.Stat(d.markerFile); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EnableOnSignal enables drain mode as soon as the process receives one of the provided signals
+// (e.g., syscall.SIGTERM). The returned function stops listening for signals.
+// Attention: Signals will not be delivered to other parts of your program that are not listening
+// for them using signal.Notify. If you need to shut down your service after draining, listen for the
+// same signals in your shutdown logic as well.
+func (d *Drain) EnableOnSignal(signals ...os.Signal) (stop func()) {
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, signals...)
+
+	go func() {
+		select {
+		case <-ch:
+			d.Enable()
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
+
+// Middleware creates a health.Middleware that reports the system as StatusDown while drain mode is enabled.
+func (d *Drain) Middleware() health.Middleware {
+	return func(next health.MiddlewareFunc) health.MiddlewareFunc {
+		return func(r *http.Request) health.CheckerResult {
+			result := next(r)
+			if d.IsEnabled() {
+				result.Status = health.StatusDown
+			}
+			return result
+		}
+	}
+}
+
+// Handler creates an http.Handler that allows controlling drain mode via HTTP. Requests with method POST or PUT
+// enable drain mode, requests with method DELETE disable it. All requests are answered with a JSON document that
+// contains the current drain mode (e.g., {"draining":true}).
+// Attention: This handler should only be reachable by administrators (e.g., by using an authentication
+// middleware or by serving it on an internal port).
+func (d *Drain) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost, http.MethodPut:
+			d.Enable()
+		case http.MethodDelete:
+			d.Disable()
+		case http.MethodGet, http.MethodHead:
+		default:
+			w.Header().Set("Allow", "GET, HEAD, POST, PUT, DELETE")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		//nolint:errcheck
+		json.NewEncoder(w).Encode(map[string]bool{"draining": d.IsEnabled()})
+	})
+}
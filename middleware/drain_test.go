@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainHandlerTogglesDrainMode(t *testing.T) {
+	// Arrange
+	drain := NewDrain()
+	handler := drain.Middleware()(func(r *http.Request) health.CheckerResult {
+		return health.CheckerResult{Status: health.StatusUp}
+	})
+	r := httptest.NewRequest(http.MethodGet, "/health", nil)
+
+	// Act & Assert
+	assert.Equal(t, health.StatusUp, handler(r).Status)
+
+	drain.Handler().ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/drain", nil))
+	assert.Equal(t, health.StatusDown, handler(r).Status)
+
+	drain.Handler().ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/drain", nil))
+	assert.Equal(t, health.StatusUp, handler(r).Status)
+}
+
+func TestDrainMarkerFile(t *testing.T) {
+	// Arrange
+	markerFile := filepath.Join(t.TempDir(), "drain")
+	drain := NewDrain(WithDrainMarkerFile(markerFile))
+
+	// Act & Assert
+	assert.False(t, drain.IsEnabled())
+	require.NoError(t, os.WriteFile(markerFile, nil, 0o600))
+	assert.True(t, drain.IsEnabled())
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"github.com/alexliesenfeld/health"
+	"net/http"
+)
+
+// HideErrors is a middleware that removes the error messages of all components from the HTTP response,
+// but keeps their availability status and timestamps. This is useful if you want to provide component-level
+// visibility to external clients without leaking error messages that may contain sensitive information
+// (such as hostnames or connection strings).
+func HideErrors() health.Middleware {
+	return func(next health.MiddlewareFunc) health.MiddlewareFunc {
+		return func(r *http.Request) health.CheckerResult {
+			result := next(r)
+			result.Details = withoutErrors(result.Details)
+			return result
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHideErrorsRemovesErrorsFromCheckResults(t *testing.T) {
+	// Arrange
+	timestamp := time.Now()
+	details := map[string]health.CheckResult{
+		"db":    {Status: health.StatusDown, Timestamp: timestamp, Error: fmt.Errorf("cannot connect to db.internal:5432")},
+		"cache": {Status: health.StatusUp, Timestamp: timestamp},
+	}
+	handler := HideErrors()(func(r *http.Request) health.CheckerResult {
+		return health.CheckerResult{Status: health.StatusDown, Details: details}
+	})
+
+	// Act
+	result := handler(httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, health.StatusDown, result.Status)
+	assert.Equal(t, map[string]health.CheckResult{
+		"db":    {Status: health.StatusDown, Timestamp: timestamp},
+		"cache": {Status: health.StatusUp, Timestamp: timestamp},
+	}, result.Details)
+	assert.Error(t, details["db"].Error, "the original details must not be modified")
+}
+
+func TestHideErrorsKeepsHTTPStatusCode(t *testing.T) {
+	// Arrange
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name: "db",
+			Check: func(ctx context.Context) error {
+				return fmt.Errorf("cannot connect to db.internal:5432")
+			},
+		}),
+	)
+	handler := health.NewHandler(checker, health.WithMiddleware(HideErrors()))
+	response := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, response.Code)
+	assert.Contains(t, response.Body.String(), `"status":"down"`)
+	assert.NotContains(t, response.Body.String(), "db.internal")
+	assert.NotContains(t, response.Body.String(), `"error"`)
+}
@@ -0,0 +1,231 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"github.com/alexliesenfeld/health"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// IntrospectionOption is a configuration option for the TokenIntrospection middleware.
+	IntrospectionOption func(cfg *introspectionConfig)
+
+	introspectionConfig struct {
+		client        *http.Client
+		clientID      string
+		clientSecret  string
+		scopes        []string
+		cacheDuration time.Duration
+	}
+
+	introspectionResponse struct {
+		Active bool   `json:"active"`
+		Scope  string `json:"scope"`
+		Exp    int64  `json:"exp"`
+	}
+
+	introspectionCacheEntry struct {
+		active    bool
+		expiresAt time.Time
+	}
+
+	introspectionCache struct {
+		mtx     sync.Mutex
+		entries map[[sha256.Size]byte]introspectionCacheEntry
+	}
+)
+
+const (
+	// maxIntrospectionCacheEntries is the maximum number of cached introspection results. If the cache is full,
+	// expired entries are removed. If there are none, the entry that expires next is replaced.
+	maxIntrospectionCacheEntries = 1024
+	// maxInactiveCacheDuration limits how long inactive introspection results are cached, so that
+	// requests with arbitrary tokens cannot fill the cache for the whole cache duration.
+	maxInactiveCacheDuration = 10 * time.Second
+)
+
+// WithIntrospectionClient sets the http.Client that is used to call the introspection endpoint.
+// By default, http.DefaultClient is used.
+func WithIntrospectionClient(client *http.Client) IntrospectionOption {
+	return func(cfg *introspectionConfig) {
+		cfg.client = client
+	}
+}
+
+// WithIntrospectionClientCredentials sets the client credentials that are used to authenticate at the
+// introspection endpoint (using HTTP basic authentication).
+func WithIntrospectionClientCredentials(clientID, clientSecret string) IntrospectionOption {
+	return func(cfg *introspectionConfig) {
+		cfg.clientID = clientID
+		cfg.clientSecret = clientSecret
+	}
+}
+
+// WithIntrospectionScopes requires active tokens to contain all provided scopes.
+func WithIntrospectionScopes(scopes ...string) IntrospectionOption {
+	return func(cfg *introspectionConfig) {
+		cfg.scopes = scopes
+	}
+}
+
+// WithIntrospectionCacheDuration sets the duration for how long introspection results will be cached.
+// Results are never cached beyond the expiry time of a token. Inactive results are cached for at most
+// 10 seconds and failed introspection requests are not cached at all. A duration of 0 disables caching.
+// Default is 1 minute.
+func WithIntrospectionCacheDuration(duration time.Duration) IntrospectionOption {
+	return func(cfg *introspectionConfig) {
+		cfg.cacheDuration = duration
+	}
+}
+
+// TokenIntrospection is a middleware that removes check details (such as service names, error messages, etc.) from
+// the HTTP response unless the request contains a bearer token in the "Authorization" header that is considered
+// active by an OAuth 2.0 token introspection endpoint (https://datatracker.ietf.org/doc/html/rfc7662).
+// Introspection results are cached (see WithIntrospectionCacheDuration), so that not every health check
+// request results in a call to the introspection endpoint.
+//
+// This is useful in environments where health dashboards authenticate via a corporate single sign-on
+// rather than basic authentication.
+func TokenIntrospection(endpoint string, options ...IntrospectionOption) health.Middleware {
+	cfg := introspectionConfig{
+		client:        http.DefaultClient,
+		cacheDuration: 1 * time.Minute,
+	}
+
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	cache := introspectionCache{entries: map[[sha256.Size]byte]introspectionCacheEntry{}}
+
+	return CustomAuth(func(r *http.Request) bool {
+		token, ok := bearerToken(r)
+		if !ok {
+			return false
+		}
+
+		key := sha256.Sum256([]byte(token))
+		if active, ok := cache.get(key); ok {
+			return active
+		}
+
+		resp, err := introspect(r, endpoint, token, &cfg)
+		if err != nil {
+			return false
+		}
+
+		active := resp.Active && containsAll(strings.Fields(resp.Scope), cfg.scopes)
+
+		cache.put(key, introspectionCacheEntry{active: active, expiresAt: cfg.cacheExpiry(active, resp.Exp)})
+
+		return active
+	})
+}
+
+func introspect(r *http.Request, endpoint, token string, cfg *introspectionConfig) (*introspectionResponse, error) {
+	form := url.Values{"token": {token}, "token_type_hint": {"access_token"}}
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if cfg.clientID != "" {
+		req.SetBasicAuth(url.QueryEscape(cfg.clientID), url.QueryEscape(cfg.clientSecret))
+	}
+
+	resp, err := cfg.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("introspection endpoint responded with status code %d", resp.StatusCode)
+	}
+
+	var result introspectionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("cannot decode introspection response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// cacheExpiry returns until when an introspection result may be cached.
+func (cfg *introspectionConfig) cacheExpiry(active bool, exp int64) time.Time {
+	cacheDuration := cfg.cacheDuration
+	if !active && cacheDuration > maxInactiveCacheDuration {
+		cacheDuration = maxInactiveCacheDuration
+	}
+
+	expiresAt := time.Now().Add(cacheDuration)
+	if exp > 0 && time.Unix(exp, 0).Before(expiresAt) {
+		expiresAt = time.Unix(exp, 0)
+	}
+
+	return expiresAt
+}
+
+func (c *introspectionCache) get(key [sha256.Size]byte) (bool, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !time.Now().Before(entry.expiresAt) {
+		return false, false
+	}
+
+	return entry.active, true
+}
+
+func (c *introspectionCache) put(key [sha256.Size]byte, entry introspectionCacheEntry) {
+	if !time.Now().Before(entry.expiresAt) {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxIntrospectionCacheEntries {
+		c.evict()
+	}
+
+	c.entries[key] = entry
+}
+
+// evict removes all expired entries from the cache. If no entry has expired, the entry that expires
+// next is removed instead, so that the cache never grows beyond maxIntrospectionCacheEntries.
+func (c *introspectionCache) evict() {
+	now := time.Now()
+	var next [sha256.Size]byte
+	var nextExpiresAt time.Time
+
+	for k, v := range c.entries {
+		if !now.Before(v.expiresAt) {
+			delete(c.entries, k)
+		} else if nextExpiresAt.IsZero() || v.expiresAt.Before(nextExpiresAt) {
+			next, nextExpiresAt = k, v.expiresAt
+		}
+	}
+
+	if len(c.entries) >= maxIntrospectionCacheEntries {
+		delete(c.entries, next)
+	}
+}
+
+func containsAll(values []string, required []string) bool {
+	for _, value := range required {
+		if !containsString(values, value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenIntrospectionCachesResults(t *testing.T) {
+	// Arrange
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		assert.NoError(t, r.ParseForm())
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"active": r.PostForm.Get("token") == "valid",
+			"scope":  "health:read",
+		})
+	}))
+	defer server.Close()
+
+	handler := TokenIntrospection(server.URL, WithIntrospectionScopes("health:read"))(func(r *http.Request) health.CheckerResult {
+		return health.CheckerResult{Status: health.StatusUp, Details: map[string]health.CheckResult{"check": {}}}
+	})
+
+	doRequest := func(token string) health.CheckerResult {
+		r := httptest.NewRequest(http.MethodGet, "/health", nil)
+		r.Header.Set("Authorization", "Bearer "+token)
+		return handler(r)
+	}
+
+	// Act
+	validResult := doRequest("valid")
+	cachedResult := doRequest("valid")
+	invalidResult := doRequest("invalid")
+
+	// Assert
+	assert.NotNil(t, validResult.Details)
+	assert.NotNil(t, cachedResult.Details)
+	assert.Nil(t, invalidResult.Details)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestIntrospectionCacheDoesNotReturnExpiredEntries(t *testing.T) {
+	// Arrange
+	cache := introspectionCache{entries: map[[32]byte]introspectionCacheEntry{}}
+	cache.entries[[32]byte{1}] = introspectionCacheEntry{active: true, expiresAt: time.Now().Add(-time.Second)}
+
+	// Act
+	_, ok := cache.get([32]byte{1})
+
+	// Assert
+	assert.False(t, ok)
+}
+
+func TestIntrospectionCacheIsBounded(t *testing.T) {
+	// Arrange
+	cache := introspectionCache{entries: map[[32]byte]introspectionCacheEntry{}}
+	now := time.Now()
+	for i := 0; i < maxIntrospectionCacheEntries; i++ {
+		key := [32]byte{byte(i), byte(i >> 8)}
+		cache.entries[key] = introspectionCacheEntry{active: true, expiresAt: now.Add(time.Hour + time.Duration(i)*time.Second)}
+	}
+
+	// Act
+	cache.put([32]byte{0xff, 0xff}, introspectionCacheEntry{active: true, expiresAt: now.Add(time.Minute)})
+
+	// Assert
+	assert.Len(t, cache.entries, maxIntrospectionCacheEntries)
+	_, evicted := cache.entries[[32]byte{0, 0}]
+	assert.False(t, evicted, "the entry that expires next should have been evicted")
+	_, ok := cache.get([32]byte{0xff, 0xff})
+	assert.True(t, ok)
+}
+
+func TestIntrospectionCacheExpiry(t *testing.T) {
+	// Arrange
+	cfg := introspectionConfig{cacheDuration: time.Hour}
+	exp := time.Now().Add(time.Minute).Unix()
+
+	// Act
+	active := cfg.cacheExpiry(true, 0)
+	inactive := cfg.cacheExpiry(false, 0)
+	expiring := cfg.cacheExpiry(true, exp)
+
+	// Assert
+	assert.WithinDuration(t, time.Now().Add(time.Hour), active, time.Second)
+	assert.WithinDuration(t, time.Now().Add(maxInactiveCacheDuration), inactive, time.Second)
+	assert.Equal(t, time.Unix(exp, 0), expiring)
+}
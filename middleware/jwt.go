@@ -0,0 +1,260 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/alexliesenfeld/health"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type (
+	// JWTKeyFunc provides the key that is used to verify the signature of a JSON Web Token. It receives the
+	// decoded token header, so that the key can be selected based on the header values (e.g., "kid" or "alg").
+	// The returned key must be a []byte for HMAC algorithms (HS256, HS384, HS512), an *rsa.PublicKey for
+	// RSA algorithms (RS256, RS384, RS512, PS256, PS384, PS512) or an *ecdsa.PublicKey for ECDSA
+	// algorithms (ES256, ES384, ES512).
+	JWTKeyFunc func(header map[string]interface{}) (interface{}, error)
+
+	// JWTOption is a configuration option for the JWTAuth middleware.
+	JWTOption func(cfg *jwtConfig)
+
+	jwtConfig struct {
+		audience string
+		scopes   []string
+		leeway   time.Duration
+	}
+)
+
+// WithJWTAudience requires tokens to contain the provided audience in their "aud" claim.
+func WithJWTAudience(audience string) JWTOption {
+	return func(cfg *jwtConfig) {
+		cfg.audience = audience
+	}
+}
+
+// WithJWTScopes requires tokens to contain all provided scopes in their "scope" claim
+// (a space-separated list as defined in RFC 8693) or "scp" claim (a list of strings).
+func WithJWTScopes(scopes ...string) JWTOption {
+	return func(cfg *jwtConfig) {
+		cfg.scopes = scopes
+	}
+}
+
+// WithJWTLeeway sets a duration that is tolerated when validating the "exp" and "nbf" claims
+// to account for clock skew between the token issuer and this service. Default is 0.
+func WithJWTLeeway(leeway time.Duration) JWTOption {
+	return func(cfg *jwtConfig) {
+		cfg.leeway = leeway
+	}
+}
+
+// JWTAuth is a middleware that removes check details (such as service names, error messages, etc.) from the
+// HTTP response unless the request contains a valid JSON Web Token (https://datatracker.ietf.org/doc/html/rfc7519)
+// as a bearer token in the "Authorization" header. A token is considered valid if its signature can be verified
+// using the key provided by argument 'keyFunc', and it is not expired (or not yet valid). Additional requirements
+// (such as audience or scopes) can be configured using options.
+//
+// This is useful if you want to allow the aggregated result to be visible to all clients, but provide
+// details only to platform tooling that holds a token.
+func JWTAuth(keyFunc JWTKeyFunc, options ...JWTOption) health.Middleware {
+	cfg := jwtConfig{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return CustomAuth(func(r *http.Request) bool {
+		token, ok := bearerToken(r)
+		return ok && verifyJWT(token, keyFunc, &cfg, time.Now()) == nil
+	})
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	authHeader := r.Header.Get("Authorization")
+	if len(authHeader) < 7 || !strings.EqualFold(authHeader[:7], "Bearer ") {
+		return "", false
+	}
+	return strings.TrimSpace(authHeader[7:]), true
+}
+
+func verifyJWT(token string, keyFunc JWTKeyFunc, cfg *jwtConfig, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errors.New("token must consist of three parts")
+	}
+
+	var header map[string]interface{}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return fmt.Errorf("cannot decode token header: %w", err)
+	}
+
+	var claims map[string]interface{}
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return fmt.Errorf("cannot decode token claims: %w", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fmt.Errorf("cannot decode token signature: %w", err)
+	}
+
+	key, err := keyFunc(header)
+	if err != nil {
+		return fmt.Errorf("cannot get token verification key: %w", err)
+	}
+
+	alg, _ := header["alg"].(string)
+	if err := verifyJWTSignature(alg, parts[0]+"."+parts[1], signature, key); err != nil {
+		return err
+	}
+
+	return verifyJWTClaims(claims, cfg, now)
+}
+
+func decodeJWTPart(part string, target interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+func verifyJWTSignature(alg, signingInput string, signature []byte, key interface{}) error {
+	if len(alg) != 5 {
+		return fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+
+	var hash crypto.Hash
+	switch alg[2:] {
+	case "256":
+		hash = crypto.SHA256
+	case "384":
+		hash = crypto.SHA384
+	case "512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+
+	hasher := hash.New()
+	hasher.Write([]byte(signingInput))
+	digest := hasher.Sum(nil)
+
+	switch {
+	case strings.HasPrefix(alg, "HS"):
+		secret, ok := key.([]byte)
+		if !ok {
+			return errors.New("HMAC algorithms require a key of type []byte")
+		}
+		mac := hmac.New(hash.New, secret)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return errors.New("invalid token signature")
+		}
+		return nil
+	case strings.HasPrefix(alg, "RS"), strings.HasPrefix(alg, "PS"):
+		publicKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("RSA algorithms require a key of type *rsa.PublicKey")
+		}
+		if strings.HasPrefix(alg, "PS") {
+			return rsa.VerifyPSS(publicKey, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}
+		return rsa.VerifyPKCS1v15(publicKey, hash, digest, signature)
+	case strings.HasPrefix(alg, "ES"):
+		publicKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return errors.New("ECDSA algorithms require a key of type *ecdsa.PublicKey")
+		}
+		var curve elliptic.Curve
+		switch hash {
+		case crypto.SHA256:
+			curve = elliptic.P256()
+		case crypto.SHA384:
+			curve = elliptic.P384()
+		default:
+			curve = elliptic.P521()
+		}
+		if publicKey.Curve == nil || publicKey.Curve.Params().Name != curve.Params().Name {
+			return fmt.Errorf("algorithm %q requires a key on curve %s", alg, curve.Params().Name)
+		}
+		// The signature is the concatenation of R and S, each with the byte size of the curve (see RFC 7518).
+		size := (curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("invalid token signature")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(publicKey, digest, r, s) {
+			return errors.New("invalid token signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+}
+
+func verifyJWTClaims(claims map[string]interface{}, cfg *jwtConfig, now time.Time) error {
+	if exp, ok := claims["exp"].(float64); ok && !now.Before(time.Unix(int64(exp), 0).Add(cfg.leeway)) {
+		return errors.New("token is expired")
+	}
+
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(cfg.leeway).Before(time.Unix(int64(nbf), 0)) {
+		return errors.New("token is not valid yet")
+	}
+
+	if cfg.audience != "" && !containsString(claimStrings(claims["aud"], ""), cfg.audience) {
+		return fmt.Errorf("token audience does not contain %q", cfg.audience)
+	}
+
+	if len(cfg.scopes) > 0 {
+		scopes := append(claimStrings(claims["scope"], " "), claimStrings(claims["scp"], " ")...)
+		for _, scope := range cfg.scopes {
+			if !containsString(scopes, scope) {
+				return fmt.Errorf("token scopes do not contain %q", scope)
+			}
+		}
+	}
+
+	return nil
+}
+
+// claimStrings converts a claim value that is either a string or a list of strings into a list of strings.
+// If argument 'sep' is not empty, string values are split by the separator.
+func claimStrings(claim interface{}, sep string) []string {
+	switch value := claim.(type) {
+	case string:
+		if sep != "" {
+			return strings.Split(value, sep)
+		}
+		return []string{value}
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
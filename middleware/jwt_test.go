@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testJWTSecret = []byte("secret")
+
+func createTestJWT(t *testing.T, claims map[string]interface{}) string {
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	assert.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	assert.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, testJWTSecret)
+	mac.Write([]byte(signingInput))
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func testJWTKeyFunc(_ map[string]interface{}) (interface{}, error) {
+	return testJWTSecret, nil
+}
+
+func TestVerifyJWT(t *testing.T) {
+	now := time.Now()
+	cfg := jwtConfig{audience: "health", scopes: []string{"health:read"}}
+
+	testCases := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"valid", createTestJWT(t, map[string]interface{}{
+			"exp": now.Add(time.Minute).Unix(), "aud": "health", "scope": "openid health:read",
+		}), false},
+		{"expired", createTestJWT(t, map[string]interface{}{
+			"exp": now.Add(-time.Minute).Unix(), "aud": "health", "scope": "health:read",
+		}), true},
+		{"wrong audience", createTestJWT(t, map[string]interface{}{
+			"aud": []string{"other"}, "scope": "health:read",
+		}), true},
+		{"missing scope", createTestJWT(t, map[string]interface{}{
+			"aud": "health", "scp": []string{"other"},
+		}), true},
+		{"invalid signature", createTestJWT(t, map[string]interface{}{
+			"aud": "health", "scope": "health:read",
+		}) + "x", true},
+		{"malformed", "not-a-token", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifyJWT(tc.token, testJWTKeyFunc, &cfg, now)
+			assert.Equal(t, tc.wantErr, err != nil, "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestVerifyJWTSignatureECDSA(t *testing.T) {
+	signingInput := "header.payload"
+
+	sign := func(key *ecdsa.PrivateKey, hash crypto.Hash) []byte {
+		hasher := hash.New()
+		hasher.Write([]byte(signingInput))
+		r, s, err := ecdsa.Sign(rand.Reader, key, hasher.Sum(nil))
+		require.NoError(t, err)
+
+		size := (key.Curve.Params().BitSize + 7) / 8
+		signature := make([]byte, 2*size)
+		r.FillBytes(signature[:size])
+		s.FillBytes(signature[size:])
+		return signature
+	}
+
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	p521Key, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		alg       string
+		signature []byte
+		key       *ecdsa.PublicKey
+		wantErr   bool
+	}{
+		{"ES256", "ES256", sign(p256Key, crypto.SHA256), &p256Key.PublicKey, false},
+		{"ES384", "ES384", sign(p384Key, crypto.SHA384), &p384Key.PublicKey, false},
+		{"ES512", "ES512", sign(p521Key, crypto.SHA512), &p521Key.PublicKey, false},
+		{"curve mismatch", "ES256", sign(p384Key, crypto.SHA256), &p384Key.PublicKey, true},
+		{"truncated signature", "ES256", sign(p256Key, crypto.SHA256)[1:], &p256Key.PublicKey, true},
+		{"padded signature", "ES256", append([]byte{0}, sign(p256Key, crypto.SHA256)...), &p256Key.PublicKey, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifyJWTSignature(tc.alg, signingInput, tc.signature, tc.key)
+			assert.Equal(t, tc.wantErr, err != nil, "unexpected error: %v", err)
+		})
+	}
+}
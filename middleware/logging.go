@@ -7,7 +7,35 @@ import (
 	"time"
 )
 
+type (
+	// Logger is a minimal structured logger interface that is used by the StructuredLogger middleware.
+	// Additional information is passed as alternating keys and values. A *slog.Logger (package log/slog)
+	// implements this interface, other loggers can be adapted using LoggerFunc.
+	Logger interface {
+		Info(msg string, keysAndValues ...interface{})
+	}
+
+	// LoggerFunc is an adapter to allow the use of ordinary functions as a Logger. For example, the method
+	// Infow of a zap.SugaredLogger can be used as a Logger by converting it: LoggerFunc(sugaredLogger.Infow).
+	// Loggers that expect fields in a map (such as logrus) can be adapted by converting the key/value pairs:
+	//
+	//	middleware.LoggerFunc(func(msg string, keysAndValues ...interface{}) {
+	//		fields := logrus.Fields{}
+	//		for i := 0; i+1 < len(keysAndValues); i += 2 {
+	//			fields[fmt.Sprint(keysAndValues[i])] = keysAndValues[i+1]
+	//		}
+	//		logrus.WithFields(fields).Info(msg)
+	//	})
+	LoggerFunc func(msg string, keysAndValues ...interface{})
+)
+
+// Info implements Logger.Info.
+func (f LoggerFunc) Info(msg string, keysAndValues ...interface{}) {
+	f(msg, keysAndValues...)
+}
+
 // BasicLogger is a basic logger that is mostly used to showcase this library.
+// Consider using StructuredLogger in production code.
 func BasicLogger() health.Middleware {
 	return func(next health.MiddlewareFunc) health.MiddlewareFunc {
 		return func(r *http.Request) health.CheckerResult {
@@ -19,3 +47,34 @@ func BasicLogger() health.Middleware {
 		}
 	}
 }
+
+// StructuredLogger is a middleware that logs every processed health check request using the provided Logger.
+// The following fields are logged: "method" and "path" of the request, "remote_addr" of the client, "duration"
+// of the request processing (as a time.Duration), the aggregated availability "status" of the result and the
+// HTTP "status_code" of the response. If the middleware is used with a handler created by health.NewHandler,
+// the request is logged after the response has been written (see health.OnResponse). Otherwise, the
+// request is logged without status code as soon as the result is available.
+func StructuredLogger(logger Logger) health.Middleware {
+	return func(next health.MiddlewareFunc) health.MiddlewareFunc {
+		return func(r *http.Request) health.CheckerResult {
+			now := time.Now()
+			result := next(r)
+			keysAndValues := []interface{}{
+				"method", r.Method,
+				"path", r.URL.Path,
+				"remote_addr", r.RemoteAddr,
+				"status", string(result.Status),
+			}
+
+			listening := health.OnResponse(r, func(statusCode int) {
+				logger.Info("processed health check request",
+					append(keysAndValues, "duration", time.Since(now), "status_code", statusCode)...)
+			})
+			if !listening {
+				logger.Info("processed health check request", append(keysAndValues, "duration", time.Since(now))...)
+			}
+
+			return result
+		}
+	}
+}
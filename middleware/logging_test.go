@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructuredLogger(t *testing.T) {
+	// Arrange
+	fields := map[string]interface{}{}
+	logger := LoggerFunc(func(msg string, keysAndValues ...interface{}) {
+		for i := 0; i+1 < len(keysAndValues); i += 2 {
+			fields[keysAndValues[i].(string)] = keysAndValues[i+1]
+		}
+	})
+
+	handler := StructuredLogger(logger)(func(r *http.Request) health.CheckerResult {
+		return health.CheckerResult{Status: health.StatusDown}
+	})
+
+	// Act
+	handler(httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	require.Contains(t, fields, "duration")
+	assert.Equal(t, http.MethodGet, fields["method"])
+	assert.Equal(t, "/health", fields["path"])
+	assert.Equal(t, "down", fields["status"])
+}
+
+func TestStructuredLoggerLogsResponseStatusCode(t *testing.T) {
+	// Arrange
+	var messages int
+	fields := map[string]interface{}{}
+	logger := LoggerFunc(func(msg string, keysAndValues ...interface{}) {
+		messages++
+		for i := 0; i+1 < len(keysAndValues); i += 2 {
+			fields[keysAndValues[i].(string)] = keysAndValues[i+1]
+		}
+	})
+
+	checker := health.NewChecker(health.WithDisabledAutostart())
+	handler := health.NewHandler(checker,
+		health.WithMiddleware(StructuredLogger(logger)),
+		health.WithStatusCodeUp(http.StatusNoContent))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, 1, messages)
+	assert.Equal(t, "up", fields["status"])
+	assert.Equal(t, http.StatusNoContent, fields["status_code"])
+}
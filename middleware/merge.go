@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/alexliesenfeld/health"
+	"net/http"
+)
+
+// MergeCheckers is a middleware that merges the results of additional health.Checker instances into the result
+// of the next middleware (and eventually the health.Checker that the handler was created with). The keys of the
+// provided map are used as namespaces for the details of each additional health.Checker (see health.MergeResults),
+// whereas the details of the handlers health.Checker are not prefixed. The aggregated status is the most
+// critical status of all results.
+//
+// This is useful for incremental migrations, where a readiness endpoint combines a new health.Checker
+// with checks that are still provided by a legacy implementation.
+func MergeCheckers(checkers map[string]health.Checker) health.Middleware {
+	return func(next health.MiddlewareFunc) health.MiddlewareFunc {
+		return func(r *http.Request) health.CheckerResult {
+			results := make(map[string]health.CheckerResult, len(checkers)+1)
+			for namespace, checker := range checkers {
+				results[namespace] = checker.Check(r.Context())
+			}
+			results[""] = next(r)
+			return health.MergeResults(results)
+		}
+	}
+}
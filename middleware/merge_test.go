@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeCheckersHandler(t *testing.T) {
+	// Arrange
+	newChecker := func(name string, err error) health.Checker {
+		return health.NewChecker(
+			health.WithDisabledAutostart(),
+			health.WithCheck(health.Check{
+				Name: name,
+				Check: func(ctx context.Context) error {
+					return err
+				},
+			}),
+		)
+	}
+
+	primary := newChecker("db", nil)
+	legacy := newChecker("queue", fmt.Errorf("queue is not reachable"))
+	handler := health.NewHandler(primary, health.WithMiddleware(MergeCheckers(map[string]health.Checker{"legacy": legacy})))
+	response := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, response.Code)
+
+	var result health.CheckerResult
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &result))
+	assert.Equal(t, health.StatusDown, result.Status)
+	require.Len(t, result.Details, 2)
+	assert.Equal(t, health.StatusUp, result.Details["db"].Status)
+	assert.Equal(t, health.StatusDown, result.Details["legacy/queue"].Status)
+	assert.EqualError(t, result.Details["legacy/queue"].Error, "queue is not reachable")
+}
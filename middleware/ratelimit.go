@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"github.com/alexliesenfeld/health"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type (
+	// RateLimitOption is a configuration option for the RateLimit middleware.
+	RateLimitOption func(cfg *rateLimitConfig)
+
+	rateLimitConfig struct {
+		reject bool
+	}
+
+	tokenBucket struct {
+		mtx      sync.Mutex
+		rate     float64
+		burst    float64
+		tokens   float64
+		lastTime time.Time
+	}
+)
+
+// WithRateLimitRejection configures the RateLimit middleware to answer requests that exceed the limit with
+// HTTP status code 429 (Too Many Requests) and a "Retry-After" header (in seconds) instead of the last result.
+// The response body only contains the availability status "unknown". This option only takes effect if the
+// middleware is used with a handler that was created by health.NewHandler (see health.OverrideResponse).
+func WithRateLimitRejection() RateLimitOption {
+	return func(cfg *rateLimitConfig) {
+		cfg.reject = true
+	}
+}
+
+// RateLimit is a middleware that limits the number of health checks that are executed on behalf of HTTP requests.
+// Requests are limited using a token bucket that is refilled with 'rps' tokens per second and holds up to 'burst'
+// tokens. Requests that exceed the limit are not forwarded to the next middleware (and eventually the
+// health.Checker) but are answered with the last result that was returned by the next middleware instead.
+// If no result is available yet, the request is forwarded regardless of the limit. Alternatively, requests that
+// exceed the limit can be rejected (see WithRateLimitRejection).
+//
+// This is useful to protect the health.Checker and downstream dependencies from aggressive external uptime monitors.
+//
+// Attention: Because results are shared between requests, this middleware must be placed after all middleware that
+// removes details based on the request (such as BasicAuth or APIKey) in the list of middleware that is passed to
+// health.WithMiddleware. Otherwise, a cached result containing all details might be returned to unauthorized clients.
+// For the same reason, the token bucket and the last result are shared by all handlers that use the returned
+// middleware, so create a separate RateLimit middleware for each handler (and do not use it with
+// health.NewMultiHandler, which applies the same middleware to all of its Checkers).
+func RateLimit(rps float64, burst int, options ...RateLimitOption) health.Middleware {
+	cfg := rateLimitConfig{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	// The handler creates a new middleware chain for each request, so the state must be kept outside of it.
+	var (
+		mtx        sync.Mutex
+		lastResult *health.CheckerResult
+		bucket     = newTokenBucket(rps, burst)
+	)
+
+	return func(next health.MiddlewareFunc) health.MiddlewareFunc {
+		return func(r *http.Request) health.CheckerResult {
+			if now := time.Now(); !bucket.take(now) {
+				if cfg.reject {
+					retryAfter := int64(math.Max(1, math.Ceil(bucket.retryAfter(now).Seconds())))
+					header := http.Header{"Retry-After": {strconv.FormatInt(retryAfter, 10)}}
+					if health.OverrideResponse(r, http.StatusTooManyRequests, header) {
+						return health.CheckerResult{Status: health.StatusUnknown}
+					}
+				}
+
+				mtx.Lock()
+				cachedResult := lastResult
+				mtx.Unlock()
+
+				if cachedResult != nil {
+					return *cachedResult
+				}
+			}
+
+			result := next(r)
+
+			mtx.Lock()
+			lastResult = &result
+			mtx.Unlock()
+
+			return result
+		}
+	}
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst)}
+}
+
+func (b *tokenBucket) take(now time.Time) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if !b.lastTime.IsZero() {
+		b.tokens += now.Sub(b.lastTime).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.lastTime = now
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// retryAfter returns the duration until the bucket will contain a token again.
+func (b *tokenBucket) retryAfter(now time.Time) time.Duration {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if b.tokens >= 1 {
+		return 0
+	}
+	if b.rate <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+
+	missing := 1 - b.tokens - now.Sub(b.lastTime).Seconds()*b.rate
+	return time.Duration(missing / b.rate * float64(time.Second))
+}
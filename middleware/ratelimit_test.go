@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitReturnsCachedResultWhenLimitExceeded(t *testing.T) {
+	// Arrange
+	calls := 0
+	handler := RateLimit(0.001, 1)(func(r *http.Request) health.CheckerResult {
+		calls++
+		return health.CheckerResult{Status: health.StatusUp}
+	})
+	r := httptest.NewRequest(http.MethodGet, "/health", nil)
+
+	// Act
+	first := handler(r)
+	second := handler(r)
+
+	// Assert
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, first, second)
+}
+
+func TestTokenBucketRefill(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	bucket := newTokenBucket(1, 1)
+
+	// Act & Assert
+	assert.True(t, bucket.take(now))
+	assert.False(t, bucket.take(now.Add(500*time.Millisecond)))
+	assert.True(t, bucket.take(now.Add(1500*time.Millisecond)))
+}
+
+func TestRateLimitWithRejection(t *testing.T) {
+	// Arrange
+	checker := health.NewChecker(health.WithDisabledAutostart())
+	handler := health.NewHandler(checker, health.WithMiddleware(RateLimit(0.5, 1, WithRateLimitRejection())))
+
+	doRequest := func() *httptest.ResponseRecorder {
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/health", nil))
+		return response
+	}
+
+	// Act
+	first := doRequest()
+	second := doRequest()
+
+	// Assert
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Empty(t, first.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusTooManyRequests, second.Code)
+	assert.Equal(t, "2", second.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"status":"unknown"}`, second.Body.String())
+}
+
+func TestTokenBucketRetryAfter(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	bucket := newTokenBucket(2, 1)
+
+	// Act & Assert
+	assert.Equal(t, time.Duration(0), bucket.retryAfter(now))
+	assert.True(t, bucket.take(now))
+	assert.Equal(t, 500*time.Millisecond, bucket.retryAfter(now))
+	assert.Equal(t, 250*time.Millisecond, bucket.retryAfter(now.Add(250*time.Millisecond)))
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"github.com/alexliesenfeld/health"
+	"net/http"
+)
+
+// LabelSelector selects components based on their labels (see health.Check.Labels). A component is selected
+// if its labels contain all key/value pairs of the LabelSelector. An empty LabelSelector selects all components.
+type LabelSelector map[string]string
+
+// RoleBasedDetails is a middleware that removes all components from the check details that are not visible to
+// the roles of the requester. The roles are extracted from the request by the function that is passed in argument
+// 'rolesFunc' (e.g., from claims of an authentication token that a preceding middleware has stored in the request
+// context). Argument 'selectors' defines which components are visible to a role: a component is visible if the
+// LabelSelector of at least one of the requesters roles selects it. The aggregated availability status is always
+// visible to all clients.
+//
+// Example: The following configuration allows requesters with role "dba" to see all checks with label
+// "type" set to "database", whereas requesters with role "admin" can see all checks:
+//
+//	middleware.RoleBasedDetails(rolesFunc, map[string]middleware.LabelSelector{
+//		"dba":   {"type": "database"},
+//		"admin": {},
+//	})
+func RoleBasedDetails(rolesFunc func(r *http.Request) []string, selectors map[string]LabelSelector) health.Middleware {
+	return func(next health.MiddlewareFunc) health.MiddlewareFunc {
+		return func(r *http.Request) health.CheckerResult {
+			varyOnCredentials(r)
+			var roleSelectors []LabelSelector
+			for _, role := range rolesFunc(r) {
+				if selector, ok := selectors[role]; ok {
+					roleSelectors = append(roleSelectors, selector)
+				}
+			}
+
+			result := next(r)
+			result.Details = selectDetails(result.Details, roleSelectors)
+			return result
+		}
+	}
+}
+
+func selectDetails(details map[string]health.CheckResult, selectors []LabelSelector) map[string]health.CheckResult {
+	var target map[string]health.CheckResult
+
+	for name, checkResult := range details {
+		for _, selector := range selectors {
+			if selector.matches(checkResult.Labels) {
+				if target == nil {
+					target = make(map[string]health.CheckResult, len(details))
+				}
+				target[name] = checkResult
+				break
+			}
+		}
+	}
+
+	return target
+}
+
+func (s LabelSelector) matches(labels map[string]string) bool {
+	for key, value := range s {
+		if labelValue, ok := labels[key]; !ok || labelValue != value {
+			return false
+		}
+	}
+	return true
+}
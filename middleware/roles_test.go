@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func doTestRoleBasedDetails(t *testing.T, roles []string, expectedComponents []string) {
+	// Arrange
+	r := httptest.NewRequest(http.MethodGet, "/health", nil)
+	handler := RoleBasedDetails(func(r *http.Request) []string {
+		return roles
+	}, map[string]LabelSelector{
+		"dba":   {"type": "database"},
+		"admin": {},
+	})(func(r *http.Request) health.CheckerResult {
+		return health.CheckerResult{
+			Status: health.StatusUp,
+			Details: map[string]health.CheckResult{
+				"postgres": {Status: health.StatusUp, Labels: map[string]string{"type": "database"}},
+				"search":   {Status: health.StatusUp, Labels: map[string]string{"type": "http"}},
+			},
+		}
+	})
+
+	// Act
+	result := handler(r)
+
+	// Assert
+	assert.Equal(t, health.StatusUp, result.Status)
+	assert.Equal(t, len(expectedComponents), len(result.Details))
+	for _, name := range expectedComponents {
+		assert.Contains(t, result.Details, name)
+	}
+}
+
+func TestRoleBasedDetailsSelectedByLabel(t *testing.T) {
+	doTestRoleBasedDetails(t, []string{"dba"}, []string{"postgres"})
+}
+
+func TestRoleBasedDetailsEmptySelector(t *testing.T) {
+	doTestRoleBasedDetails(t, []string{"dba", "admin"}, []string{"postgres", "search"})
+}
+
+func TestRoleBasedDetailsUnknownRole(t *testing.T) {
+	doTestRoleBasedDetails(t, []string{"guest"}, nil)
+}
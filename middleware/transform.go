@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"github.com/alexliesenfeld/health"
+	"net/http"
+)
+
+// TransformResult is a middleware that applies the provided function to every health check result before
+// it is written into the HTTP response. This allows reshaping results (such as renaming components,
+// removing info values or collapsing groups of components) without writing a full middleware.
+// Attention: The transform function should not modify the maps of the result it receives, but
+// create new maps instead, because they might be shared with the health.Checker.
+// If the transform function returns a result without a status (such as an empty health.CheckerResult),
+// the status is set to health.StatusUnknown, so that the request is not answered as successful.
+func TransformResult(transform func(result health.CheckerResult) health.CheckerResult) health.Middleware {
+	return func(next health.MiddlewareFunc) health.MiddlewareFunc {
+		return func(r *http.Request) health.CheckerResult {
+			result := transform(next(r))
+			if result.Status == "" {
+				result.Status = health.StatusUnknown
+			}
+			return result
+		}
+	}
+}
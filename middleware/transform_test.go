@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformResultRunsAfterCheck(t *testing.T) {
+	// Arrange
+	var checked int32
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name: "db",
+			Check: func(ctx context.Context) error {
+				atomic.StoreInt32(&checked, 1)
+				return fmt.Errorf("db is not reachable")
+			},
+		}),
+	)
+
+	var checkedBeforeTransform bool
+	var received health.CheckerResult
+	transform := TransformResult(func(result health.CheckerResult) health.CheckerResult {
+		checkedBeforeTransform = atomic.LoadInt32(&checked) == 1
+		received = result
+		return result
+	})
+	handler := health.NewHandler(checker, health.WithMiddleware(transform))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.True(t, checkedBeforeTransform)
+	assert.Equal(t, health.StatusDown, received.Status)
+	assert.EqualError(t, received.Details["db"].Error, "db is not reachable")
+}
+
+func TestTransformResultChangesResponse(t *testing.T) {
+	// Arrange
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name: "optional",
+			Check: func(ctx context.Context) error {
+				return fmt.Errorf("optional dependency is not reachable")
+			},
+		}),
+	)
+
+	doRequest := func(transform func(result health.CheckerResult) health.CheckerResult) *httptest.ResponseRecorder {
+		handler := health.NewHandler(checker, health.WithMiddleware(TransformResult(transform)))
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/health", nil))
+		return response
+	}
+
+	// Act
+	changedStatus := doRequest(func(result health.CheckerResult) health.CheckerResult {
+		result.Status = health.StatusUp
+		return result
+	})
+	withoutDetails := doRequest(func(result health.CheckerResult) health.CheckerResult {
+		result.Details = nil
+		return result
+	})
+	empty := doRequest(func(result health.CheckerResult) health.CheckerResult {
+		return health.CheckerResult{}
+	})
+
+	// Assert
+	assert.Equal(t, http.StatusOK, changedStatus.Code)
+	assert.Contains(t, changedStatus.Body.String(), `"status":"up"`)
+
+	assert.Equal(t, http.StatusServiceUnavailable, withoutDetails.Code)
+	assert.JSONEq(t, `{"status":"down"}`, withoutDetails.Body.String())
+
+	assert.Equal(t, http.StatusServiceUnavailable, empty.Code)
+	assert.JSONEq(t, `{"status":"unknown"}`, empty.Body.String())
+}
@@ -0,0 +1,137 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/alexliesenfeld/health"
+)
+
+type (
+	// MessageFunc creates the message text of a notification for an event.
+	MessageFunc func(event Event) (string, error)
+
+	// Option is a configuration option for the notifiers of this package.
+	Option func(cfg *config)
+
+	config struct {
+		client        *http.Client
+		message       MessageFunc
+		priorityLabel string
+	}
+)
+
+// WithHTTPClient sets the http.Client that is used to send notifications. By default, http.DefaultClient is used.
+func WithHTTPClient(client *http.Client) Option {
+	return func(cfg *config) {
+		cfg.client = client
+	}
+}
+
+// WithMessage sets the function that creates the message text of notifications (see TemplateMessage).
+// By default, DefaultMessage is used.
+func WithMessage(message MessageFunc) Option {
+	return func(cfg *config) {
+		cfg.message = message
+	}
+}
+
+// DefaultMessage creates a short, human-readable message text for an event, such as
+// "Component database changed status from up to down: connection refused" or
+// "System changed status from up to down (down: database, search)".
+func DefaultMessage(event Event) (string, error) {
+	if event.Component != "" {
+		msg := fmt.Sprintf("Component %s changed status from %s to %s", event.Component, event.From, event.To)
+		if event.Error != nil {
+			msg += ": " + event.Error.Error()
+		}
+		return msg, nil
+	}
+
+	msg := fmt.Sprintf("System changed status from %s to %s", event.From, event.To)
+	if failed := failedComponents(event); len(failed) > 0 {
+		msg += fmt.Sprintf(" (%s: %s)", event.To, strings.Join(failed, ", "))
+	}
+	return msg, nil
+}
+
+// TemplateMessage creates a MessageFunc that renders message texts using the provided text/template
+// (e.g., "{{.Component}} is {{.To}}{{if .Error}}: {{.Error}}{{end}}"). The template is executed with the Event.
+func TemplateMessage(text string) (MessageFunc, error) {
+	tmpl, err := template.New("message").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse message template: %w", err)
+	}
+
+	return func(event Event) (string, error) {
+		buf := strings.Builder{}
+		if err := tmpl.Execute(&buf, event); err != nil {
+			return "", fmt.Errorf("cannot execute message template: %w", err)
+		}
+		return buf.String(), nil
+	}, nil
+}
+
+func newConfig(options []Option) config {
+	cfg := config{client: http.DefaultClient, message: DefaultMessage, priorityLabel: opsgeniePriorityLabel}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// failedComponents returns the sorted names of all components of a system event that have the same
+// status as the system.
+func failedComponents(event Event) []string {
+	if event.To == health.StatusUp {
+		return nil
+	}
+
+	names := make([]string, 0)
+	for name, state := range event.Components {
+		if state.Status == event.To {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// postJSON sends the JSON encoded payload to the provided URL and expects a 2xx response.
+func postJSON(ctx context.Context, client *http.Client, url string, header http.Header, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("cannot encode notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("notification endpoint responded with status code %d: %s", resp.StatusCode, msg)
+	}
+
+	return nil
+}
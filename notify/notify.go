@@ -0,0 +1,206 @@
+// Package notify provides notifiers that inform about availability status changes of a system
+// or its components (e.g., by posting messages to Slack or Microsoft Teams, or by creating alerts in Opsgenie).
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+)
+
+type (
+	// Event describes a change of the availability status of the system or one of its components.
+	Event struct {
+		// Component is the name of the component whose status has changed.
+		// It is empty if the aggregated system status has changed.
+		Component string
+		// From is the availability status before the change.
+		From health.AvailabilityStatus
+		// To is the availability status after the change.
+		To health.AvailabilityStatus
+		// Error is the error of the last check of the component (nil if successful or for system events).
+		Error error
+		// Labels holds the labels of the component (see health.Check.Labels). It is not set for system events.
+		Labels map[string]string
+		// Timestamp is the time of when the change was detected.
+		Timestamp time.Time
+		// Components holds the state of all components. It is only set for system events.
+		Components map[string]health.CheckState
+	}
+
+	// Notifier sends notifications about availability status changes.
+	Notifier interface {
+		Notify(ctx context.Context, event Event) error
+	}
+
+	// NotifierFunc is an adapter that allows using an ordinary function as a Notifier.
+	NotifierFunc func(ctx context.Context, event Event) error
+
+	// queue sends events to a notifier one after another in the order in which they were dispatched.
+	queue struct {
+		notifier Notifier
+		events   chan Event
+		start    sync.Once
+	}
+
+	jsonEvent struct {
+		Component  string                               `json:"component,omitempty"`
+		From       health.AvailabilityStatus            `json:"from"`
+		To         health.AvailabilityStatus            `json:"to"`
+		Error      string                               `json:"error,omitempty"`
+		Timestamp  time.Time                            `json:"timestamp"`
+		Components map[string]health.AvailabilityStatus `json:"components,omitempty"`
+	}
+)
+
+const (
+	// notifyTimeout is the maximum duration of sending a single notification.
+	notifyTimeout = 30 * time.Second
+	// queueSize is the maximum number of events that wait to be sent to a notifier.
+	queueSize = 100
+)
+
+// Notify calls f(ctx, event).
+func (f NotifierFunc) Notify(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// MarshalJSON provides a custom marshaller for the Event type, so that events can be published
+// to message brokers (the error is converted into its message and only the status of each component is kept).
+func (e Event) MarshalJSON() ([]byte, error) {
+	event := jsonEvent{
+		Component: e.Component,
+		From:      e.From,
+		To:        e.To,
+		Timestamp: e.Timestamp,
+	}
+
+	if e.Error != nil {
+		event.Error = e.Error.Error()
+	}
+
+	if len(e.Components) > 0 {
+		event.Components = make(map[string]health.AvailabilityStatus, len(e.Components))
+		for name, state := range e.Components {
+			event.Components[name] = state.Status
+		}
+	}
+
+	return json.Marshal(event)
+}
+
+// SystemTransitions creates a status listener that notifies the provided Notifier whenever the aggregated
+// system status changes. Use it with health.WithStatusListener.
+// Notifications are sent asynchronously and in order, so that health checks are not blocked by slow notifiers.
+// If the notifier falls behind by more than 100 events, further events are dropped until it catches up.
+// Errors and dropped events are logged using the standard logger (see package log).
+func SystemTransitions(notifier Notifier) func(ctx context.Context, state health.CheckerState) {
+	var (
+		mtx      sync.Mutex
+		previous = health.StatusUnknown
+		q        = newQueue(notifier)
+	)
+
+	return func(ctx context.Context, state health.CheckerState) {
+		mtx.Lock()
+		from := previous
+		previous = state.Status
+		mtx.Unlock()
+
+		if from == state.Status {
+			return
+		}
+
+		components := make(map[string]health.CheckState, len(state.CheckState))
+		for name, checkState := range state.CheckState {
+			components[name] = checkState
+		}
+
+		q.dispatch(Event{
+			From:       from,
+			To:         state.Status,
+			Timestamp:  time.Now().UTC(),
+			Components: components,
+		})
+	}
+}
+
+// ComponentTransitions creates an interceptor that notifies the provided Notifier whenever the availability
+// status of a component changes. If component names are provided, only changes of these components are notified.
+// Use it with health.WithInterceptors or health.Check.Interceptors.
+// Notifications are sent asynchronously and in order, so that health checks are not blocked by slow notifiers.
+// If the notifier falls behind by more than 100 events, further events are dropped until it catches up.
+// Errors and dropped events are logged using the standard logger (see package log).
+func ComponentTransitions(notifier Notifier, components ...string) health.Interceptor {
+	q := newQueue(notifier)
+
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			result := next(ctx, name, state)
+
+			if state.Status != result.Status && (len(components) == 0 || contains(components, name)) {
+				q.dispatch(Event{
+					Component: name,
+					From:      state.Status,
+					To:        result.Status,
+					Error:     result.Result,
+					Labels:    health.CheckLabels(ctx),
+					Timestamp: time.Now().UTC(),
+				})
+			}
+
+			return result
+		}
+	}
+}
+
+func newQueue(notifier Notifier) *queue {
+	return &queue{notifier: notifier, events: make(chan Event, queueSize)}
+}
+
+// dispatch enqueues the event without blocking. The worker goroutine is started with the first event.
+func (q *queue) dispatch(event Event) {
+	q.start.Do(func() { go q.run() })
+
+	select {
+	case q.events <- event:
+	default:
+		log.Printf("dropping health status notification (%s: %s -> %s), because the notifier is too slow",
+			componentName(event), event.From, event.To)
+	}
+}
+
+func (q *queue) run() {
+	for event := range q.events {
+		q.send(event)
+	}
+}
+
+func (q *queue) send(event Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+
+	if err := q.notifier.Notify(ctx, event); err != nil {
+		log.Printf("cannot send health status notification: %v", err)
+	}
+}
+
+func componentName(event Event) string {
+	if event.Component == "" {
+		return "system"
+	}
+	return event.Component
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
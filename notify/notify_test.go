@@ -0,0 +1,206 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func channelNotifier() (Notifier, chan Event) {
+	events := make(chan Event, 10)
+	return NotifierFunc(func(ctx context.Context, event Event) error {
+		events <- event
+		return nil
+	}), events
+}
+
+func receive(t *testing.T, events chan Event) Event {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(1 * time.Second):
+		require.Fail(t, "no event received")
+		return Event{}
+	}
+}
+
+func TestComponentTransitionsNotifiesSelectedComponents(t *testing.T) {
+	// Arrange
+	notifier, events := channelNotifier()
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(ComponentTransitions(notifier, "database")),
+		health.WithCheck(health.Check{
+			Name:   "database",
+			Labels: map[string]string{"priority": "P1"},
+			Check: func(ctx context.Context) error {
+				return fmt.Errorf("connection refused")
+			},
+		}),
+		health.WithCheck(health.Check{
+			Name: "search",
+			Check: func(ctx context.Context) error {
+				return fmt.Errorf("timeout")
+			},
+		}),
+	)
+
+	// Act
+	checker.Check(context.Background())
+
+	// Assert
+	event := receive(t, events)
+	assert.Equal(t, "database", event.Component)
+	assert.Equal(t, health.StatusUnknown, event.From)
+	assert.Equal(t, health.StatusDown, event.To)
+	assert.Equal(t, map[string]string{"priority": "P1"}, event.Labels)
+	assert.Len(t, events, 0)
+}
+
+func TestSystemTransitionsNotifiesStatusChanges(t *testing.T) {
+	// Arrange
+	notifier, events := channelNotifier()
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithStatusListener(SystemTransitions(notifier)),
+		health.WithCheck(health.Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				return fmt.Errorf("connection refused")
+			},
+		}),
+	)
+
+	// Act
+	checker.Check(context.Background())
+
+	// Assert
+	event := receive(t, events)
+	msg, err := DefaultMessage(event)
+	require.NoError(t, err)
+	assert.Equal(t, "System changed status from unknown to down (down: database)", msg)
+}
+
+func TestQueueSendsEventsInOrderAndDropsOverflow(t *testing.T) {
+	// Arrange
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var received []string
+	done := make(chan struct{})
+	q := newQueue(NotifierFunc(func(ctx context.Context, event Event) error {
+		if len(received) == 0 {
+			close(started)
+			<-release
+		}
+		received = append(received, event.Component)
+		if len(received) == queueSize+1 {
+			close(done)
+		}
+		return nil
+	}))
+
+	// Act
+	q.dispatch(Event{Component: "c0"})
+	<-started // The worker is busy with the first event, so the following ones are queued.
+	for i := 1; i <= queueSize+1; i++ {
+		q.dispatch(Event{Component: fmt.Sprintf("c%d", i)})
+	}
+	close(release)
+	<-done
+
+	// Assert
+	require.Len(t, received, queueSize+1)
+	for i, component := range received {
+		assert.Equal(t, fmt.Sprintf("c%d", i), component)
+	}
+	assert.Len(t, q.events, 0)
+}
+
+func TestThrottleSendsLatestEventAfterInterval(t *testing.T) {
+	// Arrange
+	notifier, events := channelNotifier()
+	throttled := Throttle(notifier, 50*time.Millisecond)
+
+	// Act
+	_ = throttled.Notify(context.Background(), Event{Component: "db", From: health.StatusUp, To: health.StatusDown})
+	_ = throttled.Notify(context.Background(), Event{Component: "db", From: health.StatusDown, To: health.StatusUp})
+	_ = throttled.Notify(context.Background(), Event{Component: "db", From: health.StatusUp, To: health.StatusDown})
+	_ = throttled.Notify(context.Background(), Event{Component: "db", From: health.StatusDown, To: health.StatusUp})
+
+	// Assert
+	assert.Equal(t, health.StatusDown, receive(t, events).To)
+	last := receive(t, events)
+	assert.Equal(t, health.StatusDown, last.From)
+	assert.Equal(t, health.StatusUp, last.To)
+	assert.Len(t, events, 0)
+}
+
+func TestThrottleSendsFlushedEventsInOrder(t *testing.T) {
+	// Arrange
+	var (
+		started  = make(chan struct{})
+		release  = make(chan struct{})
+		done     = make(chan struct{})
+		received []string
+	)
+	throttled := Throttle(NotifierFunc(func(ctx context.Context, event Event) error {
+		if len(received) == 0 {
+			close(started)
+			<-release
+		}
+		received = append(received, fmt.Sprintf("%s:%s", event.Component, event.To))
+		if len(received) == 3 {
+			close(done)
+		}
+		return nil
+	}), 20*time.Millisecond)
+	q := throttled.(*throttledNotifier).queue
+
+	// Act
+	_ = throttled.Notify(context.Background(), Event{Component: "db", From: health.StatusUp, To: health.StatusDown})
+	<-started // The notifier is busy with the first event, so the following ones are queued.
+	_ = throttled.Notify(context.Background(), Event{Component: "db", From: health.StatusDown, To: health.StatusUp})
+	require.Eventually(t, func() bool { return len(q.events) == 1 }, time.Second, time.Millisecond)
+	_ = throttled.Notify(context.Background(), Event{Component: "cache", From: health.StatusUp, To: health.StatusDown})
+	close(release)
+	<-done
+
+	// Assert
+	assert.Equal(t, []string{"db:down", "db:up", "cache:down"}, received)
+}
+
+func TestTemplateMessage(t *testing.T) {
+	// Arrange
+	message, err := TemplateMessage("{{.Component}} is {{.To}}{{if .Error}}: {{.Error}}{{end}}")
+	require.NoError(t, err)
+
+	// Act
+	msg, err := message(Event{Component: "db", To: health.StatusDown, Error: fmt.Errorf("timeout")})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "db is down: timeout", msg)
+}
+
+func TestEventMarshalJSON(t *testing.T) {
+	// Arrange
+	event := Event{
+		From:       health.StatusUp,
+		To:         health.StatusDown,
+		Timestamp:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Components: map[string]health.CheckState{"db": {Status: health.StatusDown, Result: fmt.Errorf("timeout")}},
+	}
+
+	// Act
+	data, err := json.Marshal(event)
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"from":"up","to":"down","timestamp":"2024-01-02T03:04:05Z","components":{"db":"down"}}`, string(data))
+}
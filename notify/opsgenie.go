@@ -0,0 +1,118 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/alexliesenfeld/health"
+)
+
+const (
+	// OpsgenieAPIURL is the URL of the Opsgenie API.
+	OpsgenieAPIURL = "https://api.opsgenie.com"
+	// OpsgenieEUAPIURL is the URL of the Opsgenie API for accounts in the EU region.
+	OpsgenieEUAPIURL = "https://api.eu.opsgenie.com"
+
+	// opsgenieDefaultPriority is the alert priority of components that do not have a configured priority.
+	opsgenieDefaultPriority = "P3"
+	// opsgenieSystemPriority is the alert priority of the aggregated system status.
+	opsgenieSystemPriority = "P1"
+	// opsgeniePriorityLabel is the default label that holds the alert priority of a component.
+	opsgeniePriorityLabel = "priority"
+)
+
+// Opsgenie is a Notifier that creates an alert in Opsgenie (https://www.atlassian.com/software/opsgenie) when the
+// system or a component goes down and closes the alert when it is up again. Alerts are identified by the alias
+// "health-system" (for the aggregated system status) or "health-<component name>", so that Opsgenie deduplicates
+// alerts of the same component.
+type Opsgenie struct {
+	apiURL     string
+	apiKey     string
+	priorities map[string]string
+	cfg        config
+}
+
+// WithPriorityLabel sets the key of the check label (see health.Check.Labels) that holds the Opsgenie alert
+// priority of a component ("P1" to "P5"). Default is "priority". This option is only used by Opsgenie.
+func WithPriorityLabel(key string) Option {
+	return func(cfg *config) {
+		cfg.priorityLabel = key
+	}
+}
+
+// NewOpsgenie creates a new Opsgenie notifier that uses the Opsgenie API at the provided URL (see OpsgenieAPIURL)
+// with the provided API key. The alert priority ("P1" to "P5") of a component is read from the labels of its
+// check (see WithPriorityLabel). Argument 'priorities' maps component names to alert priorities for checks
+// without a valid priority label and may be nil. Alerts of components without a priority are created with
+// priority "P3". Alerts about the aggregated system status are always created with priority "P1".
+func NewOpsgenie(apiURL, apiKey string, priorities map[string]string, options ...Option) *Opsgenie {
+	return &Opsgenie{
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+		apiKey:     apiKey,
+		priorities: priorities,
+		cfg:        newConfig(options),
+	}
+}
+
+// Notify implements Notifier.
+func (o *Opsgenie) Notify(ctx context.Context, event Event) error {
+	msg, err := o.cfg.message(event)
+	if err != nil {
+		return err
+	}
+
+	alias := "health-system"
+	priority := opsgenieSystemPriority
+	if event.Component != "" {
+		alias = "health-" + event.Component
+		priority = o.priority(event)
+	}
+
+	header := http.Header{"Authorization": {"GenieKey " + o.apiKey}}
+
+	switch event.To {
+	case health.StatusDown:
+		payload := map[string]interface{}{
+			"message":  truncate(msg, 130),
+			"alias":    alias,
+			"priority": priority,
+			"tags":     []string{"health"},
+		}
+		if event.Error != nil {
+			payload["description"] = event.Error.Error()
+		}
+		return postJSON(ctx, o.cfg.client, o.apiURL+"/v2/alerts", header, payload)
+	case health.StatusUp:
+		endpoint := fmt.Sprintf("%s/v2/alerts/%s/close?identifierType=alias", o.apiURL, url.PathEscape(alias))
+		return postJSON(ctx, o.cfg.client, endpoint, header, map[string]string{"note": msg})
+	default:
+		return nil
+	}
+}
+
+// priority returns the alert priority of a component event.
+func (o *Opsgenie) priority(event Event) string {
+	if p := event.Labels[o.cfg.priorityLabel]; isOpsgeniePriority(p) {
+		return p
+	}
+	if p, ok := o.priorities[event.Component]; ok {
+		return p
+	}
+	return opsgenieDefaultPriority
+}
+
+func isOpsgeniePriority(p string) bool {
+	return len(p) == 2 && p[0] == 'P' && p[1] >= '1' && p[1] <= '5'
+}
+
+// truncate shortens the provided string to at most n runes.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
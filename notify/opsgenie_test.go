@@ -0,0 +1,85 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doTestOpsgenie(t *testing.T, event Event, expectedPath string, expectedPayload map[string]interface{}) {
+	// Arrange
+	var (
+		path    string
+		payload map[string]interface{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GenieKey secret", r.Header.Get("Authorization"))
+		path = r.URL.RequestURI()
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	opsgenie := NewOpsgenie(server.URL, "secret", map[string]string{"database": "P2"})
+
+	// Act
+	err := opsgenie.Notify(context.Background(), event)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, expectedPath, path)
+	assert.Equal(t, expectedPayload, payload)
+}
+
+func TestOpsgenieCreatesAlertWhenDown(t *testing.T) {
+	doTestOpsgenie(t,
+		Event{Component: "database", From: health.StatusUp, To: health.StatusDown, Error: fmt.Errorf("timeout")},
+		"/v2/alerts",
+		map[string]interface{}{
+			"message":     "Component database changed status from up to down: timeout",
+			"alias":       "health-database",
+			"priority":    "P2",
+			"description": "timeout",
+			"tags":        []interface{}{"health"},
+		})
+}
+
+func TestOpsgenieReadsPriorityFromLabels(t *testing.T) {
+	doTestOpsgenie(t,
+		Event{Component: "database", From: health.StatusUp, To: health.StatusDown, Labels: map[string]string{"priority": "P1"}},
+		"/v2/alerts",
+		map[string]interface{}{
+			"message":  "Component database changed status from up to down",
+			"alias":    "health-database",
+			"priority": "P1",
+			"tags":     []interface{}{"health"},
+		})
+}
+
+func TestOpsgenieFallsBackToPriorityMapping(t *testing.T) {
+	doTestOpsgenie(t,
+		Event{Component: "database", From: health.StatusUp, To: health.StatusDown, Labels: map[string]string{"priority": "high"}},
+		"/v2/alerts",
+		map[string]interface{}{
+			"message":  "Component database changed status from up to down",
+			"alias":    "health-database",
+			"priority": "P2",
+			"tags":     []interface{}{"health"},
+		})
+}
+
+func TestOpsgenieClosesAlertWhenUp(t *testing.T) {
+	doTestOpsgenie(t,
+		Event{Component: "search", From: health.StatusDown, To: health.StatusUp},
+		"/v2/alerts/health-search/close?identifierType=alias",
+		map[string]interface{}{
+			"note": "Component search changed status from down to up",
+		})
+}
@@ -0,0 +1,42 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/alexliesenfeld/health"
+)
+
+// Slack is a Notifier that posts messages to a Slack channel using an incoming webhook
+// (https://api.slack.com/messaging/webhooks).
+type Slack struct {
+	webhookURL string
+	cfg        config
+}
+
+// NewSlack creates a new Slack notifier that posts messages to the provided incoming webhook URL.
+func NewSlack(webhookURL string, options ...Option) *Slack {
+	return &Slack{webhookURL: webhookURL, cfg: newConfig(options)}
+}
+
+// Notify implements Notifier.
+func (s *Slack) Notify(ctx context.Context, event Event) error {
+	msg, err := s.cfg.message(event)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]string{"text": slackEmoji(event.To) + " " + msg}
+
+	return postJSON(ctx, s.cfg.client, s.webhookURL, nil, payload)
+}
+
+func slackEmoji(status health.AvailabilityStatus) string {
+	switch status {
+	case health.StatusUp:
+		return ":large_green_circle:"
+	case health.StatusDown:
+		return ":red_circle:"
+	default:
+		return ":white_circle:"
+	}
+}
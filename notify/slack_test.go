@@ -0,0 +1,47 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlackPostsMessage(t *testing.T) {
+	// Arrange
+	var payload map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+	}))
+	defer server.Close()
+
+	slack := NewSlack(server.URL)
+
+	// Act
+	err := slack.Notify(context.Background(), Event{Component: "db", From: health.StatusUp, To: health.StatusDown})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ":red_circle: Component db changed status from up to down", payload["text"])
+}
+
+func TestSlackReturnsErrorOnFailedRequest(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("invalid_token"))
+	}))
+	defer server.Close()
+
+	// Act
+	err := NewSlack(server.URL).Notify(context.Background(), Event{Component: "db"})
+
+	// Assert
+	assert.EqualError(t, err, "notification endpoint responded with status code 403: invalid_token")
+}
@@ -0,0 +1,74 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexliesenfeld/health"
+)
+
+// Teams is a Notifier that posts adaptive cards (https://adaptivecards.io) to a Microsoft Teams channel
+// using an incoming webhook.
+type Teams struct {
+	webhookURL string
+	cfg        config
+}
+
+// NewTeams creates a new Teams notifier that posts messages to the provided incoming webhook URL.
+func NewTeams(webhookURL string, options ...Option) *Teams {
+	return &Teams{webhookURL: webhookURL, cfg: newConfig(options)}
+}
+
+// Notify implements Notifier.
+func (t *Teams) Notify(ctx context.Context, event Event) error {
+	msg, err := t.cfg.message(event)
+	if err != nil {
+		return err
+	}
+
+	title := "System is " + string(event.To)
+	if event.Component != "" {
+		title = fmt.Sprintf("%s is %s", event.Component, event.To)
+	}
+
+	payload := map[string]interface{}{
+		"type": "message",
+		"attachments": []interface{}{
+			map[string]interface{}{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body": []interface{}{
+						map[string]interface{}{
+							"type":   "TextBlock",
+							"text":   title,
+							"size":   "Medium",
+							"weight": "Bolder",
+							"color":  teamsColor(event.To),
+						},
+						map[string]interface{}{
+							"type": "TextBlock",
+							"text": msg,
+							"wrap": true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return postJSON(ctx, t.cfg.client, t.webhookURL, nil, payload)
+}
+
+func teamsColor(status health.AvailabilityStatus) string {
+	switch status {
+	case health.StatusUp:
+		return "Good"
+	case health.StatusDown:
+		return "Attention"
+	default:
+		return "Warning"
+	}
+}
@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamsPostsAdaptiveCard(t *testing.T) {
+	// Arrange
+	var payload struct {
+		Attachments []struct {
+			ContentType string `json:"contentType"`
+			Content     struct {
+				Body []map[string]interface{} `json:"body"`
+			} `json:"content"`
+		} `json:"attachments"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+	}))
+	defer server.Close()
+
+	message, err := TemplateMessage("{{.Component}} went {{.To}}")
+	require.NoError(t, err)
+	teams := NewTeams(server.URL, WithMessage(message))
+
+	// Act
+	err = teams.Notify(context.Background(), Event{Component: "db", From: health.StatusUp, To: health.StatusDown})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, payload.Attachments, 1)
+	assert.Equal(t, "application/vnd.microsoft.card.adaptive", payload.Attachments[0].ContentType)
+	body := payload.Attachments[0].Content.Body
+	require.Len(t, body, 2)
+	assert.Equal(t, "db is down", body[0]["text"])
+	assert.Equal(t, "Attention", body[0]["color"])
+	assert.Equal(t, "db went down", body[1]["text"])
+}
@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+)
+
+type (
+	throttledNotifier struct {
+		queue      *queue
+		interval   time.Duration
+		mtx        sync.Mutex
+		components map[string]*throttleState
+	}
+
+	throttleState struct {
+		lastSentAt time.Time
+		lastStatus health.AvailabilityStatus
+		pending    *Event
+		timer      *time.Timer
+	}
+)
+
+// Throttle wraps the provided Notifier so that at most one notification per component (or the system) is sent
+// within the provided interval. This avoids spamming notification channels when a component is flapping.
+// Events that occur within the interval are not dropped silently: at the end of the interval, the most recent
+// event is sent if the status differs from the last notified status.
+// Notifications are passed to the wrapped Notifier asynchronously and in order, including the ones that are
+// sent at the end of an interval. Errors and dropped events are therefore logged using the standard logger
+// (see package log) instead of being returned.
+func Throttle(notifier Notifier, interval time.Duration) Notifier {
+	return &throttledNotifier{
+		queue:      newQueue(notifier),
+		interval:   interval,
+		components: map[string]*throttleState{},
+	}
+}
+
+// Notify implements Notifier.
+func (t *throttledNotifier) Notify(_ context.Context, event Event) error {
+	t.mtx.Lock()
+
+	state, ok := t.components[event.Component]
+	if !ok {
+		state = &throttleState{}
+		t.components[event.Component] = state
+	}
+
+	elapsed := time.Since(state.lastSentAt)
+	if state.timer == nil && elapsed >= t.interval {
+		state.lastSentAt = time.Now()
+		state.lastStatus = event.To
+		// Events are dispatched while holding the lock, so that their order in the queue matches the order in
+		// which they have been accepted (also with respect to flushed events).
+		t.queue.dispatch(event)
+		t.mtx.Unlock()
+		return nil
+	}
+
+	state.pending = &event
+	if state.timer == nil {
+		state.timer = time.AfterFunc(t.interval-elapsed, func() {
+			t.flush(event.Component)
+		})
+	}
+
+	t.mtx.Unlock()
+	return nil
+}
+
+func (t *throttledNotifier) flush(component string) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	state := t.components[component]
+	event := state.pending
+	state.pending = nil
+	state.timer = nil
+
+	if event == nil || event.To == state.lastStatus {
+		return
+	}
+
+	event.From = state.lastStatus
+	state.lastSentAt = time.Now()
+	state.lastStatus = event.To
+	t.queue.dispatch(*event)
+}
@@ -0,0 +1,137 @@
+// Package push provides a "dead man's switch" reporter that periodically pings an external monitoring service
+// (such as https://healthchecks.io or https://cronitor.io) while the system is healthy. In contrast to HTTP health
+// check endpoints that are polled by the monitoring service, this also covers situations in which the process
+// crashed or hangs: the monitoring service alerts as soon as the pings stop.
+package push
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+)
+
+type (
+	// Option is a configuration option for Start.
+	Option func(cfg *config)
+
+	config struct {
+		interval time.Duration
+		timeout  time.Duration
+		client   *http.Client
+		downURL  string
+	}
+)
+
+// WithInterval sets the interval between two pings. Default is 1 minute.
+func WithInterval(interval time.Duration) Option {
+	return func(cfg *config) {
+		cfg.interval = interval
+	}
+}
+
+// WithTimeout sets the timeout for checking the system health and sending a ping. Default is 10 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithHTTPClient sets the http.Client that is used to send pings. By default, http.DefaultClient is used.
+func WithHTTPClient(client *http.Client) Option {
+	return func(cfg *config) {
+		cfg.client = client
+	}
+}
+
+// WithDownURL sets a URL that is pinged while the system is not healthy (e.g., the "/fail" endpoint of
+// healthchecks.io), so that the monitoring service alerts immediately instead of waiting for missing pings.
+// By default, no ping is sent while the system is not healthy.
+func WithDownURL(url string) Option {
+	return func(cfg *config) {
+		cfg.downURL = url
+	}
+}
+
+// Start starts periodically checking the system health using the provided health.Checker and sends a ping
+// (an HTTP GET request) to the provided URL whenever the aggregated status is health.StatusUp.
+// The first check is performed immediately. Errors are logged using the standard logger (see package log).
+// The returned function stops sending pings and waits until a currently running ping has finished.
+func Start(checker health.Checker, url string, options ...Option) (stop func()) {
+	cfg := config{interval: 1 * time.Minute, timeout: 10 * time.Second, client: http.DefaultClient}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(cfg.interval)
+		defer ticker.Stop()
+
+		for {
+			report(ctx, checker, url, &cfg)
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+func report(ctx context.Context, checker health.Checker, url string, cfg *config) {
+	reqCtx, cancel := context.WithTimeout(ctx, cfg.timeout)
+	defer cancel()
+
+	target := url
+	if checker.Check(reqCtx).Status != health.StatusUp {
+		if cfg.downURL == "" {
+			return
+		}
+		target = cfg.downURL
+	}
+
+	if err := ping(reqCtx, cfg.client, target); err != nil {
+		if ctx.Err() != nil {
+			// The ping was aborted because Start has been stopped.
+			return
+		}
+		log.Printf("cannot send health ping: %v", err)
+	}
+}
+
+func ping(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("ping endpoint responded with status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
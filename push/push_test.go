@@ -0,0 +1,97 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func doTestStart(t *testing.T, checkErr error, expectedPath string) {
+	// Arrange
+	paths := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths <- r.URL.Path
+	}))
+	defer server.Close()
+
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name: "check",
+			Check: func(ctx context.Context) error {
+				return checkErr
+			},
+		}),
+	)
+
+	// Act
+	stop := Start(checker, server.URL+"/ping", WithInterval(10*time.Millisecond), WithDownURL(server.URL+"/fail"))
+	defer stop()
+
+	// Assert
+	select {
+	case path := <-paths:
+		assert.Equal(t, expectedPath, path)
+	case <-time.After(1 * time.Second):
+		assert.Fail(t, "no ping received")
+	}
+}
+
+func TestStartPingsWhileUp(t *testing.T) {
+	doTestStart(t, nil, "/ping")
+}
+
+func TestStartPingsDownURLWhileDown(t *testing.T) {
+	doTestStart(t, fmt.Errorf("check error"), "/fail")
+}
+
+type syncBuffer struct {
+	mtx sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.buf.String()
+}
+
+func TestStartLogsPingTimeouts(t *testing.T) {
+	// Arrange
+	output := &syncBuffer{}
+	log.SetOutput(output)
+	defer log.SetOutput(os.Stderr)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	checker := health.NewChecker(health.WithDisabledAutostart())
+
+	// Act
+	stop := Start(checker, server.URL+"/ping", WithInterval(time.Hour), WithTimeout(10*time.Millisecond))
+	defer stop()
+
+	// Assert
+	assert.Eventually(t, func() bool {
+		return strings.Contains(output.String(), "cannot send health ping")
+	}, 1*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,124 @@
+// Package statusfile provides a status listener that writes the aggregated health status into a file.
+// This allows processes that cannot (or should not) access the HTTP health check endpoint to read the
+// health status of a service, such as Kubernetes exec probes (e.g., "grep -q up /tmp/health"), cron
+// based monitors or administrators debugging on a host.
+package statusfile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/alexliesenfeld/health"
+)
+
+type (
+	// Writer writes the aggregated health status into a file whenever the status changes.
+	// The file is replaced atomically, so that readers never observe a partially written file.
+	Writer struct {
+		path     string
+		json     bool
+		fileMode os.FileMode
+		mtx      sync.Mutex
+	}
+
+	// Option is a configuration option for a Writer.
+	Option func(w *Writer)
+)
+
+// WithJSON configures the Writer to write the JSON representation of the health.CheckerResult (the same
+// format the handler responds with) instead of only the aggregated status (e.g., "up").
+func WithJSON() Option {
+	return func(w *Writer) {
+		w.json = true
+	}
+}
+
+// WithFileMode sets the permissions of the file. Default is 0644.
+func WithFileMode(mode os.FileMode) Option {
+	return func(w *Writer) {
+		w.fileMode = mode
+	}
+}
+
+// NewWriter creates a new Writer that writes into the file at the provided path.
+// The directory of the file must already exist.
+func NewWriter(path string, options ...Option) *Writer {
+	w := Writer{path: path, fileMode: 0644}
+	for _, opt := range options {
+		opt(&w)
+	}
+	return &w
+}
+
+// StatusListener returns a status listener that writes the file whenever the aggregated health status
+// changes. Use it with health.WithStatusListener. Errors are logged using the standard logger (see package log).
+func (w *Writer) StatusListener() func(ctx context.Context, state health.CheckerState) {
+	return func(ctx context.Context, state health.CheckerState) {
+		if err := w.Write(state); err != nil {
+			log.Printf("cannot write health status file: %v", err)
+		}
+	}
+}
+
+// Write writes the provided state into the file.
+func (w *Writer) Write(state health.CheckerState) error {
+	content, err := w.content(state)
+	if err != nil {
+		return err
+	}
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	// The content is written into a temporary file in the same directory first, which is renamed afterwards.
+	// Renaming is atomic on POSIX systems, so readers either see the old or the new content.
+	tmp, err := os.CreateTemp(filepath.Dir(w.path), "."+filepath.Base(w.path)+".*")
+	if err != nil {
+		return fmt.Errorf("cannot create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(content)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), w.fileMode)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), w.path)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot write file %q: %w", w.path, err)
+	}
+
+	return nil
+}
+
+func (w *Writer) content(state health.CheckerState) ([]byte, error) {
+	if !w.json {
+		return []byte(string(state.Status) + "\n"), nil
+	}
+
+	result := health.CheckerResult{Status: state.Status}
+	if len(state.CheckState) > 0 {
+		result.Details = make(map[string]health.CheckResult, len(state.CheckState))
+		for name, checkState := range state.CheckState {
+			result.Details[name] = health.CheckResult{
+				Status:    checkState.Status,
+				Timestamp: checkState.LastCheckedAt,
+				Error:     checkState.Result,
+			}
+		}
+	}
+
+	return json.Marshal(result)
+}
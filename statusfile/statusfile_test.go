@@ -0,0 +1,54 @@
+package statusfile
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusListenerWritesStatus(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "health")
+	listener := NewWriter(path).StatusListener()
+
+	// Act
+	listener(context.Background(), health.CheckerState{Status: health.StatusDown})
+	listener(context.Background(), health.CheckerState{Status: health.StatusUp})
+
+	// Assert
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "up\n", string(content))
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestWriteWithJSON(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "health.json")
+	writer := NewWriter(path, WithJSON(), WithFileMode(0600))
+
+	// Act
+	err := writer.Write(health.CheckerState{
+		Status:     health.StatusDown,
+		CheckState: map[string]health.CheckState{"database": {Status: health.StatusDown, Result: errors.New("connection refused")}},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"status":"down","details":{"database":{"status":"down","timestamp":"0001-01-01T00:00:00Z","error":"connection refused"}}}`, string(content))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}